@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	answer "go.hollow.sh/dnscontroller/pkg/api/v1/answers"
+	owner "go.hollow.sh/dnscontroller/pkg/api/v1/owners"
+)
+
+// ErrorNoTarget is when an answer command is run without --target
+var ErrorNoTarget = errors.New("--target is required")
+
+var answerCmd = &cobra.Command{
+	Use:   "answer",
+	Short: "inspects and changes the answers of records through the api",
+}
+
+var answerListCmd = &cobra.Command{
+	Use:   "list NAME TYPE",
+	Short: "lists the answers of a record",
+	Args:  cobra.ExactArgs(recordArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		answers, err := newAPIClient().ListAnswers(cmd.Context(), args[0], args[1])
+		if err != nil {
+			return err
+		}
+
+		return printOutput(cmd.OutOrStdout(), answers, func(w io.Writer) {
+			fmt.Fprintln(w, "TARGET\tTTL\tPORT\tPRIORITY\tWEIGHT\tOWNER\tHEALTHY\tEXPIRES")
+
+			for _, a := range answers {
+				fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+					a.Target, a.TTL, formatInt(a.Port), formatInt(a.Priority), formatInt(a.Weight),
+					formatOwner(a.Owner), formatHealth(a.Health), formatExpiry(a.ExpiresAt))
+			}
+		})
+	},
+}
+
+var answerAddCmd = &cobra.Command{
+	Use:   "add NAME TYPE",
+	Short: "adds an answer of the owner to a record, or replaces its ttl and details",
+	Long:  "adds an answer of the owner to a record, or replaces its ttl and details. The record and owner are created if they don't exist.",
+	Args:  cobra.ExactArgs(recordArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		o, a, err := answerFromFlags(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		c := newAPIClient()
+
+		if err := c.CreateAnswer(cmd.Context(), args[0], args[1], o, a); err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(cmd.OutOrStdout(), "added %s to %s %s\n", a.Target, args[0], args[1])
+
+		return err
+	},
+}
+
+var answerRemoveCmd = &cobra.Command{
+	Use:   "remove NAME TYPE",
+	Short: "removes an answer of the owner from a record",
+	Args:  cobra.ExactArgs(recordArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		o, a, err := answerFromFlags(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		if err := newAPIClient().DeleteAnswer(cmd.Context(), args[0], args[1], o, a); err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(cmd.OutOrStdout(), "removed %s from %s %s\n", a.Target, args[0], args[1])
+
+		return err
+	},
+}
+
+func init() {
+	root.Cmd.AddCommand(answerCmd)
+	addAPIFlags(answerCmd)
+
+	answerCmd.AddCommand(answerListCmd, answerAddCmd, answerRemoveCmd)
+
+	for _, c := range []*cobra.Command{answerAddCmd, answerRemoveCmd} {
+		addOwnerFlags(c)
+		c.Flags().String("target", "", "address, host name or text of the answer")
+	}
+
+	answerAddCmd.Flags().Int64("ttl", answer.DefaultTTL, "ttl of the answer in seconds")
+	answerAddCmd.Flags().Int64("port", 0, "port of a SRV answer")
+	answerAddCmd.Flags().Int64("priority", 0, "priority of a SRV answer, or preference of a MX answer")
+	answerAddCmd.Flags().Int64("weight", 0, "weight of a SRV answer")
+	answerAddCmd.Flags().String("protocol", "", "health check protocol of a SRV answer, one of tcp, tls, http or https")
+	answerAddCmd.Flags().Int64("flags", 0, "flags of a CAA answer")
+	answerAddCmd.Flags().String("tag", "", "tag of a CAA answer")
+	answerAddCmd.Flags().Int64("lease", 0, "lease of the answer in seconds, the answer never expires when 0")
+
+	for _, c := range []*cobra.Command{answerListCmd, answerAddCmd, answerRemoveCmd} {
+		// Errors are the API's rather than the usage's, Execute prints them
+		c.SilenceUsage = true
+		c.SilenceErrors = true
+	}
+}
+
+// addOwnerFlags adds the flags naming the owner of answers to a command
+func addOwnerFlags(cmd *cobra.Command) {
+	cmd.Flags().String("owner", "", "name of the owner of the answer")
+	cmd.Flags().String("origin", "cli", "origin of the owner of the answer")
+	cmd.Flags().String("service", "", "service of the owner of the answer")
+}
+
+// ownerFromFlags returns the owner of the owner flags
+func ownerFromFlags(flags *pflag.FlagSet) (*owner.Owner, error) {
+	o := &owner.Owner{}
+	o.Name, _ = flags.GetString("owner")
+	o.Origin, _ = flags.GetString("origin")
+	o.Service, _ = flags.GetString("service")
+
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+// answerFromFlags returns the owner and answer of the add and remove flags,
+// the details are only set when their flag is
+func answerFromFlags(flags *pflag.FlagSet) (*owner.Owner, *answer.Answer, error) {
+	o, err := ownerFromFlags(flags)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	a := &answer.Answer{}
+	a.Target, _ = flags.GetString("target")
+
+	if a.Target == "" {
+		return nil, nil, ErrorNoTarget
+	}
+
+	if flags.Lookup("ttl") != nil {
+		a.TTL, _ = flags.GetInt64("ttl")
+		a.Protocol, _ = flags.GetString("protocol")
+		a.Tag, _ = flags.GetString("tag")
+	}
+
+	for name, field := range map[string]**int64{
+		"port":     &a.Port,
+		"priority": &a.Priority,
+		"weight":   &a.Weight,
+		"flags":    &a.Flags,
+		"lease":    &a.Lease,
+	} {
+		if flags.Changed(name) {
+			v, _ := flags.GetInt64(name)
+			*field = &v
+		}
+	}
+
+	return o, a, nil
+}
+
+func formatOwner(o *owner.Owner) string {
+	if o == nil {
+		return "-"
+	}
+
+	return o.Name + "/" + o.Origin + "/" + o.Service
+}
+
+func formatHealth(h *answer.Health) string {
+	if h == nil {
+		return "-"
+	}
+
+	return fmt.Sprint(h.Healthy)
+}
+
+func formatExpiry(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+
+	return t.Format(time.RFC3339)
+}
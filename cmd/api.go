@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"sigs.k8s.io/yaml"
+
+	flagsx "go.hollow.sh/dnscontroller/internal/x/flags"
+	apiclient "go.hollow.sh/dnscontroller/pkg/api/v1/client"
+)
+
+// Output formats of the api commands
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+// ErrorInvalidOutput is when the output format isn't one of table, json or
+// yaml
+var ErrorInvalidOutput = errors.New("output must be table, json or yaml")
+
+// addAPIFlags adds the flags of the commands calling the API to a command
+// group. The token is usually set with DNSCONTROLLER_API_TOKEN or in the
+// config file rather than on the command line.
+func addAPIFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().String("api-url", "http://localhost:14000", "address of the dnscontroller api server")
+	cmd.PersistentFlags().String("api-token", "", "bearer token sent to the dnscontroller api server")
+	cmd.PersistentFlags().StringP("output", "o", outputTable, "output format, one of table, json or yaml")
+
+	// The flags are shared by every command group, bind them when a command
+	// runs so the groups don't shadow each other's flags
+	cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		flagsx.MustBindPFlag("api.url", cmd.Flags().Lookup("api-url"))
+		flagsx.MustBindPFlag("api.token", cmd.Flags().Lookup("api-token"))
+		flagsx.MustBindPFlag("output", cmd.Flags().Lookup("output"))
+
+		switch viper.GetString("output") {
+		case outputTable, outputJSON, outputYAML:
+			return nil
+		default:
+			return ErrorInvalidOutput
+		}
+	}
+}
+
+func newAPIClient() *apiclient.Client {
+	return apiclient.New(viper.GetString("api.url"), viper.GetString("api.token"))
+}
+
+// printOutput writes v in the output format, table writes the table rows of
+// v with tabs between the columns
+func printOutput(w io.Writer, v interface{}, table func(w io.Writer)) error {
+	switch viper.GetString("output") {
+	case outputTable:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		table(tw)
+
+		return tw.Flush()
+	case outputJSON:
+		b, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintln(w, string(b))
+
+		return err
+	case outputYAML:
+		b, err := yaml.Marshal(v)
+		if err != nil {
+			return err
+		}
+
+		_, err = w.Write(b)
+
+		return err
+	default:
+		return ErrorInvalidOutput
+	}
+}
+
+func formatInt(v *int64) string {
+	if v == nil {
+		return "-"
+	}
+
+	return fmt.Sprint(*v)
+}
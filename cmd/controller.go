@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"go.hollow.sh/dnscontroller/internal/ingress"
+	flagsx "go.hollow.sh/dnscontroller/internal/x/flags"
+	apiclient "go.hollow.sh/dnscontroller/pkg/api/v1/client"
+)
+
+var controllerCmd = &cobra.Command{
+	Use:   "controller",
+	Short: "registers the hosts of kubernetes ingresses as SRV answers",
+	Run: func(cmd *cobra.Command, args []string) {
+		controller(cmd.Context())
+	},
+}
+
+func init() {
+	root.Cmd.AddCommand(controllerCmd)
+
+	controllerCmd.Flags().String("kubeconfig", "", "path to a kubeconfig, the in cluster config is used when empty")
+	flagsx.MustBindPFlag("controller.kubeconfig", controllerCmd.Flags().Lookup("kubeconfig"))
+
+	controllerCmd.Flags().String("namespace", "", "namespace to watch, all namespaces when empty")
+	flagsx.MustBindPFlag("controller.namespace", controllerCmd.Flags().Lookup("namespace"))
+
+	controllerCmd.Flags().String("cluster", "", "name of the cluster, answers are registered under owners with this name")
+	flagsx.MustBindPFlag("controller.cluster", controllerCmd.Flags().Lookup("cluster"))
+
+	controllerCmd.Flags().String("domain", "", "domain of the SRV records for ingresses without the domain annotation")
+	flagsx.MustBindPFlag("controller.domain", controllerCmd.Flags().Lookup("domain"))
+
+	controllerCmd.Flags().String("api-url", "http://localhost:14000", "address of the dnscontroller api server")
+	flagsx.MustBindPFlag("controller.api.url", controllerCmd.Flags().Lookup("api-url"))
+
+	controllerCmd.Flags().String("api-token", "", "bearer token sent to the dnscontroller api server")
+	flagsx.MustBindPFlag("controller.api.token", controllerCmd.Flags().Lookup("api-token"))
+
+	controllerCmd.Flags().Duration("resync", 10*time.Minute, "time between full syncs of every ingress")
+	flagsx.MustBindPFlag("controller.resync", controllerCmd.Flags().Lookup("resync"))
+
+	controllerCmd.Flags().Int("workers", ingress.DefaultWorkers, "number of ingresses synced at once")
+	flagsx.MustBindPFlag("controller.workers", controllerCmd.Flags().Lookup("workers"))
+}
+
+func controller(ctx context.Context) {
+	if viper.GetString("controller.cluster") == "" {
+		logger.Fatal("a cluster name is required")
+	}
+
+	config, err := clientcmd.BuildConfigFromFlags("", viper.GetString("controller.kubeconfig"))
+	if err != nil {
+		logger.Fatalw("failed loading kubernetes config", "error", err)
+	}
+
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		logger.Fatalw("failed creating kubernetes client", "error", err)
+	}
+
+	c := &ingress.Controller{
+		Logger: logger.With("component", "controller"),
+		Client: client,
+		API: &ingress.Client{
+			API: apiclient.New(viper.GetString("controller.api.url"), viper.GetString("controller.api.token")),
+		},
+		Cluster:   viper.GetString("controller.cluster"),
+		Domain:    viper.GetString("controller.domain"),
+		Namespace: viper.GetString("controller.namespace"),
+		Resync:    viper.GetDuration("controller.resync"),
+		Workers:   viper.GetInt("controller.workers"),
+	}
+
+	logger.Infow("starting dns-controller ingress controller", "cluster", c.Cluster, "namespace", c.Namespace)
+
+	if err := c.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		logger.Fatalw("ingress controller stopped", "error", err)
+	}
+}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"go.hollow.sh/dnscontroller/internal/provider"
+	"go.hollow.sh/dnscontroller/internal/provider/memory"
+	"go.hollow.sh/dnscontroller/internal/provider/rfc2136"
+	"go.hollow.sh/dnscontroller/internal/reconciler"
+	dbx "go.hollow.sh/dnscontroller/internal/x/db"
+	flagsx "go.hollow.sh/dnscontroller/internal/x/flags"
+)
+
+const defaultReconcileInterval = time.Minute
+
+var reconcileCmd = &cobra.Command{
+	Use:   "reconcile",
+	Short: "pushes the records in the database to an upstream dns provider",
+	PreRun: func(cmd *cobra.Command, args []string) {
+		// db.uri is shared with serve, bind it when the command runs so
+		// neither command shadows the other's flag
+		flagsx.MustBindPFlag("db.uri", cmd.Flags().Lookup("db-uri"))
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		reconcile(cmd.Context())
+	},
+}
+
+func init() {
+	root.Cmd.AddCommand(reconcileCmd)
+
+	reconcileCmd.Flags().String("db-uri", "postgresql://root@localhost:26257/dns-controller?sslmode=disable", "URI for database connection")
+
+	reconcileCmd.Flags().String("provider", "rfc2136", "upstream provider, one of rfc2136 or memory")
+	flagsx.MustBindPFlag("reconcile.provider", reconcileCmd.Flags().Lookup("provider"))
+
+	reconcileCmd.Flags().StringSlice("zones", []string{}, "zones to reconcile")
+	flagsx.MustBindPFlag("reconcile.zones", reconcileCmd.Flags().Lookup("zones"))
+
+	reconcileCmd.Flags().Bool("all-zones", false, "reconcile every provider zone when no zones are given")
+	flagsx.MustBindPFlag("reconcile.all_zones", reconcileCmd.Flags().Lookup("all-zones"))
+
+	reconcileCmd.Flags().String("owner", reconciler.DefaultOwner, "owner written to the markers of the managed records")
+	flagsx.MustBindPFlag("reconcile.owner", reconcileCmd.Flags().Lookup("owner"))
+
+	reconcileCmd.Flags().Bool("adopt", false, "take over records in the database that exist upstream without a marker")
+	flagsx.MustBindPFlag("reconcile.adopt", reconcileCmd.Flags().Lookup("adopt"))
+
+	reconcileCmd.Flags().Bool("dry-run", false, "log the changes without applying them")
+	flagsx.MustBindPFlag("reconcile.dry_run", reconcileCmd.Flags().Lookup("dry-run"))
+
+	reconcileCmd.Flags().Int("batch-size", reconciler.DefaultBatchSize, "number of changes applied at once")
+	flagsx.MustBindPFlag("reconcile.batch_size", reconcileCmd.Flags().Lookup("batch-size"))
+
+	reconcileCmd.Flags().Duration("interval", defaultReconcileInterval, "time between runs")
+	flagsx.MustBindPFlag("reconcile.interval", reconcileCmd.Flags().Lookup("interval"))
+
+	reconcileCmd.Flags().Bool("once", false, "run once and exit")
+	flagsx.MustBindPFlag("reconcile.once", reconcileCmd.Flags().Lookup("once"))
+
+	reconcileCmd.Flags().String("rfc2136-server", "", "host:port of the server accepting dynamic updates")
+	flagsx.MustBindPFlag("provider.rfc2136.server", reconcileCmd.Flags().Lookup("rfc2136-server"))
+
+	reconcileCmd.Flags().String("rfc2136-tsig-key", "", "name of the TSIG key updates are signed with")
+	flagsx.MustBindPFlag("provider.rfc2136.tsig.key", reconcileCmd.Flags().Lookup("rfc2136-tsig-key"))
+
+	reconcileCmd.Flags().String("rfc2136-tsig-secret", "", "base64 encoded TSIG secret")
+	flagsx.MustBindPFlag("provider.rfc2136.tsig.secret", reconcileCmd.Flags().Lookup("rfc2136-tsig-secret"))
+
+	reconcileCmd.Flags().String("rfc2136-tsig-algorithm", "hmac-sha256", "TSIG algorithm, hmac-sha256 or hmac-sha512")
+	flagsx.MustBindPFlag("provider.rfc2136.tsig.algorithm", reconcileCmd.Flags().Lookup("rfc2136-tsig-algorithm"))
+}
+
+func reconcile(ctx context.Context) {
+	if len(viper.GetStringSlice("reconcile.zones")) == 0 && !viper.GetBool("reconcile.all_zones") {
+		logger.Fatalw("refusing to reconcile every provider zone, pass --zones or --all-zones")
+	}
+
+	var db *sqlx.DB
+	if viper.GetBool("tracing.enabled") {
+		db = dbx.NewDBWithTracing(logger)
+	} else {
+		db = dbx.NewDB(logger)
+	}
+
+	r := &reconciler.Reconciler{
+		Logger:    logger.With("component", "reconciler"),
+		Provider:  newProvider(),
+		Source:    &reconciler.DBSource{DB: db},
+		Zones:     viper.GetStringSlice("reconcile.zones"),
+		AllZones:  viper.GetBool("reconcile.all_zones"),
+		Owner:     viper.GetString("reconcile.owner"),
+		Adopt:     viper.GetBool("reconcile.adopt"),
+		DryRun:    viper.GetBool("reconcile.dry_run"),
+		BatchSize: viper.GetInt("reconcile.batch_size"),
+		Interval:  viper.GetDuration("reconcile.interval"),
+	}
+
+	logger.Infow("starting dns-controller reconciler", "provider", viper.GetString("reconcile.provider"), "dry_run", r.DryRun)
+
+	if viper.GetBool("reconcile.once") {
+		report, err := r.RunOnce(ctx)
+		if err != nil {
+			logger.Fatalw("failed reconciling", "error", err)
+		}
+
+		if failed := report.Failed(); len(failed) > 0 {
+			logger.Fatalw("failed applying changes", "failed", len(failed), "total", len(report.Results))
+		}
+
+		return
+	}
+
+	if err := r.Run(ctx); err != nil {
+		logger.Fatalw("reconciler stopped", "error", err)
+	}
+}
+
+func newProvider() provider.Provider {
+	switch viper.GetString("reconcile.provider") {
+	case "memory":
+		return memory.New(viper.GetStringSlice("reconcile.zones")...)
+	case "rfc2136":
+		p, err := rfc2136.New(rfc2136.Config{
+			Server:    viper.GetString("provider.rfc2136.server"),
+			Zones:     viper.GetStringSlice("reconcile.zones"),
+			KeyName:   viper.GetString("provider.rfc2136.tsig.key"),
+			Secret:    viper.GetString("provider.rfc2136.tsig.secret"),
+			Algorithm: viper.GetString("provider.rfc2136.tsig.algorithm"),
+		})
+		if err != nil {
+			logger.Fatalw("failed creating rfc2136 provider", "error", err)
+		}
+
+		return p
+	}
+
+	logger.Fatalw("unknown provider", "provider", viper.GetString("reconcile.provider"))
+
+	return nil
+}
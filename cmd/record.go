@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	apiclient "go.hollow.sh/dnscontroller/pkg/api/v1/client"
+	record "go.hollow.sh/dnscontroller/pkg/api/v1/records"
+)
+
+// recordArgs is the number of arguments naming a record, its name and type
+const recordArgs = 2
+
+var recordCmd = &cobra.Command{
+	Use:   "record",
+	Short: "inspects and changes records through the api",
+}
+
+var recordListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "lists records, every page unless --page is given",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p := &record.ListParams{}
+		p.Type, _ = cmd.Flags().GetString("type")
+		p.Prefix, _ = cmd.Flags().GetString("prefix")
+		p.Suffix, _ = cmd.Flags().GetString("suffix")
+		p.Owner, _ = cmd.Flags().GetString("owner")
+		p.Origin, _ = cmd.Flags().GetString("origin")
+		p.Page, _ = cmd.Flags().GetInt("page")
+		p.PageSize, _ = cmd.Flags().GetInt("page-size")
+
+		var (
+			records []*record.Record
+			err     error
+		)
+
+		if p.Page > 0 {
+			var page *apiclient.RecordPage
+			page, err = newAPIClient().ListRecords(cmd.Context(), p)
+
+			if page != nil {
+				records = page.Records
+			}
+		} else {
+			records, err = newAPIClient().AllRecords(cmd.Context(), p)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		return printRecords(cmd.OutOrStdout(), records, records...)
+	},
+}
+
+var recordGetCmd = &cobra.Command{
+	Use:   "get NAME TYPE",
+	Short: "shows a record",
+	Args:  cobra.ExactArgs(recordArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		r, err := newAPIClient().GetRecord(cmd.Context(), args[0], args[1])
+		if err != nil {
+			return err
+		}
+
+		return printRecords(cmd.OutOrStdout(), r, r)
+	},
+}
+
+var recordCreateCmd = &cobra.Command{
+	Use:   "create NAME TYPE",
+	Short: "creates a record, an existing record is left as is",
+	Args:  cobra.ExactArgs(recordArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c := newAPIClient()
+
+		if err := c.CreateRecord(cmd.Context(), args[0], args[1]); err != nil {
+			return err
+		}
+
+		r, err := c.GetRecord(cmd.Context(), args[0], args[1])
+		if err != nil {
+			return err
+		}
+
+		return printRecords(cmd.OutOrStdout(), r, r)
+	},
+}
+
+var recordDeleteCmd = &cobra.Command{
+	Use:   "delete NAME TYPE",
+	Short: "deletes a record along with its answers",
+	Args:  cobra.ExactArgs(recordArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := newAPIClient().DeleteRecord(cmd.Context(), args[0], args[1]); err != nil {
+			return err
+		}
+
+		_, err := fmt.Fprintf(cmd.OutOrStdout(), "deleted %s %s\n", args[0], args[1])
+
+		return err
+	},
+}
+
+func init() {
+	root.Cmd.AddCommand(recordCmd)
+	addAPIFlags(recordCmd)
+
+	recordCmd.AddCommand(recordListCmd, recordGetCmd, recordCreateCmd, recordDeleteCmd)
+
+	recordListCmd.Flags().String("type", "", "only records of this type")
+	recordListCmd.Flags().String("prefix", "", "only records with names starting with this")
+	recordListCmd.Flags().String("suffix", "", "only records with names ending with this")
+	recordListCmd.Flags().String("owner", "", "only records with answers from owners with this name")
+	recordListCmd.Flags().String("origin", "", "only records with answers from owners with this origin")
+	recordListCmd.Flags().Int("page", 0, "page to list, every page when 0")
+	recordListCmd.Flags().Int("page-size", 0, "records per page, the server default when 0")
+
+	for _, c := range []*cobra.Command{recordListCmd, recordGetCmd, recordCreateCmd, recordDeleteCmd} {
+		// Errors are the API's rather than the usage's, Execute prints them
+		c.SilenceUsage = true
+		c.SilenceErrors = true
+	}
+}
+
+// printRecords writes v in the output format, the records are the table rows
+func printRecords(w io.Writer, v interface{}, records ...*record.Record) error {
+	return printOutput(w, v, func(w io.Writer) {
+		fmt.Fprintln(w, "RECORD\tTYPE\tUUID\tUPDATED")
+
+		for _, r := range records {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.Name, r.Type, r.UUID, r.UpdatedAt.Format(time.RFC3339))
+		}
+	})
+}
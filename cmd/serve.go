@@ -2,13 +2,21 @@ package cmd
 
 import (
 	"context"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"go.hollow.sh/toolbox/ginjwt"
 
+	"go.hollow.sh/dnscontroller/internal/collector"
+	"go.hollow.sh/dnscontroller/internal/healthcheck"
 	"go.hollow.sh/dnscontroller/internal/httpsrv"
+	"go.hollow.sh/dnscontroller/internal/notifier"
+	"go.hollow.sh/dnscontroller/internal/reaper"
+	"go.hollow.sh/dnscontroller/internal/relay"
+	"go.hollow.sh/dnscontroller/internal/sink"
+	"go.hollow.sh/dnscontroller/internal/sink/stdout"
 	dbx "go.hollow.sh/dnscontroller/internal/x/db"
 	flagsx "go.hollow.sh/dnscontroller/internal/x/flags"
 )
@@ -32,6 +40,66 @@ func init() {
 	serveCmd.Flags().String("db-uri", "postgresql://root@localhost:26257/dns-controller?sslmode=disable", "URI for database connection")
 	flagsx.MustBindPFlag("db.uri", serveCmd.Flags().Lookup("db-uri"))
 
+	serveCmd.Flags().Duration("zone-gc-interval", 5*time.Minute, "time between runs of the empty zone collector, 0 disables it")
+	flagsx.MustBindPFlag("zones.gc.interval", serveCmd.Flags().Lookup("zone-gc-interval"))
+
+	serveCmd.Flags().Duration("zone-gc-grace-period", time.Hour, "time a created zone stays without answers before it is removed")
+	flagsx.MustBindPFlag("zones.gc.grace_period", serveCmd.Flags().Lookup("zone-gc-grace-period"))
+
+	serveCmd.Flags().Duration("health-interval", 0, "time between answer health checks, 0 disables them")
+	flagsx.MustBindPFlag("health.interval", serveCmd.Flags().Lookup("health-interval"))
+
+	serveCmd.Flags().Duration("health-timeout", 5*time.Second, "time a single health check may take")
+	flagsx.MustBindPFlag("health.timeout", serveCmd.Flags().Lookup("health-timeout"))
+
+	serveCmd.Flags().Int64("health-rise", 2, "consecutive successful checks before an answer is served again")
+	flagsx.MustBindPFlag("health.rise", serveCmd.Flags().Lookup("health-rise"))
+
+	serveCmd.Flags().Int64("health-fall", 3, "consecutive failed checks before an answer is withdrawn")
+	flagsx.MustBindPFlag("health.fall", serveCmd.Flags().Lookup("health-fall"))
+
+	serveCmd.Flags().Int("health-concurrency", 10, "health checks run at once")
+	flagsx.MustBindPFlag("health.concurrency", serveCmd.Flags().Lookup("health-concurrency"))
+
+	serveCmd.Flags().String("health-http-path", "/", "path requested by the http and https health checks")
+	flagsx.MustBindPFlag("health.http_path", serveCmd.Flags().Lookup("health-http-path"))
+
+	serveCmd.Flags().Duration("health-lock", time.Minute, "time the health checker holds its lock without renewing it, the checker of another replica takes over after that")
+	flagsx.MustBindPFlag("health.lock", serveCmd.Flags().Lookup("health-lock"))
+
+	serveCmd.Flags().Duration("lease-reap-interval", 30*time.Second, "time between removals of answers whose lease expired, 0 disables them")
+	flagsx.MustBindPFlag("leases.reap_interval", serveCmd.Flags().Lookup("lease-reap-interval"))
+
+	serveCmd.Flags().Duration("webhook-interval", 5*time.Second, "time between runs of the webhook notifier, 0 disables it")
+	flagsx.MustBindPFlag("webhooks.interval", serveCmd.Flags().Lookup("webhook-interval"))
+
+	serveCmd.Flags().Duration("webhook-timeout", 10*time.Second, "time a single webhook delivery attempt may take")
+	flagsx.MustBindPFlag("webhooks.timeout", serveCmd.Flags().Lookup("webhook-timeout"))
+
+	serveCmd.Flags().Int64("webhook-max-attempts", 10, "delivery attempts before a webhook delivery is dead-lettered")
+	flagsx.MustBindPFlag("webhooks.max_attempts", serveCmd.Flags().Lookup("webhook-max-attempts"))
+
+	serveCmd.Flags().Duration("webhook-backoff", 10*time.Second, "wait before the first retry of a webhook delivery, it doubles for each retry")
+	flagsx.MustBindPFlag("webhooks.backoff", serveCmd.Flags().Lookup("webhook-backoff"))
+
+	serveCmd.Flags().Duration("webhook-max-backoff", time.Hour, "longest wait between retries of a webhook delivery")
+	flagsx.MustBindPFlag("webhooks.max_backoff", serveCmd.Flags().Lookup("webhook-max-backoff"))
+
+	serveCmd.Flags().String("outbox-sink", "", "sink the outbox relay publishes to, one of stdout, the relay doesn't run when empty")
+	flagsx.MustBindPFlag("outbox.sink", serveCmd.Flags().Lookup("outbox-sink"))
+
+	serveCmd.Flags().Duration("outbox-interval", time.Second, "time between runs of the outbox relay")
+	flagsx.MustBindPFlag("outbox.interval", serveCmd.Flags().Lookup("outbox-interval"))
+
+	serveCmd.Flags().Int("outbox-batch-size", relay.DefaultBatchSize, "number of outbox messages read at once")
+	flagsx.MustBindPFlag("outbox.batch_size", serveCmd.Flags().Lookup("outbox-batch-size"))
+
+	serveCmd.Flags().Duration("outbox-retention", 24*time.Hour, "time delivered outbox messages are kept, 0 keeps them")
+	flagsx.MustBindPFlag("outbox.retention", serveCmd.Flags().Lookup("outbox-retention"))
+
+	serveCmd.Flags().Duration("outbox-lock", 30*time.Second, "time the outbox relay holds its lock without renewing it, the relay of another replica takes over after that")
+	flagsx.MustBindPFlag("outbox.lock", serveCmd.Flags().Lookup("outbox-lock"))
+
 	flagsx.RegisterOIDCFlags(serveCmd)
 }
 
@@ -43,6 +111,91 @@ func serve(ctx context.Context) {
 		db = dbx.NewDB(logger)
 	}
 
+	if interval := viper.GetDuration("zones.gc.interval"); interval > 0 {
+		zc := &collector.Collector{
+			Logger:      logger.With("component", "collector"),
+			DB:          db,
+			Interval:    interval,
+			GracePeriod: viper.GetDuration("zones.gc.grace_period"),
+		}
+
+		go func() {
+			if err := zc.Run(ctx); err != nil {
+				logger.Errorw("zone collector stopped", "error", err)
+			}
+		}()
+	}
+
+	if interval := viper.GetDuration("health.interval"); interval > 0 {
+		hc := &healthcheck.Checker{
+			Logger:      logger.With("component", "healthcheck"),
+			DB:          db,
+			Interval:    interval,
+			Timeout:     viper.GetDuration("health.timeout"),
+			Rise:        viper.GetInt64("health.rise"),
+			Fall:        viper.GetInt64("health.fall"),
+			Concurrency: viper.GetInt("health.concurrency"),
+			HTTPPath:    viper.GetString("health.http_path"),
+			Lock:        viper.GetDuration("health.lock"),
+		}
+
+		go func() {
+			if err := hc.Run(ctx); err != nil {
+				logger.Errorw("health checker stopped", "error", err)
+			}
+		}()
+	}
+
+	if interval := viper.GetDuration("leases.reap_interval"); interval > 0 {
+		lr := &reaper.Reaper{
+			Logger:   logger.With("component", "reaper"),
+			DB:       db,
+			Interval: interval,
+		}
+
+		go func() {
+			if err := lr.Run(ctx); err != nil {
+				logger.Errorw("lease reaper stopped", "error", err)
+			}
+		}()
+	}
+
+	if interval := viper.GetDuration("webhooks.interval"); interval > 0 {
+		wn := &notifier.Notifier{
+			Logger:      logger.With("component", "notifier"),
+			DB:          db,
+			Interval:    interval,
+			Timeout:     viper.GetDuration("webhooks.timeout"),
+			MaxAttempts: viper.GetInt64("webhooks.max_attempts"),
+			Backoff:     viper.GetDuration("webhooks.backoff"),
+			MaxBackoff:  viper.GetDuration("webhooks.max_backoff"),
+		}
+
+		go func() {
+			if err := wn.Run(ctx); err != nil {
+				logger.Errorw("webhook notifier stopped", "error", err)
+			}
+		}()
+	}
+
+	if s := viper.GetString("outbox.sink"); s != "" {
+		rl := &relay.Relay{
+			Logger:    logger.With("component", "relay"),
+			DB:        db,
+			Sink:      newSink(s),
+			Interval:  viper.GetDuration("outbox.interval"),
+			BatchSize: viper.GetInt("outbox.batch_size"),
+			Retention: viper.GetDuration("outbox.retention"),
+			Lock:      viper.GetDuration("outbox.lock"),
+		}
+
+		go func() {
+			if err := rl.Run(ctx); err != nil {
+				logger.Errorw("outbox relay stopped", "error", err)
+			}
+		}()
+	}
+
 	logger.Infow("starting dns-controller api server", "address", viper.GetString("listen"))
 
 	hs := &httpsrv.Server{
@@ -59,6 +212,8 @@ func serve(ctx context.Context) {
 			RolesClaim:    viper.GetString("oidc.claims.roles"),
 			UsernameClaim: viper.GetString("oidc.claims.username"),
 		},
+		OwnerClaim:     viper.GetString("oidc.claims.owner"),
+		ClaimUnowned:   viper.GetBool("oidc.claim-unowned"),
 		TrustedProxies: viper.GetStringSlice("gin.trustedproxies"),
 	}
 
@@ -66,3 +221,13 @@ func serve(ctx context.Context) {
 		logger.Fatalw("failed starting metadata server", "error", err)
 	}
 }
+
+func newSink(name string) sink.Sink {
+	if name == "stdout" {
+		return stdout.New(nil)
+	}
+
+	logger.Fatalw("unknown outbox sink", "sink", name)
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"go.hollow.sh/dnscontroller/internal/dnssrv"
+	dbx "go.hollow.sh/dnscontroller/internal/x/db"
+	flagsx "go.hollow.sh/dnscontroller/internal/x/flags"
+)
+
+var serveDNSCmd = &cobra.Command{
+	Use:   "serve-dns",
+	Short: "starts an authoritative dns server for the records in the database",
+	PreRun: func(cmd *cobra.Command, args []string) {
+		// db.uri is shared with serve, bind it when the command runs so
+		// neither command shadows the other's flag
+		flagsx.MustBindPFlag("db.uri", cmd.Flags().Lookup("db-uri"))
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		serveDNS(cmd.Context())
+	},
+}
+
+func init() {
+	root.Cmd.AddCommand(serveDNSCmd)
+
+	serveDNSCmd.Flags().String("listen", "0.0.0.0:15353", "address on which to listen for udp and tcp queries")
+	flagsx.MustBindPFlag("dns.listen", serveDNSCmd.Flags().Lookup("listen"))
+
+	serveDNSCmd.Flags().String("db-uri", "postgresql://root@localhost:26257/dns-controller?sslmode=disable", "URI for database connection")
+
+	serveDNSCmd.Flags().StringSlice("zones", []string{}, "zones the server is authoritative for")
+	flagsx.MustBindPFlag("dns.zones", serveDNSCmd.Flags().Lookup("zones"))
+
+	serveDNSCmd.Flags().String("soa-nameserver", "", "SOA primary nameserver, defaults to ns.<zone>")
+	flagsx.MustBindPFlag("dns.soa.nameserver", serveDNSCmd.Flags().Lookup("soa-nameserver"))
+
+	serveDNSCmd.Flags().String("soa-mailbox", "", "SOA responsible mailbox, defaults to hostmaster.<zone>")
+	flagsx.MustBindPFlag("dns.soa.mailbox", serveDNSCmd.Flags().Lookup("soa-mailbox"))
+
+	serveDNSCmd.Flags().Uint32("negative-ttl", 300, "ttl for caching NXDOMAIN and NODATA responses")
+	flagsx.MustBindPFlag("dns.soa.negative_ttl", serveDNSCmd.Flags().Lookup("negative-ttl"))
+}
+
+func serveDNS(ctx context.Context) {
+	var db *sqlx.DB
+	if viper.GetBool("tracing.enabled") {
+		db = dbx.NewDBWithTracing(logger)
+	} else {
+		db = dbx.NewDB(logger)
+	}
+
+	zones := viper.GetStringSlice("dns.zones")
+	if len(zones) == 0 {
+		logger.Fatal("at least one zone is required")
+	}
+
+	logger.Infow("starting dns-controller dns server", "address", viper.GetString("dns.listen"), "zones", zones)
+
+	ds := &dnssrv.Server{
+		Logger:      logger.With("component", "dnssrv"),
+		Listen:      viper.GetString("dns.listen"),
+		DB:          db,
+		Zones:       zones,
+		Nameserver:  viper.GetString("dns.soa.nameserver"),
+		Mailbox:     viper.GetString("dns.soa.mailbox"),
+		NegativeTTL: viper.GetUint32("dns.soa.negative_ttl"),
+	}
+
+	if err := ds.Run(); err != nil {
+		logger.Fatalw("failed starting dns server", "error", err)
+	}
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	answer "go.hollow.sh/dnscontroller/pkg/api/v1/answers"
+	"go.hollow.sh/dnscontroller/pkg/api/v1/batch"
+	zone "go.hollow.sh/dnscontroller/pkg/api/v1/zones"
+)
+
+var zoneCmd = &cobra.Command{
+	Use:   "zone",
+	Short: "exports and imports zones as master files through the api",
+}
+
+var zoneExportCmd = &cobra.Command{
+	Use:   "export ZONE",
+	Short: "writes the served answers of the records in a zone as a master file",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		b, err := newAPIClient().ExportZone(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+
+		_, err = cmd.OutOrStdout().Write(b)
+
+		return err
+	},
+}
+
+var zoneImportCmd = &cobra.Command{
+	Use:   "import FILE",
+	Short: "upserts the resource records of a master file as answers of the owner",
+	Long: "upserts the resource records of a master file as answers of the owner, - reads the file from stdin. " +
+		"The whole file is parsed first, then its answers are upserted in one atomic batch, so either all of them are written or none. " +
+		"Answers the owner already has get the ttl and details of the file, so an import can be run again. " +
+		"Files with more answers than a batch takes need --chunked, which writes them in atomic batches one after the other. " +
+		"SOA records, and types dnscontroller doesn't serve, are skipped.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		o, err := ownerFromFlags(cmd.Flags())
+		if err != nil {
+			return err
+		}
+
+		origin, _ := cmd.Flags().GetString("zone")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		chunked, _ := cmd.Flags().GetBool("chunked")
+
+		r := cmd.InOrStdin()
+
+		if args[0] != "-" {
+			f, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+
+			defer f.Close()
+
+			r = f
+		}
+
+		rrs, err := zone.Parse(r, origin, args[0], cmd.ErrOrStderr())
+		if err != nil {
+			return err
+		}
+
+		ops := make([]*answer.Operation, 0, len(rrs))
+		out := cmd.OutOrStdout()
+
+		for _, rr := range rrs {
+			a, err := answer.NewAnswerFromRR(rr)
+			if err != nil {
+				return err
+			}
+
+			ops = append(ops, &answer.Operation{Op: batch.OpUpsert, Record: rr.Header().Name, RecordType: a.Type, Owner: o, Answer: a})
+		}
+
+		if len(ops) > batch.MaxOperations && !chunked {
+			return fmt.Errorf("%s has %d answers, import it with --chunked: %w", args[0], len(ops), batch.ErrorTooManyOperations)
+		}
+
+		if dryRun {
+			for _, op := range ops {
+				fmt.Fprintf(out, "would set %s on %s %s\n", op.Answer.Target, op.Record, op.RecordType)
+			}
+
+			return nil
+		}
+
+		c := newAPIClient()
+
+		for start := 0; start < len(ops); start += batch.MaxOperations {
+			end := start + batch.MaxOperations
+			if end > len(ops) {
+				end = len(ops)
+			}
+
+			if _, err := c.BatchAnswers(cmd.Context(), &answer.Batch{Mode: batch.ModeAtomic, Operations: ops[start:end]}); err != nil {
+				// Upserts can be run again, so importing the file again
+				// writes the rest
+				return fmt.Errorf("importing %s, answers %d to %d weren't written: %w", args[0], start+1, len(ops), err)
+			}
+
+			for _, op := range ops[start:end] {
+				fmt.Fprintf(out, "set %s on %s %s\n", op.Answer.Target, op.Record, op.RecordType)
+			}
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	root.Cmd.AddCommand(zoneCmd)
+	addAPIFlags(zoneCmd)
+
+	zoneCmd.AddCommand(zoneExportCmd, zoneImportCmd)
+
+	addOwnerFlags(zoneImportCmd)
+	zoneImportCmd.Flags().String("zone", "", "origin of the relative names in the file, unless it sets $ORIGIN")
+	zoneImportCmd.Flags().Bool("dry-run", false, "only print the answers that would be written")
+	zoneImportCmd.Flags().Bool("chunked", false, "write a file with more answers than a batch takes in several atomic batches")
+
+	for _, c := range []*cobra.Command{zoneExportCmd, zoneImportCmd} {
+		// Errors are the API's rather than the usage's, Execute prints them
+		c.SilenceUsage = true
+		c.SilenceErrors = true
+	}
+}
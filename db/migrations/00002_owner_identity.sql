@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE UNIQUE INDEX idx_owner_name_origin_service ON owners (name, origin, service);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX owners@idx_owner_name_origin_service;
+
+-- +goose StatementEnd
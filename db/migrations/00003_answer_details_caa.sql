@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE answer_details ADD COLUMN flags INT CHECK (flags >= 0), ADD COLUMN tag STRING;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE answer_details DROP COLUMN flags, DROP COLUMN tag;
+
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE zones (
+   id UUID PRIMARY KEY NOT NULL DEFAULT gen_random_uuid(),
+   name STRING NOT NULL,
+   auto_created BOOL NOT NULL DEFAULT false,
+   empty_since TIMESTAMPTZ,
+   created_at TIMESTAMPTZ NOT NULL,
+   updated_at TIMESTAMPTZ NOT NULL,
+   UNIQUE INDEX idx_zone_name (name)
+ );
+
+ALTER TABLE records ADD COLUMN zone_id UUID REFERENCES zones(id) ON DELETE SET NULL ON UPDATE CASCADE;
+
+CREATE INDEX idx_record_zone ON records (zone_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX records@idx_record_zone;
+ALTER TABLE records DROP COLUMN zone_id;
+DROP TABLE zones;
+
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE answers
+  ADD COLUMN healthy BOOL NOT NULL DEFAULT true,
+  ADD COLUMN health_successes INT NOT NULL DEFAULT 0,
+  ADD COLUMN health_failures INT NOT NULL DEFAULT 0,
+  ADD COLUMN health_checked_at TIMESTAMPTZ,
+  ADD COLUMN health_error STRING;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE answers
+  DROP COLUMN healthy,
+  DROP COLUMN health_successes,
+  DROP COLUMN health_failures,
+  DROP COLUMN health_checked_at,
+  DROP COLUMN health_error;
+
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE answers
+  ADD COLUMN lease_seconds INT,
+  ADD COLUMN expires_at TIMESTAMPTZ;
+
+CREATE INDEX idx_answer_expires_at ON answers (expires_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX answers@idx_answer_expires_at;
+
+ALTER TABLE answers
+  DROP COLUMN lease_seconds,
+  DROP COLUMN expires_at;
+
+-- +goose StatementEnd
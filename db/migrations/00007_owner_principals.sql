@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE owners ADD COLUMN principal STRING;
+
+CREATE INDEX idx_owner_principal ON owners (principal);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX owners@idx_owner_principal;
+
+ALTER TABLE owners DROP COLUMN principal;
+
+-- +goose StatementEnd
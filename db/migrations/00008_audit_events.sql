@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE audit_events (
+   id UUID PRIMARY KEY NOT NULL DEFAULT gen_random_uuid(),
+   actor STRING NOT NULL DEFAULT '',
+   operation STRING NOT NULL,
+   record STRING,
+   record_type STRING,
+   owner_id UUID,
+   before JSONB,
+   after JSONB,
+   request_id STRING,
+   created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+   INDEX idx_audit_event_created_at (created_at),
+   INDEX idx_audit_event_record (record, created_at),
+   INDEX idx_audit_event_owner (owner_id, created_at),
+   INDEX idx_audit_event_actor (actor, created_at)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE audit_events;
+
+-- +goose StatementEnd
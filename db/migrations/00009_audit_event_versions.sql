@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE audit_events ADD COLUMN version INT8;
+
+CREATE INDEX idx_audit_event_version ON audit_events (version);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX audit_events@idx_audit_event_version;
+
+ALTER TABLE audit_events DROP COLUMN version;
+
+-- +goose StatementEnd
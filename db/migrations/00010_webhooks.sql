@@ -0,0 +1,57 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE webhooks (
+   id UUID PRIMARY KEY NOT NULL DEFAULT gen_random_uuid(),
+   url STRING NOT NULL,
+   secret STRING NOT NULL,
+   events STRING NOT NULL DEFAULT '',
+   zone STRING,
+   owner_id UUID,
+   version INT8 NOT NULL,
+   created_at TIMESTAMPTZ NOT NULL,
+   updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE webhook_deliveries (
+   id UUID PRIMARY KEY NOT NULL DEFAULT gen_random_uuid(),
+   webhook_id UUID NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE ON UPDATE CASCADE,
+   event_id UUID NOT NULL,
+   event STRING NOT NULL,
+   payload JSONB NOT NULL,
+   status STRING NOT NULL DEFAULT 'pending',
+   attempts INT NOT NULL DEFAULT 0,
+   next_attempt_at TIMESTAMPTZ,
+   last_status_code INT,
+   last_error STRING,
+   delivered_at TIMESTAMPTZ,
+   created_at TIMESTAMPTZ NOT NULL,
+   updated_at TIMESTAMPTZ NOT NULL,
+   UNIQUE INDEX idx_webhook_delivery_event (webhook_id, event_id),
+   INDEX idx_webhook_delivery_created_at (webhook_id, created_at),
+   INDEX idx_webhook_delivery_next_attempt_at (next_attempt_at)
+);
+
+CREATE TABLE webhook_dead_letters (
+   id UUID PRIMARY KEY NOT NULL,
+   webhook_id UUID NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE ON UPDATE CASCADE,
+   event_id UUID NOT NULL,
+   event STRING NOT NULL,
+   payload JSONB NOT NULL,
+   attempts INT NOT NULL,
+   last_status_code INT,
+   last_error STRING,
+   created_at TIMESTAMPTZ NOT NULL,
+   INDEX idx_webhook_dead_letter_created_at (webhook_id, created_at)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE webhook_dead_letters;
+DROP TABLE webhook_deliveries;
+DROP TABLE webhooks;
+
+-- +goose StatementEnd
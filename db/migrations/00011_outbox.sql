@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE outbox (
+   id UUID PRIMARY KEY NOT NULL DEFAULT gen_random_uuid(),
+   event STRING NOT NULL,
+   record STRING NOT NULL,
+   record_type STRING NOT NULL,
+   owner_id UUID,
+   before JSONB,
+   after JSONB,
+   version INT8 NOT NULL,
+   sequence INT8 NOT NULL DEFAULT unique_rowid(),
+   delivered_at TIMESTAMPTZ,
+   created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+   INDEX idx_outbox_pending (version, sequence) WHERE delivered_at IS NULL,
+   INDEX idx_outbox_delivered_at (delivered_at)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE outbox;
+
+-- +goose StatementEnd
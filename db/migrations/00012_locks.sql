@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE locks (
+   name STRING PRIMARY KEY NOT NULL,
+   holder STRING NOT NULL,
+   expires_at TIMESTAMPTZ NOT NULL
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE locks;
+
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Serials start from the time, like the serials served before zones had
+-- their own, so they don't go backwards for secondaries that saw those
+ALTER TABLE zones ADD COLUMN serial INT8 NOT NULL DEFAULT extract(epoch FROM now())::INT8;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE zones DROP COLUMN serial;
+
+-- +goose StatementEnd
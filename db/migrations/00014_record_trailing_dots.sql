@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Records written before names were canonicalized may be stored without the
+-- trailing dot, so lookups by the canonical name miss them. A record that
+-- also exists with the dot is merged into it: its answers move over, unless
+-- the same answer of the same owner is already there, and it is deleted.
+
+DELETE FROM answers AS a
+ WHERE EXISTS (
+   SELECT 1 FROM records AS r
+     JOIN records AS d ON d.record = r.record || '.' AND d.record_type = r.record_type
+     JOIN answers AS b ON b.record_id = d.id AND b.owner_id = a.owner_id AND b.target = a.target AND b.type = a.type
+    WHERE r.id = a.record_id AND r.record NOT LIKE '%.'
+ );
+
+UPDATE answers AS a
+   SET record_id = (
+     SELECT d.id FROM records AS r
+       JOIN records AS d ON d.record = r.record || '.' AND d.record_type = r.record_type
+      WHERE r.id = a.record_id
+   )
+ WHERE a.record_id IN (
+   SELECT r.id FROM records AS r
+     JOIN records AS d ON d.record = r.record || '.' AND d.record_type = r.record_type
+    WHERE r.record NOT LIKE '%.'
+ );
+
+DELETE FROM records AS r
+ WHERE r.record NOT LIKE '%.'
+   AND EXISTS (SELECT 1 FROM records AS d WHERE d.record = r.record || '.' AND d.record_type = r.record_type);
+
+-- The rest get the dot, and are linked to their longest zone the way
+-- RelinkZone links them
+UPDATE records AS r
+   SET record = r.record || '.',
+       zone_id = (
+         SELECT z.id FROM zones AS z
+          WHERE z.name = r.record || '.' OR right(r.record || '.', length(z.name) + 1) = '.' || z.name
+          ORDER BY length(z.name) DESC
+          LIMIT 1
+       )
+ WHERE r.record NOT LIKE '%.';
+
+-- +goose StatementEnd
+
+-- +goose Down
+
+-- The names stored without a dot aren't kept, so there is nothing to restore
@@ -0,0 +1,165 @@
+// Package collector garbage collects the zones dnscontroller created, a zone
+// is removed once it has had no answers for the grace period
+package collector
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach-go/v2/crdb/crdbsqlx"
+	"github.com/jmoiron/sqlx"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"go.uber.org/zap"
+
+	"go.hollow.sh/dnscontroller/internal/models"
+	"go.hollow.sh/dnscontroller/pkg/api/v1/audit"
+	"go.hollow.sh/dnscontroller/pkg/api/v1/outbox"
+	record "go.hollow.sh/dnscontroller/pkg/api/v1/records"
+)
+
+// Actor is who the collector's deletions are recorded as in the audit log
+const Actor = "system:collector"
+
+// Collector removes empty zones that were created for a record, zones
+// created through the API are never touched
+type Collector struct {
+	Logger      *zap.SugaredLogger
+	DB          *sqlx.DB
+	Interval    time.Duration
+	GracePeriod time.Duration
+}
+
+// Run collects every interval until the context is done
+func (c *Collector) Run(ctx context.Context) error {
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.Collect(ctx); err != nil {
+			c.Logger.Errorw("failed collecting zones", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Collect runs once over the automatically created zones, a zone that fails
+// is logged and doesn't stop the others
+func (c *Collector) Collect(ctx context.Context) error {
+	dbZones, err := models.Zones(models.ZoneWhere.AutoCreated.EQ(true)).All(ctx, c.DB)
+	if err != nil {
+		return err
+	}
+
+	for _, dbZone := range dbZones {
+		if err := c.collectZone(ctx, dbZone.ID); err != nil {
+			c.Logger.Errorw("failed collecting zone", "zone", dbZone.Name, "error", err)
+		}
+	}
+
+	return nil
+}
+
+// collectZone marks the zone as empty when its last answer is gone and
+// removes it, with its records, once it stayed empty for the grace period. The
+// zone is read again in the transaction so an answer added in the meantime
+// keeps it.
+func (c *Collector) collectZone(ctx context.Context, id string) error {
+	ctx = audit.WithActor(ctx, audit.Actor{Subject: Actor})
+
+	var removed *models.Zone
+
+	err := crdbsqlx.ExecuteTx(ctx, c.DB, nil, func(tx *sqlx.Tx) error {
+		removed = nil
+
+		dbZone, err := models.FindZone(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if !dbZone.AutoCreated {
+			return nil
+		}
+
+		hasAnswers, err := models.Answers(
+			qm.InnerJoin("records ON records.id = answers.record_id"),
+			qm.Where("records.zone_id=?", dbZone.ID),
+		).Exists(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+
+		switch {
+		case hasAnswers && dbZone.EmptySince.Valid:
+			dbZone.EmptySince = null.Time{}
+		case hasAnswers:
+			return nil
+		case !dbZone.EmptySince.Valid:
+			dbZone.EmptySince = null.TimeFrom(now)
+		case now.Sub(dbZone.EmptySince.Time) < c.GracePeriod:
+			return nil
+		default:
+			if err := deleteRecords(ctx, tx, dbZone.ID); err != nil {
+				return err
+			}
+
+			if _, err := dbZone.Delete(ctx, tx); err != nil {
+				return err
+			}
+
+			removed = dbZone
+
+			return nil
+		}
+
+		_, err = dbZone.Update(ctx, tx, boil.Whitelist(models.ZoneColumns.EmptySince, models.ZoneColumns.UpdatedAt))
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if removed != nil {
+		c.Logger.Infow("removed empty zone", "zone", removed.Name, "empty_since", removed.EmptySince.Time)
+	}
+
+	return nil
+}
+
+// deleteRecords removes the records of a zone, recording each in the audit log
+func deleteRecords(ctx context.Context, exec boil.ContextExecutor, zoneID string) error {
+	dbRecords, err := models.Records(qm.Where("zone_id=?", zoneID)).All(ctx, exec)
+	if err != nil {
+		return err
+	}
+
+	for _, dbRecord := range dbRecords {
+		e := &audit.Event{Operation: audit.RecordDelete, Record: dbRecord.Record, RecordType: dbRecord.RecordType}
+
+		before := &record.Record{}
+		if err := before.FromDBModel(dbRecord); err != nil {
+			return err
+		}
+
+		if err := audit.Write(ctx, exec, e, before, nil); err != nil {
+			return err
+		}
+
+		if err := outbox.WriteChange(ctx, exec, &outbox.Message{Event: e.Operation, Record: e.Record, RecordType: e.RecordType}, before, nil); err != nil {
+			return err
+		}
+	}
+
+	_, err = dbRecords.DeleteAll(ctx, exec)
+
+	return err
+}
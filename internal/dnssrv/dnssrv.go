@@ -0,0 +1,367 @@
+// Package dnssrv has an authoritative dns server for dnscontroller, answers
+// are served straight from the database
+package dnssrv
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/miekg/dns"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"go.uber.org/zap"
+
+	"go.hollow.sh/dnscontroller/internal/models"
+	answer "go.hollow.sh/dnscontroller/pkg/api/v1/answers"
+	record "go.hollow.sh/dnscontroller/pkg/api/v1/records"
+	zx "go.hollow.sh/dnscontroller/pkg/api/v1/zones"
+)
+
+// Server contains the DNS server configuration
+type Server struct {
+	Logger *zap.SugaredLogger
+	Listen string
+	DB     *sqlx.DB
+	// Zones are the zones the server is authoritative for
+	Zones []string
+	// Nameserver is the SOA MNAME, defaults to ns.<zone>
+	Nameserver string
+	// Mailbox is the SOA RNAME, defaults to hostmaster.<zone>
+	Mailbox string
+	// NegativeTTL is the SOA minimum, used to cache NXDOMAIN and NODATA
+	// responses (RFC 2308)
+	NegativeTTL uint32
+}
+
+var (
+	queryTimeout = 5 * time.Second
+	// maxCNAMEHops limits how far a CNAME chain is followed
+	maxCNAMEHops = 8
+	// ednsSize is the UDP payload size advertised to EDNS0 clients, and the
+	// largest UDP response sent to them so responses aren't fragmented
+	ednsSize uint16 = 1232
+)
+
+// rrSet are the resource records of a name by type
+type rrSet map[uint16][]dns.RR
+
+// Run will start the server listening on udp and tcp on the specified
+// address. The zones are created if they don't exist, so they have a serial.
+// Zones that exist are left as they are.
+func (s *Server) Run() error {
+	s.Zones = canonicalZones(s.Zones)
+
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	defer cancel()
+
+	for _, z := range s.Zones {
+		if err := (&zx.Zone{Name: z}).FindOrCreate(ctx, s.DB); err != nil {
+			return err
+		}
+	}
+
+	errs := make(chan error, 2)
+
+	for _, network := range []string{"udp", "tcp"} {
+		srv := &dns.Server{Addr: s.Listen, Net: network, Handler: s}
+
+		go func() { errs <- srv.ListenAndServe() }()
+	}
+
+	return <-errs
+}
+
+// ServeDNS answers a single query
+func (s *Server) ServeDNS(w dns.ResponseWriter, req *dns.Msg) {
+	m := &dns.Msg{}
+	m.SetReply(req)
+	m.Compress = true
+
+	// The OPT record is echoed to EDNS0 clients (RFC 6891 7)
+	if req.IsEdns0() != nil {
+		m.SetEdns0(ednsSize, false)
+	}
+
+	defer func() {
+		// Records that don't fit are left out with the TC bit set, so the
+		// client retries over TCP
+		m.Truncate(maxSize(w, req))
+
+		if err := w.WriteMsg(m); err != nil {
+			s.Logger.Errorw("failed writing dns response", "error", err)
+		}
+	}()
+
+	if len(req.Question) != 1 {
+		m.Rcode = dns.RcodeFormatError
+		return
+	}
+
+	q := req.Question[0]
+	name := strings.ToLower(dns.Fqdn(q.Name))
+
+	if s.zoneFor(name) == "" || (q.Qclass != dns.ClassINET && q.Qclass != dns.ClassANY) {
+		m.Rcode = dns.RcodeRefused
+		return
+	}
+
+	m.Authoritative = true
+
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	defer cancel()
+
+	if err := s.resolve(ctx, m, name, q.Qtype); err != nil {
+		s.Logger.Errorw("failed resolving dns query", "name", name, "type", dns.TypeToString[q.Qtype], "error", err)
+
+		m.Answer, m.Ns = nil, nil
+		m.Rcode = dns.RcodeServerFailure
+	}
+}
+
+// resolve fills in the response, following CNAMEs that point into our zones
+func (s *Server) resolve(ctx context.Context, m *dns.Msg, name string, qtype uint16) error {
+	for hop := 0; hop <= maxCNAMEHops; hop++ {
+		zone := s.zoneFor(name)
+		if zone == "" {
+			// The CNAME target is out of zone, the resolver follows it
+			return nil
+		}
+
+		rrs, exists, err := s.lookup(ctx, zone, name)
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			m.Rcode = dns.RcodeNameError
+			return s.addNegative(ctx, m, zone)
+		}
+
+		if qtype == dns.TypeANY {
+			for _, set := range rrs {
+				m.Answer = append(m.Answer, set...)
+			}
+
+			if len(m.Answer) > 0 {
+				return nil
+			}
+		}
+
+		if set := rrs[qtype]; len(set) > 0 {
+			m.Answer = append(m.Answer, set...)
+			return nil
+		}
+
+		cname := rrs[dns.TypeCNAME]
+		if len(cname) == 0 {
+			// The name exists but not with this type
+			return s.addNegative(ctx, m, zone)
+		}
+
+		m.Answer = append(m.Answer, cname[0])
+		name = cname[0].(*dns.CNAME).Target
+	}
+
+	return nil
+}
+
+// lookup returns the resource records for a name and whether the name exists.
+// Names without records of their own exist when there are records below them,
+// otherwise the wildcard at the closest encloser is used (RFC 4592).
+func (s *Server) lookup(ctx context.Context, zone, name string) (rrSet, bool, error) {
+	rrs, err := s.rrsByName(ctx, name, name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if name == zone {
+		if err := s.addApex(ctx, rrs, zone); err != nil {
+			return nil, false, err
+		}
+	}
+
+	if len(rrs) > 0 {
+		return rrs, true, nil
+	}
+
+	exists, err := record.HasDescendants(ctx, s.DB, name)
+	if err != nil || exists {
+		return rrs, exists, err
+	}
+
+	for encloser := parent(name); ; encloser = parent(encloser) {
+		rrs, err := s.rrsByName(ctx, "*."+encloser, name)
+		if err != nil || len(rrs) > 0 {
+			return rrs, len(rrs) > 0, err
+		}
+
+		if encloser == zone {
+			return nil, false, nil
+		}
+
+		stored, err := s.rrsByName(ctx, encloser, encloser)
+		if err != nil {
+			return nil, false, err
+		}
+
+		exists, err := record.HasDescendants(ctx, s.DB, encloser)
+		if err != nil {
+			return nil, false, err
+		}
+
+		// The closest encloser has no wildcard
+		if len(stored) > 0 || exists {
+			return nil, false, nil
+		}
+	}
+}
+
+// rrsByName returns the stored answers of the records named owner, as
+// resource records for name
+func (s *Server) rrsByName(ctx context.Context, owner, name string) (rrSet, error) {
+	dbRecords, err := models.Records(
+		qm.Where("record=?", owner),
+		qm.Load(models.RecordRels.Answers, answer.QMServed()),
+		qm.Load(models.RecordRels.Answers+"."+models.AnswerRels.AnswerDetail),
+	).All(ctx, s.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	rrs := rrSet{}
+
+	for _, dbRecord := range dbRecords {
+		for _, dbAnswer := range dbRecord.R.Answers {
+			a := &answer.Answer{}
+			if err := a.FromDBModel(dbAnswer); err != nil {
+				return nil, err
+			}
+
+			rr, err := a.RR(name)
+			if err != nil {
+				return nil, err
+			}
+
+			rrs.add(rr)
+		}
+	}
+
+	return rrs, nil
+}
+
+// addApex adds the SOA, and a NS when none are stored, to the zone apex
+func (s *Server) addApex(ctx context.Context, rrs rrSet, zone string) error {
+	soa, err := s.soa(ctx, zone)
+	if err != nil {
+		return err
+	}
+
+	rrs.add(soa)
+
+	if len(rrs[dns.TypeNS]) == 0 {
+		rrs.add(&dns.NS{
+			Hdr: dns.RR_Header{Name: zone, Rrtype: dns.TypeNS, Class: dns.ClassINET, Ttl: uint32(answer.DefaultTTL)},
+			Ns:  soa.Ns,
+		})
+	}
+
+	return nil
+}
+
+// addNegative adds the SOA to the authority section of a NXDOMAIN or NODATA
+// response, its ttl caps how long the response is cached (RFC 2308 3)
+func (s *Server) addNegative(ctx context.Context, m *dns.Msg, zone string) error {
+	soa, err := s.soa(ctx, zone)
+	if err != nil {
+		return err
+	}
+
+	if soa.Minttl < soa.Hdr.Ttl {
+		soa.Hdr.Ttl = soa.Minttl
+	}
+
+	m.Ns = append(m.Ns, soa)
+
+	return nil
+}
+
+// soa returns the SOA of a zone
+func (s *Server) soa(ctx context.Context, zone string) (*dns.SOA, error) {
+	serial, err := zx.Serial(ctx, s.DB, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	return zx.SOA(zone, s.Nameserver, s.Mailbox, serial, s.NegativeTTL), nil
+}
+
+// maxSize returns the largest response the client takes. UDP responses are
+// limited to 512 bytes (RFC 1035 4.2.1), or to the payload size of the
+// request's OPT record (RFC 6891 6.2.5).
+func maxSize(w dns.ResponseWriter, req *dns.Msg) int {
+	if w.LocalAddr().Network() != "udp" {
+		return dns.MaxMsgSize
+	}
+
+	opt := req.IsEdns0()
+	if opt == nil {
+		return dns.MinMsgSize
+	}
+
+	size := opt.UDPSize()
+
+	switch {
+	case size < dns.MinMsgSize:
+		size = dns.MinMsgSize
+	case size > ednsSize:
+		size = ednsSize
+	}
+
+	return int(size)
+}
+
+// zoneFor returns the most specific zone the name is in, or "" when the
+// server isn't authoritative for it
+func (s *Server) zoneFor(name string) string {
+	zone := ""
+
+	for _, z := range s.Zones {
+		if dns.IsSubDomain(z, name) && len(z) > len(zone) {
+			zone = z
+		}
+	}
+
+	return zone
+}
+
+// add appends a resource record, skipping duplicates from different owners
+func (rrs rrSet) add(rr dns.RR) {
+	t := rr.Header().Rrtype
+
+	for _, existing := range rrs[t] {
+		if dns.IsDuplicate(existing, rr) {
+			return
+		}
+	}
+
+	rrs[t] = append(rrs[t], rr)
+}
+
+func parent(name string) string {
+	if i, end := dns.NextLabel(name, 0); !end {
+		return name[i:]
+	}
+
+	return "."
+}
+
+func canonicalZones(zones []string) []string {
+	canonical := make([]string, 0, len(zones))
+
+	for _, z := range zones {
+		canonical = append(canonical, strings.ToLower(dns.Fqdn(z)))
+	}
+
+	return canonical
+}
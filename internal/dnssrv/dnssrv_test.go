@@ -0,0 +1,76 @@
+package dnssrv
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/miekg/dns"
+)
+
+// testWriter is a response writer that only has a local address
+type testWriter struct {
+	dns.ResponseWriter
+	addr net.Addr
+}
+
+func (w *testWriter) LocalAddr() net.Addr {
+	return w.addr
+}
+
+func TestMaxSize(t *testing.T) {
+	udp := &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 53}
+	tcp := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 53}
+
+	tests := []struct {
+		name string
+		addr net.Addr
+		edns uint16
+		want int
+	}{
+		{name: "udp", addr: udp, want: dns.MinMsgSize},
+		{name: "udp edns", addr: udp, edns: 1200, want: 1200},
+		{name: "udp small edns", addr: udp, edns: 256, want: dns.MinMsgSize},
+		{name: "udp large edns", addr: udp, edns: 4096, want: int(ednsSize)},
+		{name: "tcp", addr: tcp, want: dns.MaxMsgSize},
+		{name: "tcp edns", addr: tcp, edns: 1200, want: dns.MaxMsgSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &dns.Msg{}
+			req.SetQuestion("_web._tcp.example.com.", dns.TypeSRV)
+
+			if tt.edns > 0 {
+				req.SetEdns0(tt.edns, false)
+			}
+
+			if got := maxSize(&testWriter{addr: tt.addr}, req); got != tt.want {
+				t.Errorf("maxSize() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	req := &dns.Msg{}
+	req.SetQuestion("_web._tcp.example.com.", dns.TypeSRV)
+
+	m := &dns.Msg{}
+	m.SetReply(req)
+
+	for i := 0; i < 50; i++ {
+		rr, err := dns.NewRR(fmt.Sprintf("_web._tcp.example.com. 300 IN SRV 10 10 443 web%d.example.com.", i))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		m.Answer = append(m.Answer, rr)
+	}
+
+	m.Truncate(maxSize(&testWriter{addr: &net.UDPAddr{}}, req))
+
+	if !m.Truncated || m.Len() > dns.MinMsgSize {
+		t.Errorf("Truncate() = %d bytes, truncated %t, want at most %d bytes truncated", m.Len(), m.Truncated, dns.MinMsgSize)
+	}
+}
@@ -0,0 +1,285 @@
+// Package healthcheck probes the endpoints behind answers, an answer that
+// fails its checks is withdrawn until it recovers.
+//
+// Every replica of the server may run a checker, only the one holding the
+// health check lock probes. Two checkers probing at once would each count
+// their probes, and withdraw an answer after half its falls.
+package healthcheck
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/cockroach-go/v2/crdb/crdbsqlx"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"go.uber.org/zap"
+
+	"go.hollow.sh/dnscontroller/internal/models"
+	answer "go.hollow.sh/dnscontroller/pkg/api/v1/answers"
+	"go.hollow.sh/dnscontroller/pkg/api/v1/audit"
+	"go.hollow.sh/dnscontroller/pkg/api/v1/outbox"
+	record "go.hollow.sh/dnscontroller/pkg/api/v1/records"
+)
+
+// Actor is who the checker's withdrawals are recorded as in the audit log
+const Actor = "system:healthcheck"
+
+// lockName is the row of the locks table the checkers contend for
+const lockName = "healthcheck"
+
+// Checker probes every answer that has a port. An answer becomes unhealthy
+// after Fall consecutive failures and healthy again after Rise consecutive
+// successes.
+type Checker struct {
+	Logger   *zap.SugaredLogger
+	DB       *sqlx.DB
+	Interval time.Duration
+	Timeout  time.Duration
+	Rise     int64
+	Fall     int64
+	// Concurrency limits how many probes run at once
+	Concurrency int
+	// HTTPPath is requested by the http and https checks
+	HTTPPath string
+	// Lock is how long the checker holds the health check lock without
+	// renewing it, it should be well above the time a run takes. The
+	// checker doesn't take the lock when it is 0, for when it is the only
+	// checker.
+	Lock time.Duration
+	// Holder identifies the checker in the lock, a random id is used when it
+	// is empty
+	Holder string
+}
+
+// Run checks every interval until the context is done
+func (c *Checker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+
+	// Another checker can take over right away rather than once the lock
+	// expires
+	defer c.release()
+
+	for {
+		if err := c.CheckAll(ctx); err != nil {
+			c.Logger.Errorw("failed health checking answers", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckAll probes each answer once, an answer that fails to be stored is
+// logged and doesn't stop the others. Nothing is probed while another checker
+// holds the lock.
+func (c *Checker) CheckAll(ctx context.Context) error {
+	held, err := c.hold(ctx)
+	if err != nil || !held {
+		return err
+	}
+
+	dbAnswers, err := models.Answers(
+		qm.InnerJoin("answer_details ON answer_details.answer_id = answers.id"),
+		qm.Where("answer_details.port IS NOT NULL"),
+		qm.Where("answers.target!=?", "."),
+		qm.Load(models.AnswerRels.Record),
+		qm.Load(models.AnswerRels.AnswerDetail),
+	).All(ctx, c.DB)
+	if err != nil {
+		return err
+	}
+
+	probes := c.probes()
+	sem := make(chan struct{}, c.concurrency())
+	wg := sync.WaitGroup{}
+
+	for _, dbAnswer := range dbAnswers {
+		detail := dbAnswer.R.AnswerDetail
+
+		probe, ok := probes[strings.ToLower(detail.Protocol.String)]
+		if !ok {
+			c.Logger.Debugw("skipping answer", "answer", dbAnswer.ID, "protocol", detail.Protocol.String, "error", ErrorUnsupportedProtocol)
+			continue
+		}
+
+		sem <- struct{}{}
+
+		wg.Add(1)
+
+		go func(dbAnswer *models.Answer) {
+			defer func() { <-sem; wg.Done() }()
+
+			if err := c.check(ctx, dbAnswer, probe); err != nil {
+				c.Logger.Errorw("failed storing answer health", "answer", dbAnswer.ID, "error", err)
+			}
+		}(dbAnswer)
+	}
+
+	wg.Wait()
+
+	return nil
+}
+
+// check probes a single answer and stores the result, the answer must have
+// its record and details loaded
+func (c *Checker) check(ctx context.Context, dbAnswer *models.Answer, probe probeFunc) error {
+	host := strings.TrimSuffix(dbAnswer.Target, ".")
+	addr := net.JoinHostPort(host, strconv.FormatInt(dbAnswer.R.AnswerDetail.Port.Int64, 10))
+
+	probeCtx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
+	probeErr := probe(probeCtx, host, addr)
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return ctx.Err()
+	}
+
+	// The counters are read again and updated in a transaction, the answer
+	// may have changed while it was probed
+	return crdbsqlx.ExecuteTx(ctx, c.DB, nil, func(tx *sqlx.Tx) error {
+		current, err := models.Answers(
+			models.AnswerWhere.ID.EQ(dbAnswer.ID),
+			qm.Load(models.AnswerRels.Owner),
+			qm.Load(models.AnswerRels.AnswerDetail),
+			qm.For("UPDATE"),
+		).One(ctx, tx)
+
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			// Deleted while it was probed
+			return nil
+		case err != nil:
+			return err
+		}
+
+		return c.store(ctx, tx, current, dbAnswer.R.Record, addr, probeErr)
+	})
+}
+
+// store counts the result of a probe towards the health of the answer, a
+// change in health is recorded like any other change to the answer
+func (c *Checker) store(ctx context.Context, exec boil.ContextExecutor, dbAnswer *models.Answer, dbRecord *models.Record, addr string, probeErr error) error {
+	before := &answer.Answer{}
+	if err := before.FromDBModel(dbAnswer); err != nil {
+		return err
+	}
+
+	columns := []string{
+		models.AnswerColumns.Healthy,
+		models.AnswerColumns.HealthSuccesses,
+		models.AnswerColumns.HealthFailures,
+		models.AnswerColumns.HealthCheckedAt,
+		models.AnswerColumns.HealthError,
+	}
+
+	if probeErr == nil {
+		dbAnswer.HealthSuccesses++
+		dbAnswer.HealthFailures = 0
+		dbAnswer.HealthError = null.String{}
+		dbAnswer.Healthy = dbAnswer.Healthy || dbAnswer.HealthSuccesses >= c.Rise
+	} else {
+		dbAnswer.HealthFailures++
+		dbAnswer.HealthSuccesses = 0
+		dbAnswer.HealthError = null.StringFrom(probeErr.Error())
+		dbAnswer.Healthy = dbAnswer.Healthy && dbAnswer.HealthFailures < c.Fall
+	}
+
+	dbAnswer.HealthCheckedAt = null.TimeFrom(time.Now())
+
+	if before.Health.Healthy == dbAnswer.Healthy {
+		_, err := dbAnswer.Update(ctx, exec, boil.Whitelist(columns...))
+
+		return err
+	}
+
+	c.Logger.Infow("answer health changed", "answer", dbAnswer.ID, "target", addr, "healthy", dbAnswer.Healthy, "error", probeErr)
+
+	// A change in health changes what is served
+	columns = append(columns, models.AnswerColumns.UpdatedAt)
+	ctx = audit.WithActor(ctx, audit.Actor{Subject: Actor})
+
+	if _, err := dbAnswer.Update(ctx, exec, boil.Whitelist(columns...)); err != nil {
+		return err
+	}
+
+	if err := record.BumpSerial(ctx, exec, dbAnswer.RecordID); err != nil {
+		return err
+	}
+
+	after := &answer.Answer{}
+	if err := after.FromDBModel(dbAnswer); err != nil {
+		return err
+	}
+
+	e := &audit.Event{
+		Operation:  audit.AnswerUpdate,
+		Record:     dbRecord.Record,
+		RecordType: dbRecord.RecordType,
+		OwnerID:    dbAnswer.OwnerID,
+	}
+
+	if err := audit.Write(ctx, exec, e, before, after); err != nil {
+		return err
+	}
+
+	return outbox.WriteChange(ctx, exec, &outbox.Message{Event: e.Operation, Record: e.Record, RecordType: e.RecordType, OwnerID: e.OwnerID}, before, after)
+}
+
+// hold takes or renews the health check lock and reports whether the checker
+// holds it. The lock is taken over once its holder stopped renewing it.
+func (c *Checker) hold(ctx context.Context) (bool, error) {
+	if c.Lock <= 0 {
+		return true, nil
+	}
+
+	if c.Holder == "" {
+		c.Holder = uuid.NewString()
+	}
+
+	res, err := c.DB.ExecContext(ctx,
+		"INSERT INTO locks (name, holder, expires_at) VALUES ($1, $2, now() + $3::INT8 * INTERVAL '1 millisecond') "+
+			"ON CONFLICT (name) DO UPDATE SET holder = excluded.holder, expires_at = excluded.expires_at "+
+			"WHERE locks.holder = excluded.holder OR locks.expires_at < now()",
+		lockName, c.Holder, c.Lock.Milliseconds(),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := res.RowsAffected()
+
+	return n == 1, err
+}
+
+// release gives the health check lock up if the checker holds it
+func (c *Checker) release() {
+	if c.Lock <= 0 || c.Holder == "" {
+		return
+	}
+
+	if _, err := c.DB.Exec("DELETE FROM locks WHERE name = $1 AND holder = $2", lockName, c.Holder); err != nil {
+		c.Logger.Errorw("failed releasing the health check lock", "error", err)
+	}
+}
+
+func (c *Checker) concurrency() int {
+	if c.Concurrency < 1 {
+		return 1
+	}
+
+	return c.Concurrency
+}
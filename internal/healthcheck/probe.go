@@ -0,0 +1,90 @@
+package healthcheck
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrorUnsupportedProtocol when an answer's protocol can't be probed, the
+	// answer is left as it is
+	ErrorUnsupportedProtocol = errors.New("protocol can't be health checked")
+	// ErrorUnhealthyStatus when an HTTP check gets a response outside of 2xx
+	// and 3xx
+	ErrorUnhealthyStatus = errors.New("unhealthy http status")
+)
+
+// probeFunc checks a single target
+type probeFunc func(ctx context.Context, host, addr string) error
+
+// probes maps an answer protocol to the check used for it, answers without
+// a protocol get a TCP connect
+func (c *Checker) probes() map[string]probeFunc {
+	return map[string]probeFunc{
+		"":      probeTCP,
+		"tcp":   probeTCP,
+		"tls":   probeTLS,
+		"http":  c.probeHTTP("http"),
+		"https": c.probeHTTP("https"),
+	}
+}
+
+func probeTCP(ctx context.Context, host, addr string) error {
+	d := &net.Dialer{}
+
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
+
+func probeTLS(ctx context.Context, host, addr string) error {
+	d := &tls.Dialer{Config: &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}}
+
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
+
+func (c *Checker) probeHTTP(scheme string) probeFunc {
+	client := &http.Client{
+		// A redirect is a healthy response
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	return func(ctx context.Context, host, addr string) error {
+		url := fmt.Sprintf("%s://%s/%s", scheme, addr, strings.TrimPrefix(c.HTTPPath, "/"))
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+
+		req.Host = host
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+
+		defer resp.Body.Close()
+
+		if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+			return fmt.Errorf("%w: %s", ErrorUnhealthyStatus, resp.Status)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,81 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProbes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/healthz":
+			w.WriteHeader(http.StatusOK)
+		case "/moved":
+			http.Redirect(w, r, "/elsewhere", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	addr := strings.TrimPrefix(srv.URL, "http://")
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	closedAddr := closed.Addr().String()
+	closed.Close()
+
+	tests := []struct {
+		name     string
+		protocol string
+		path     string
+		addr     string
+		wantErr  bool
+		errIs    error
+	}{
+		{name: "tcp open", protocol: "tcp", addr: addr},
+		{name: "no protocol is tcp", protocol: "", addr: addr},
+		{name: "tcp closed", protocol: "tcp", addr: closedAddr, wantErr: true},
+		{name: "http ok", protocol: "http", path: "/healthz", addr: addr},
+		{name: "http redirect", protocol: "http", path: "moved", addr: addr},
+		{name: "http unavailable", protocol: "http", path: "/", addr: addr, wantErr: true, errIs: ErrorUnhealthyStatus},
+		{name: "tls against plain tcp", protocol: "tls", addr: addr, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Checker{HTTPPath: tt.path}
+
+			probe, ok := c.probes()[tt.protocol]
+			if !ok {
+				t.Fatalf("no probe for %q", tt.protocol)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+
+			err := probe(ctx, "127.0.0.1", tt.addr)
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("probe() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.errIs != nil && !errors.Is(err, tt.errIs) {
+				t.Errorf("probe() error = %v, want %v", err, tt.errIs)
+			}
+		})
+	}
+
+	if _, ok := (&Checker{}).probes()["udp"]; ok {
+		t.Error("udp answers should not be probed")
+	}
+}
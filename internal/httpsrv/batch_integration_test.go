@@ -0,0 +1,69 @@
+//go:build integration
+
+package httpsrv
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	answer "go.hollow.sh/dnscontroller/pkg/api/v1/answers"
+	"go.hollow.sh/dnscontroller/pkg/api/v1/batch"
+	"go.hollow.sh/dnscontroller/pkg/api/v1/client"
+	owner "go.hollow.sh/dnscontroller/pkg/api/v1/owners"
+	record "go.hollow.sh/dnscontroller/pkg/api/v1/records"
+)
+
+func TestBatch(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t)
+
+	const name1, name2 = "batch1.example.com.", "batch2.example.com."
+
+	t.Cleanup(func() {
+		_ = c.DeleteRecord(ctx, name1, "A")
+		_ = c.DeleteRecord(ctx, name2, "A")
+	})
+
+	o := &owner.Owner{Name: "batch-test", Origin: "test", Service: "web"}
+	ops := []*answer.Operation{
+		{Op: batch.OpUpsert, Record: name1, RecordType: "A", Owner: o, Answer: &answer.Answer{Target: "192.0.2.1"}},
+		{Op: batch.OpCreate, Record: name2, RecordType: "A", Owner: o, Answer: &answer.Answer{Target: "192.0.2.2"}},
+		{Op: batch.OpCreate, Record: name1, RecordType: "A", Owner: o, Answer: &answer.Answer{Target: "192.0.2.1"}},
+	}
+
+	// The second create of the same answer rolls the whole batch back
+	_, err := c.BatchAnswers(ctx, &answer.Batch{Mode: batch.ModeAtomic, Operations: ops})
+	if !errors.Is(err, answer.ErrorAnswerExists) {
+		t.Fatalf("atomic BatchAnswers() error = %v, want %v", err, answer.ErrorAnswerExists)
+	}
+
+	if _, err := c.GetRecord(ctx, name1, "A"); !errors.Is(err, client.ErrorNotFound) {
+		t.Fatalf("GetRecord() after a rolled back batch error = %v, want %v", err, client.ErrorNotFound)
+	}
+
+	results, err := c.BatchAnswers(ctx, &answer.Batch{Mode: batch.ModeBestEffort, Operations: ops})
+	if err != nil {
+		t.Fatalf("best effort BatchAnswers() error = %v", err)
+	}
+
+	wantStatuses := []int{http.StatusOK, http.StatusOK, http.StatusConflict}
+	for i, r := range results {
+		if r.Status != wantStatuses[i] {
+			t.Errorf("result %d status = %d, want %d", i, r.Status, wantStatuses[i])
+		}
+	}
+
+	results, err = c.BatchRecords(ctx, &record.Batch{Operations: []*record.Operation{
+		{Op: batch.OpDelete, Name: name1, Type: "A"},
+		{Op: batch.OpDelete, Name: name2, Type: "A"},
+	}})
+	if err != nil || len(results) != 2 {
+		t.Fatalf("BatchRecords() = %v, %v, want 2 results", results, err)
+	}
+
+	if _, err := c.GetRecord(ctx, name2, "A"); !errors.Is(err, client.ErrorNotFound) {
+		t.Errorf("GetRecord() after deleting it error = %v, want %v", err, client.ErrorNotFound)
+	}
+}
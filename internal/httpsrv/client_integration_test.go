@@ -0,0 +1,147 @@
+//go:build integration
+
+package httpsrv
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq" // Register the Postgres driver.
+	"go.hollow.sh/toolbox/ginjwt"
+	"go.uber.org/zap"
+
+	answer "go.hollow.sh/dnscontroller/pkg/api/v1/answers"
+	"go.hollow.sh/dnscontroller/pkg/api/v1/client"
+	owner "go.hollow.sh/dnscontroller/pkg/api/v1/owners"
+	record "go.hollow.sh/dnscontroller/pkg/api/v1/records"
+)
+
+// newTestDB opens the migrated test database, see the integration-test
+// target of the Makefile
+func newTestDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	uri := os.Getenv("DNSCONTROLLER_DB_URI")
+	if uri == "" {
+		t.Skip("DNSCONTROLLER_DB_URI isn't set")
+	}
+
+	db := sqlx.MustOpen("postgres", uri)
+	t.Cleanup(func() { _ = db.Close() })
+
+	return db
+}
+
+// newTestClient serves the API from the test database
+func newTestClient(t *testing.T) *client.Client {
+	t.Helper()
+
+	return newTestClientWithDB(t, newTestDB(t))
+}
+
+func newTestClientWithDB(t *testing.T, db *sqlx.DB) *client.Client {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+
+	s := &Server{
+		Logger:     zap.NewNop().Sugar(),
+		DB:         db,
+		AuthConfig: ginjwt.AuthConfig{Enabled: false},
+	}
+
+	srv := httptest.NewServer(s.setup())
+	t.Cleanup(srv.Close)
+
+	return client.New(srv.URL, "")
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t)
+
+	const name, rtype = "_web._tcp.client-test.example.com.", "SRV"
+
+	o := &owner.Owner{Name: "client-test", Origin: "test", Service: "web"}
+	port := int64(443)
+
+	if err := c.CreateAnswer(ctx, name, rtype, o, &answer.Answer{Target: "web1.example.com.", Port: &port}); err != nil {
+		t.Fatalf("CreateAnswer() error = %v", err)
+	}
+
+	t.Cleanup(func() { _ = c.DeleteRecord(ctx, name, rtype) })
+
+	if _, err := c.GetRecord(ctx, name, rtype); err != nil {
+		t.Fatalf("GetRecord() error = %v", err)
+	}
+
+	port = 8443
+	if err := c.UpdateAnswer(ctx, name, rtype, o, &answer.Answer{Target: "web1.example.com.", Port: &port}); err != nil {
+		t.Fatalf("UpdateAnswer() error = %v", err)
+	}
+
+	answers, err := c.ListAnswers(ctx, name, rtype)
+	if err != nil {
+		t.Fatalf("ListAnswers() error = %v", err)
+	}
+
+	if len(answers) != 1 || answers[0].Port == nil || *answers[0].Port != port {
+		t.Fatalf("ListAnswers() = %v, want one answer on port %d", answers, port)
+	}
+
+	records, err := c.AllRecords(ctx, &record.ListParams{Type: rtype, Owner: o.Name, Origin: o.Origin})
+	if err != nil || len(records) != 1 || records[0].Name != name {
+		t.Fatalf("AllRecords() = %v, %v, want %s", records, err, name)
+	}
+
+	if err := c.CreateOwner(ctx, o); err != nil {
+		t.Fatalf("CreateOwner() error = %v", err)
+	}
+
+	if renewed, err := c.RenewOwner(ctx, o.UUID); err != nil || renewed != 0 {
+		t.Fatalf("RenewOwner() = %d, %v, want 0 without leases", renewed, err)
+	}
+
+	if err := c.DeleteAnswer(ctx, name, rtype, o, &answer.Answer{Target: "web1.example.com."}); err != nil {
+		t.Fatalf("DeleteAnswer() error = %v", err)
+	}
+
+	if err := c.DeleteOwner(ctx, o.UUID); err != nil {
+		t.Fatalf("DeleteOwner() error = %v", err)
+	}
+
+	if _, err := c.GetOwner(ctx, o.UUID); !errors.Is(err, client.ErrorNotFound) {
+		t.Errorf("GetOwner() error = %v, want %v", err, client.ErrorNotFound)
+	}
+
+	if err := c.DeleteRecord(ctx, name, rtype); err != nil {
+		t.Fatalf("DeleteRecord() error = %v", err)
+	}
+
+	if _, err := c.GetRecord(ctx, name, rtype); !errors.Is(err, client.ErrorNotFound) {
+		t.Errorf("GetRecord() error = %v, want %v", err, client.ErrorNotFound)
+	}
+}
+
+func TestClientErrors(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t)
+
+	if err := c.CreateRecord(ctx, "a..b.example.com.", "A"); !errors.Is(err, record.ErrorEmptyLabel) {
+		t.Errorf("CreateRecord() error = %v, want %v", err, record.ErrorEmptyLabel)
+	}
+
+	if err := c.CreateRecord(ctx, "www.example.com.", "SPF"); !errors.Is(err, record.ErrorUnsupportedType) {
+		t.Errorf("CreateRecord() error = %v, want %v", err, record.ErrorUnsupportedType)
+	}
+
+	o := &owner.Owner{Origin: "test", Service: "web"}
+	if err := c.CreateAnswer(ctx, "www.example.com.", "A", o, &answer.Answer{Target: "192.0.2.1"}); !errors.Is(err, owner.ErrorNoOwnerName) {
+		t.Errorf("CreateAnswer() error = %v, want %v", err, owner.ErrorNoOwnerName)
+	}
+}
@@ -0,0 +1,55 @@
+//go:build integration
+
+package httpsrv
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	answer "go.hollow.sh/dnscontroller/pkg/api/v1/answers"
+	owner "go.hollow.sh/dnscontroller/pkg/api/v1/owners"
+	record "go.hollow.sh/dnscontroller/pkg/api/v1/records"
+)
+
+func TestCNAMEExclusive(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t)
+
+	const alias, host = "cname-test.example.com.", "host.cname-test.example.com."
+
+	t.Cleanup(func() {
+		_ = c.DeleteRecord(ctx, alias, "CNAME")
+		_ = c.DeleteRecord(ctx, host, "A")
+	})
+
+	o := &owner.Owner{Name: "cname-test", Origin: "test", Service: "web"}
+	other := &owner.Owner{Name: "cname-test", Origin: "test", Service: "other"}
+
+	if err := c.CreateAnswer(ctx, alias, "CNAME", o, &answer.Answer{Target: "www.example.com."}); err != nil {
+		t.Fatalf("CreateAnswer() of the CNAME error = %v", err)
+	}
+
+	// A CNAME has a single target, whoever adds the second one
+	if err := c.CreateAnswer(ctx, alias, "CNAME", other, &answer.Answer{Target: "web.example.com."}); !errors.Is(err, answer.ErrorCNAMETarget) {
+		t.Errorf("CreateAnswer() of a second CNAME target error = %v, want %v", err, answer.ErrorCNAMETarget)
+	}
+
+	// The same target of another owner is still the single target
+	if err := c.CreateAnswer(ctx, alias, "CNAME", other, &answer.Answer{Target: "www.example.com."}); err != nil {
+		t.Errorf("CreateAnswer() of the same CNAME target error = %v", err)
+	}
+
+	// And no other record can share the name
+	if err := c.CreateAnswer(ctx, alias, "A", o, &answer.Answer{Target: "192.0.2.1"}); !errors.Is(err, record.ErrorCNAMEConflict) {
+		t.Errorf("CreateAnswer() of an A next to the CNAME error = %v, want %v", err, record.ErrorCNAMEConflict)
+	}
+
+	if err := c.CreateAnswer(ctx, host, "A", o, &answer.Answer{Target: "192.0.2.1"}); err != nil {
+		t.Fatalf("CreateAnswer() of the A error = %v", err)
+	}
+
+	if err := c.CreateAnswer(ctx, host, "CNAME", o, &answer.Answer{Target: "www.example.com."}); !errors.Is(err, record.ErrorCNAMEConflict) {
+		t.Errorf("CreateAnswer() of a CNAME next to the A error = %v, want %v", err, record.ErrorCNAMEConflict)
+	}
+}
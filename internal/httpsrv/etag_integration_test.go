@@ -0,0 +1,75 @@
+//go:build integration
+
+package httpsrv
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRecordETag(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t)
+
+	const path = "/api/v1/records/etag-test.example.com./A"
+
+	do := func(method, suffix, header, value, body string) *http.Response {
+		t.Helper()
+
+		req, err := http.NewRequestWithContext(ctx, method, c.URL+path+suffix, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req.Header.Set("Content-Type", "application/json")
+
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_ = resp.Body.Close()
+
+		return resp
+	}
+
+	const answer = `{"owner":{"name":"etag-test","origin":"test","service":"web"},"answer":{"target":"192.0.2.1"}}`
+
+	if resp := do(http.MethodPost, "", "If-None-Match", "*", ""); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("POST If-None-Match: * = %d, want %d", resp.StatusCode, http.StatusCreated)
+	}
+
+	t.Cleanup(func() { _ = c.DeleteRecord(ctx, "etag-test.example.com.", "A") })
+
+	if resp := do(http.MethodPost, "", "If-None-Match", "*", ""); resp.StatusCode != http.StatusPreconditionFailed {
+		t.Fatalf("POST If-None-Match: * of an existing record = %d, want %d", resp.StatusCode, http.StatusPreconditionFailed)
+	}
+
+	etag := do(http.MethodGet, "", "", "", "").Header.Get("ETag")
+	if etag == "" {
+		t.Fatal("GET returned no ETag")
+	}
+
+	if resp := do(http.MethodGet, "", "If-None-Match", etag, ""); resp.StatusCode != http.StatusNotModified {
+		t.Fatalf("GET If-None-Match = %d, want %d", resp.StatusCode, http.StatusNotModified)
+	}
+
+	if resp := do(http.MethodPost, "/answers", "If-Match", etag, answer); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("POST answer If-Match = %d, want %d", resp.StatusCode, http.StatusCreated)
+	}
+
+	// The answer changed the ETag
+	if resp := do(http.MethodPost, "/answers", "If-Match", etag, answer); resp.StatusCode != http.StatusPreconditionFailed {
+		t.Fatalf("POST answer with a stale If-Match = %d, want %d", resp.StatusCode, http.StatusPreconditionFailed)
+	}
+
+	if got := do(http.MethodGet, "/answers", "", "", "").Header.Get("ETag"); got == etag {
+		t.Fatalf("ETag = %s after adding an answer, want it changed", got)
+	}
+}
@@ -0,0 +1,88 @@
+//go:build integration
+
+package httpsrv
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.hollow.sh/dnscontroller/internal/healthcheck"
+	answer "go.hollow.sh/dnscontroller/pkg/api/v1/answers"
+	owner "go.hollow.sh/dnscontroller/pkg/api/v1/owners"
+)
+
+func TestHealthCheckLock(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	c := newTestClientWithDB(t, db)
+
+	const name = "www.health-lock-test.example.com."
+
+	t.Cleanup(func() { _, _ = db.Exec("DELETE FROM locks WHERE holder LIKE 'health-lock-test-%'") })
+
+	// Nothing listens on the port once the listener is closed
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening: %v", err)
+	}
+
+	port := int64(l.Addr().(*net.TCPAddr).Port)
+	_ = l.Close()
+
+	o := &owner.Owner{Name: "health-lock-test", Origin: "test", Service: "web"}
+	if err := c.CreateAnswer(ctx, name, "A", o, &answer.Answer{Target: "127.0.0.1", Port: &port}); err != nil {
+		t.Fatalf("CreateAnswer() error = %v", err)
+	}
+
+	t.Cleanup(func() { _ = c.DeleteRecord(ctx, name, "A") })
+
+	newChecker := func(holder string) *healthcheck.Checker {
+		return &healthcheck.Checker{
+			Logger:  zap.NewNop().Sugar(),
+			DB:      db,
+			Timeout: time.Second,
+			Rise:    1,
+			Fall:    1,
+			Lock:    time.Minute,
+			Holder:  holder,
+		}
+	}
+
+	held := newChecker("health-lock-test-1")
+	if err := held.CheckAll(ctx); err != nil {
+		t.Fatalf("CheckAll() error = %v", err)
+	}
+
+	health := func() *answer.Health {
+		t.Helper()
+
+		answers, err := c.ListAnswers(ctx, name, "A")
+		if err != nil || len(answers) != 1 {
+			t.Fatalf("ListAnswers() = %v, %v, want one answer", answers, err)
+		}
+
+		return answers[0].Health
+	}
+
+	failures := health().Failures
+
+	if err := newChecker("health-lock-test-2").CheckAll(ctx); err != nil {
+		t.Fatalf("CheckAll() error = %v", err)
+	}
+
+	if got := health().Failures; got != failures {
+		t.Fatalf("failures = %d after a check without the lock, want %d", got, failures)
+	}
+
+	if err := held.CheckAll(ctx); err != nil {
+		t.Fatalf("CheckAll() error = %v", err)
+	}
+
+	if h := health(); h.Failures != failures+1 || h.Healthy {
+		t.Errorf("health = %+v after a failed check with the lock, want %d failures and withdrawn", h, failures+1)
+	}
+}
@@ -28,6 +28,8 @@ type Server struct {
 	Debug          bool
 	DB             *sqlx.DB
 	AuthConfig     ginjwt.AuthConfig
+	OwnerClaim     string
+	ClaimUnowned   bool
 	TrustedProxies []string
 	TemplateFields map[string]template.Template
 }
@@ -101,7 +103,9 @@ func (s *Server) setup() *gin.Engine {
 	r.GET("/healthz/liveness", s.livenessCheck)
 	r.GET("/healthz/readiness", s.readinessCheck)
 
-	v1Rtr := v1router.New(authMW, s.DB, s.Logger)
+	ownerAuth := v1router.OwnerAuth{Enabled: s.AuthConfig.Enabled, Claim: s.OwnerClaim, ClaimUnowned: s.ClaimUnowned}
+
+	v1Rtr := v1router.New(authMW, ownerAuth, s.DB, s.Logger)
 
 	// Host our latest version of the API under / in addition to /api/v*
 	latest := r.Group("/")
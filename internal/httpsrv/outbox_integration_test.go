@@ -0,0 +1,198 @@
+//go:build integration
+
+package httpsrv
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.hollow.sh/dnscontroller/internal/relay"
+	"go.hollow.sh/dnscontroller/internal/sink/memory"
+	answer "go.hollow.sh/dnscontroller/pkg/api/v1/answers"
+	"go.hollow.sh/dnscontroller/pkg/api/v1/outbox"
+	owner "go.hollow.sh/dnscontroller/pkg/api/v1/owners"
+)
+
+func TestOutboxRelay(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	c := newTestClientWithDB(t, db)
+
+	const name, other = "www.outbox-test.example.com.", "api.outbox-test.example.com."
+
+	o := &owner.Owner{Name: "outbox-test", Origin: "test", Service: "web"}
+	if err := c.CreateAnswer(ctx, name, "A", o, &answer.Answer{Target: "192.0.2.1", TTL: 300}); err != nil {
+		t.Fatalf("CreateAnswer() error = %v", err)
+	}
+
+	t.Cleanup(func() { _ = c.DeleteRecord(ctx, name, "A") })
+
+	if err := c.UpdateAnswer(ctx, name, "A", o, &answer.Answer{Target: "192.0.2.1", TTL: 60}); err != nil {
+		t.Fatalf("UpdateAnswer() error = %v", err)
+	}
+
+	if err := c.DeleteAnswer(ctx, name, "A", o, &answer.Answer{Target: "192.0.2.1"}); err != nil {
+		t.Fatalf("DeleteAnswer() error = %v", err)
+	}
+
+	if err := c.CreateAnswer(ctx, other, "A", o, &answer.Answer{Target: "192.0.2.2"}); err != nil {
+		t.Fatalf("CreateAnswer() error = %v", err)
+	}
+
+	t.Cleanup(func() { _ = c.DeleteRecord(ctx, other, "A") })
+
+	errDown := errors.New("sink down")
+
+	s := memory.New()
+	s.Fail = func(m *outbox.Message) error {
+		if m.Record == name {
+			return errDown
+		}
+
+		return nil
+	}
+
+	// Batches of one make the failed record fill whole batches
+	r := &relay.Relay{Logger: zap.NewNop().Sugar(), DB: db, Sink: s, BatchSize: 1}
+
+	if err := r.RelayAll(ctx); err != nil {
+		t.Fatalf("RelayAll() error = %v", err)
+	}
+
+	if got := events(s.Messages(), name); len(got) != 0 {
+		t.Fatalf("published %v while the sink failed, want nothing", got)
+	}
+
+	if got := events(s.Messages(), other); len(got) == 0 {
+		t.Fatalf("published nothing of %s while %s failed", other, name)
+	}
+
+	s.Fail = nil
+
+	if err := r.RelayAll(ctx); err != nil {
+		t.Fatalf("RelayAll() error = %v", err)
+	}
+
+	want := []string{"record.create", "answer.create", "answer.update", "answer.delete"}
+
+	got := events(s.Messages(), name)
+	if len(got) < len(want) {
+		t.Fatalf("published %v, want %v first", got, want)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("published %v, want %v first", got, want)
+		}
+	}
+
+	// Delivered messages aren't published again
+	published := len(s.Messages())
+
+	if err := r.RelayAll(ctx); err != nil {
+		t.Fatalf("RelayAll() error = %v", err)
+	}
+
+	if again := s.Messages()[published:]; len(events(again, name)) != 0 {
+		t.Errorf("published %v again", events(again, name))
+	}
+}
+
+func TestOutboxOwnerDelete(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	c := newTestClientWithDB(t, db)
+
+	const name = "www.outbox-owner-test.example.com."
+
+	o := &owner.Owner{Name: "outbox-owner-test", Origin: "test", Service: "web"}
+	if err := c.CreateAnswer(ctx, name, "A", o, &answer.Answer{Target: "192.0.2.1"}); err != nil {
+		t.Fatalf("CreateAnswer() error = %v", err)
+	}
+
+	t.Cleanup(func() { _ = c.DeleteRecord(ctx, name, "A") })
+
+	if err := c.CreateOwner(ctx, o); err != nil {
+		t.Fatalf("CreateOwner() error = %v", err)
+	}
+
+	// The owner's answers go with it
+	if err := c.DeleteOwner(ctx, o.UUID); err != nil {
+		t.Fatalf("DeleteOwner() error = %v", err)
+	}
+
+	s := memory.New()
+	r := &relay.Relay{Logger: zap.NewNop().Sugar(), DB: db, Sink: s}
+
+	if err := r.RelayAll(ctx); err != nil {
+		t.Fatalf("RelayAll() error = %v", err)
+	}
+
+	for _, m := range s.Messages() {
+		if m.Record == name && m.Event == "answer.delete" && m.OwnerID == o.UUID.String() {
+			return
+		}
+	}
+
+	t.Fatalf("published %v, want answer.delete", events(s.Messages(), name))
+}
+
+func TestOutboxRelayLock(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	c := newTestClientWithDB(t, db)
+
+	const name = "www.outbox-lock-test.example.com."
+
+	t.Cleanup(func() { _, _ = db.Exec("DELETE FROM locks WHERE holder LIKE 'outbox-lock-test-%'") })
+
+	hs := memory.New()
+	held := &relay.Relay{Logger: zap.NewNop().Sugar(), DB: db, Sink: hs, Lock: time.Minute, Holder: "outbox-lock-test-1"}
+
+	if err := held.RelayAll(ctx); err != nil {
+		t.Fatalf("RelayAll() error = %v", err)
+	}
+
+	o := &owner.Owner{Name: "outbox-lock-test", Origin: "test", Service: "web"}
+	if err := c.CreateAnswer(ctx, name, "A", o, &answer.Answer{Target: "192.0.2.1"}); err != nil {
+		t.Fatalf("CreateAnswer() error = %v", err)
+	}
+
+	t.Cleanup(func() { _ = c.DeleteRecord(ctx, name, "A") })
+
+	s := memory.New()
+	r := &relay.Relay{Logger: zap.NewNop().Sugar(), DB: db, Sink: s, Lock: time.Minute, Holder: "outbox-lock-test-2"}
+
+	if err := r.RelayAll(ctx); err != nil {
+		t.Fatalf("RelayAll() error = %v", err)
+	}
+
+	if len(s.Messages()) != 0 {
+		t.Fatalf("published %v while another relay held the lock, want nothing", events(s.Messages(), name))
+	}
+
+	if err := held.RelayAll(ctx); err != nil {
+		t.Fatalf("RelayAll() error = %v", err)
+	}
+
+	if got := events(hs.Messages(), name); len(got) == 0 {
+		t.Fatalf("the relay holding the lock published nothing of %s", name)
+	}
+}
+
+// events returns the events of the messages of a record
+func events(messages []*outbox.Message, record string) []string {
+	names := []string{}
+
+	for _, m := range messages {
+		if m.Record == record {
+			names = append(names, m.Event)
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,136 @@
+//go:build integration
+
+package httpsrv
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"go.uber.org/zap"
+
+	"go.hollow.sh/dnscontroller/internal/models"
+	"go.hollow.sh/dnscontroller/internal/reaper"
+	answer "go.hollow.sh/dnscontroller/pkg/api/v1/answers"
+	"go.hollow.sh/dnscontroller/pkg/api/v1/client"
+	owner "go.hollow.sh/dnscontroller/pkg/api/v1/owners"
+)
+
+func TestReaper(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	c := newTestClientWithDB(t, db)
+
+	const (
+		shared = "www.reaper-test.example.com."
+		leased = "api.reaper-test.example.com."
+	)
+
+	lease := int64(60)
+	o := &owner.Owner{Name: "reaper-test", Origin: "test", Service: "web"}
+	other := &owner.Owner{Name: "reaper-test-other", Origin: "test", Service: "web"}
+
+	t.Cleanup(func() {
+		_ = c.DeleteRecord(ctx, shared, "A")
+		_ = c.DeleteRecord(ctx, leased, "A")
+	})
+
+	for _, tt := range []struct {
+		name string
+		o    *owner.Owner
+		a    *answer.Answer
+	}{
+		{shared, o, &answer.Answer{Target: "192.0.2.1", TTL: 60, Lease: &lease}},
+		{shared, other, &answer.Answer{Target: "192.0.2.2", TTL: 60}},
+		{leased, o, &answer.Answer{Target: "192.0.2.3", TTL: 60, Lease: &lease}},
+	} {
+		if err := c.CreateAnswer(ctx, tt.name, "A", tt.o, tt.a); err != nil {
+			t.Fatalf("CreateAnswer() error = %v", err)
+		}
+	}
+
+	answers, err := c.ListAnswers(ctx, leased, "A")
+	if err != nil || len(answers) != 1 {
+		t.Fatalf("ListAnswers() = %v, %v, want one answer", answers, err)
+	}
+
+	ownerID := answers[0].Owner.UUID
+
+	if _, err := db.ExecContext(ctx, "UPDATE answers SET expires_at = now() - INTERVAL '1 minute' WHERE owner_id = $1", ownerID.String()); err != nil {
+		t.Fatalf("expiring the leases: %v", err)
+	}
+
+	served, err := models.Answers(
+		qm.InnerJoin("records ON records.id = answers.record_id"),
+		qm.Where("records.record=?", shared),
+		answer.QMServed(),
+	).Count(ctx, db)
+	if err != nil || served != 1 {
+		t.Errorf("served answers of %s = %d, %v, want the one without a lease", shared, served, err)
+	}
+
+	// Expired answers are left for the reaper rather than renewed
+	if renewed, err := c.RenewOwner(ctx, ownerID); err != nil || renewed != 0 {
+		t.Errorf("RenewOwner() = %d, %v, want nothing renewed", renewed, err)
+	}
+
+	r := &reaper.Reaper{Logger: zap.NewNop().Sugar(), DB: db}
+	if err := r.Reap(ctx); err != nil {
+		t.Fatalf("Reap() error = %v", err)
+	}
+
+	answers, err = c.ListAnswers(ctx, shared, "A")
+	if err != nil || len(answers) != 1 || answers[0].Target != "192.0.2.2" {
+		t.Errorf("answers of %s after reaping = %v, %v, want the one without a lease", shared, answers, err)
+	}
+
+	if _, err := c.GetRecord(ctx, leased, "A"); !errors.Is(err, client.ErrorNotFound) {
+		t.Errorf("GetRecord() of a record left without answers error = %v, want %v", err, client.ErrorNotFound)
+	}
+}
+
+func TestRenew(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t)
+
+	const name = "www.renew-test.example.com."
+
+	lease := int64(60)
+	o := &owner.Owner{Name: "renew-test", Origin: "test", Service: "web"}
+
+	if err := c.CreateAnswer(ctx, name, "A", o, &answer.Answer{Target: "192.0.2.1", TTL: 60, Lease: &lease}); err != nil {
+		t.Fatalf("CreateAnswer() error = %v", err)
+	}
+
+	t.Cleanup(func() { _ = c.DeleteRecord(ctx, name, "A") })
+
+	if err := c.CreateAnswer(ctx, name, "A", o, &answer.Answer{Target: "192.0.2.2", TTL: 60}); err != nil {
+		t.Fatalf("CreateAnswer() error = %v", err)
+	}
+
+	before, err := c.ListAnswers(ctx, name, "A")
+	if err != nil || len(before) != 2 {
+		t.Fatalf("ListAnswers() = %v, %v, want two answers", before, err)
+	}
+
+	// Only leased answers are renewed
+	renewed, err := c.RenewOwner(ctx, before[0].Owner.UUID)
+	if err != nil || renewed != 1 {
+		t.Fatalf("RenewOwner() = %d, %v, want one answer renewed", renewed, err)
+	}
+
+	after, err := c.ListAnswers(ctx, name, "A")
+	if err != nil || len(after) != 2 {
+		t.Fatalf("ListAnswers() = %v, %v, want two answers", after, err)
+	}
+
+	for i, a := range after {
+		switch {
+		case a.Lease == nil && a.ExpiresAt != nil:
+			t.Errorf("answer %s without a lease expires at %v", a.Target, a.ExpiresAt)
+		case a.Lease != nil && (a.ExpiresAt == nil || !a.ExpiresAt.After(*before[i].ExpiresAt)):
+			t.Errorf("answer %s expires at %v after renewing, want after %v", a.Target, a.ExpiresAt, before[i].ExpiresAt)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+//go:build integration
+
+package httpsrv
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	answer "go.hollow.sh/dnscontroller/pkg/api/v1/answers"
+	"go.hollow.sh/dnscontroller/pkg/api/v1/client"
+	owner "go.hollow.sh/dnscontroller/pkg/api/v1/owners"
+)
+
+func TestSyncOwnerAnswers(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t)
+
+	const name = "sync-test.example.com."
+
+	o := &owner.Owner{Name: "sync-test", Origin: "test", Service: "web"}
+	if err := c.CreateOwner(ctx, o); err != nil {
+		t.Fatalf("CreateOwner() error = %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = c.DeleteRecord(ctx, name, "A")
+		_ = c.DeleteOwner(ctx, o.UUID)
+	})
+
+	desired := func(ttl int64, targets ...string) []*answer.RecordAnswer {
+		answers := []*answer.RecordAnswer{}
+
+		for _, target := range targets {
+			answers = append(answers, &answer.RecordAnswer{Record: name, RecordType: "A", Answer: &answer.Answer{Target: target, TTL: ttl}})
+		}
+
+		return answers
+	}
+
+	tests := []struct {
+		name    string
+		desired []*answer.RecordAnswer
+		added   int
+		updated int
+		removed int
+	}{
+		{name: "add", desired: desired(300, "192.0.2.1", "192.0.2.2"), added: 2},
+		{name: "unchanged", desired: desired(300, "192.0.2.1", "192.0.2.2")},
+		{name: "replace and update", desired: desired(60, "192.0.2.2", "192.0.2.3"), added: 1, updated: 1, removed: 1},
+		{name: "remove all", desired: nil, removed: 2},
+	}
+
+	for _, tt := range tests {
+		diff, err := c.SyncOwnerAnswers(ctx, o.UUID, tt.desired)
+		if err != nil {
+			t.Fatalf("%s: SyncOwnerAnswers() error = %v", tt.name, err)
+		}
+
+		if len(diff.Added) != tt.added || len(diff.Updated) != tt.updated || len(diff.Removed) != tt.removed {
+			t.Errorf("%s: SyncOwnerAnswers() added %d, updated %d, removed %d, want %d, %d, %d", tt.name,
+				len(diff.Added), len(diff.Updated), len(diff.Removed), tt.added, tt.updated, tt.removed)
+		}
+	}
+}
+
+func TestSyncOwnerAnswersRenews(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t)
+
+	const name = "sync-renew-test.example.com."
+
+	o := &owner.Owner{Name: "sync-renew-test", Origin: "test", Service: "web"}
+	if err := c.CreateOwner(ctx, o); err != nil {
+		t.Fatalf("CreateOwner() error = %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = c.DeleteRecord(ctx, name, "A")
+		_ = c.DeleteOwner(ctx, o.UUID)
+	})
+
+	lease := int64(300)
+	desired := []*answer.RecordAnswer{{Record: name, RecordType: "A", Answer: &answer.Answer{Target: "192.0.2.1", Lease: &lease}}}
+
+	expiresAt := func() time.Time {
+		t.Helper()
+
+		answers, err := c.ListAnswers(ctx, name, "A")
+		if err != nil || len(answers) != 1 || answers[0].ExpiresAt == nil {
+			t.Fatalf("ListAnswers() = %v, %v, want one leased answer", answers, err)
+		}
+
+		return *answers[0].ExpiresAt
+	}
+
+	if _, err := c.SyncOwnerAnswers(ctx, o.UUID, desired); err != nil {
+		t.Fatalf("SyncOwnerAnswers() error = %v", err)
+	}
+
+	first := expiresAt()
+
+	diff, err := c.SyncOwnerAnswers(ctx, o.UUID, desired)
+	if err != nil {
+		t.Fatalf("SyncOwnerAnswers() error = %v", err)
+	}
+
+	if len(diff.Added)+len(diff.Updated)+len(diff.Removed) != 0 {
+		t.Errorf("SyncOwnerAnswers() = %+v, want no changes", diff)
+	}
+
+	if renewed := expiresAt(); !renewed.After(first) {
+		t.Errorf("expires_at = %s after the second sync, want after %s", renewed, first)
+	}
+
+	if _, err := c.SyncOwnerAnswers(ctx, uuid.New(), desired); !errors.Is(err, client.ErrorNotFound) {
+		t.Errorf("SyncOwnerAnswers() of an unknown owner error = %v, want %v", err, client.ErrorNotFound)
+	}
+}
@@ -0,0 +1,73 @@
+//go:build integration
+
+package httpsrv
+
+import (
+	"context"
+	"testing"
+
+	answer "go.hollow.sh/dnscontroller/pkg/api/v1/answers"
+	owner "go.hollow.sh/dnscontroller/pkg/api/v1/owners"
+	"go.hollow.sh/dnscontroller/pkg/api/v1/watch"
+)
+
+func TestWatch(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t)
+
+	const name = "www.watch-test.example.com."
+
+	_, start, err := c.Watch(ctx, &watch.Params{Zone: "watch-test.example.com", Timeout: 1})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	o := &owner.Owner{Name: "watch-test", Origin: "test", Service: "web"}
+	if err := c.CreateAnswer(ctx, name, "A", o, &answer.Answer{Target: "192.0.2.1"}); err != nil {
+		t.Fatalf("CreateAnswer() error = %v", err)
+	}
+
+	t.Cleanup(func() { _ = c.DeleteRecord(ctx, name, "A") })
+
+	events, version, err := c.Watch(ctx, &watch.Params{Version: start, Zone: "watch-test.example.com", Timeout: 5})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	names := map[string]bool{}
+	for _, e := range events {
+		names[e.Name()] = true
+
+		// The record and its first answer are created in one transaction
+		if e.Version != version {
+			t.Errorf("Watch() event %s at %s, want %s", e.Name(), e.Version, version)
+		}
+	}
+
+	if !names["record.create"] || !names["answer.create"] {
+		t.Fatalf("Watch() = %v, want record.create and answer.create", events)
+	}
+
+	if events, _, err := c.Watch(ctx, &watch.Params{Version: start, Zone: "other.example.com", Timeout: 1}); err != nil || len(events) != 0 {
+		t.Errorf("Watch() of another zone = %v, %v, want no events", events, err)
+	}
+
+	if err := c.DeleteRecord(ctx, name, "A"); err != nil {
+		t.Fatalf("DeleteRecord() error = %v", err)
+	}
+
+	events, _, err = c.Watch(ctx, &watch.Params{Version: version, Zone: "watch-test.example.com", Timeout: 5})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	names = map[string]bool{}
+	for _, e := range events {
+		names[e.Name()] = true
+	}
+
+	// The answer removed along with its record is a change of its own
+	if !names["record.delete"] || !names["answer.delete"] {
+		t.Fatalf("Watch() = %v, want record.delete and answer.delete", events)
+	}
+}
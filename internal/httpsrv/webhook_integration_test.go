@@ -0,0 +1,111 @@
+//go:build integration
+
+package httpsrv
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.hollow.sh/dnscontroller/internal/notifier"
+	answer "go.hollow.sh/dnscontroller/pkg/api/v1/answers"
+	owner "go.hollow.sh/dnscontroller/pkg/api/v1/owners"
+	"go.hollow.sh/dnscontroller/pkg/api/v1/watch"
+	webhook "go.hollow.sh/dnscontroller/pkg/api/v1/webhooks"
+)
+
+// receiver records the changes POSTed to it with a valid signature, and
+// answers with the status
+type receiver struct {
+	mu     sync.Mutex
+	status int
+	events []*watch.Event
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	e := &watch.Event{}
+	if webhook.Verify("s3cret", body, req.Header.Get(webhook.SignatureHeader)) && json.Unmarshal(body, e) == nil {
+		r.mu.Lock()
+		r.events = append(r.events, e)
+		r.mu.Unlock()
+	}
+
+	w.WriteHeader(r.status)
+}
+
+func TestWebhooks(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	c := newTestClientWithDB(t, db)
+
+	const name = "www.webhook-test.example.com."
+
+	tests := []struct {
+		name       string
+		status     int
+		wantStatus string
+	}{
+		{name: "delivered", status: http.StatusNoContent, wantStatus: webhook.StatusDelivered},
+		{name: "dead-lettered", status: http.StatusInternalServerError, wantStatus: webhook.StatusDead},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recv := &receiver{status: tt.status}
+
+			srv := httptest.NewServer(recv)
+			defer srv.Close()
+
+			w := &webhook.Webhook{URL: srv.URL, Secret: "s3cret", Events: []string{"answer.create"}, Zone: "webhook-test.example.com"}
+			if err := c.CreateWebhook(ctx, w); err != nil {
+				t.Fatalf("CreateWebhook() error = %v", err)
+			}
+
+			defer func() { _ = c.DeleteWebhook(ctx, w.UUID) }()
+
+			o := &owner.Owner{Name: "webhook-test", Origin: "test", Service: "web"}
+			if err := c.CreateAnswer(ctx, name, "A", o, &answer.Answer{Target: "192.0.2.1"}); err != nil {
+				t.Fatalf("CreateAnswer() error = %v", err)
+			}
+
+			defer func() { _ = c.DeleteRecord(ctx, name, "A") }()
+
+			n := &notifier.Notifier{
+				Logger:      zap.NewNop().Sugar(),
+				DB:          db,
+				Timeout:     time.Second,
+				MaxAttempts: 1,
+			}
+
+			// The change is only read once its version is in the past
+			time.Sleep(time.Millisecond)
+
+			if err := n.Notify(ctx); err != nil {
+				t.Fatalf("Notify() error = %v", err)
+			}
+
+			if len(recv.events) != 1 || recv.events[0].Name() != "answer.create" || recv.events[0].Record != name {
+				t.Fatalf("received %v, want the answer.create of %s", recv.events, name)
+			}
+
+			deliveries, err := c.WebhookDeliveries(ctx, w.UUID, nil)
+			if err != nil || len(deliveries) != 1 || deliveries[0].Status != tt.wantStatus {
+				t.Fatalf("WebhookDeliveries() = %v, %v, want one %s delivery", deliveries, err, tt.wantStatus)
+			}
+
+			letters, err := c.WebhookDeadLetters(ctx, w.UUID, nil)
+			if wantLetters := tt.wantStatus == webhook.StatusDead; err != nil || (len(letters) == 1) != wantLetters {
+				t.Errorf("WebhookDeadLetters() = %v, %v, want dead letters %v", letters, err, wantLetters)
+			}
+		})
+	}
+}
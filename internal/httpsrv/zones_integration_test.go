@@ -0,0 +1,226 @@
+//go:build integration
+
+package httpsrv
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"go.uber.org/zap"
+
+	"go.hollow.sh/dnscontroller/internal/collector"
+	"go.hollow.sh/dnscontroller/internal/models"
+	answer "go.hollow.sh/dnscontroller/pkg/api/v1/answers"
+	"go.hollow.sh/dnscontroller/pkg/api/v1/client"
+	owner "go.hollow.sh/dnscontroller/pkg/api/v1/owners"
+	zone "go.hollow.sh/dnscontroller/pkg/api/v1/zones"
+)
+
+func TestZoneFindOrCreate(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	c := newTestClientWithDB(t, db)
+
+	const (
+		name = "www.zone-find-test.test."
+		apex = "zone-find-test.test."
+	)
+
+	o := &owner.Owner{Name: "zone-find-test", Origin: "test", Service: "web"}
+	if err := c.CreateAnswer(ctx, name, "A", o, &answer.Answer{Target: "192.0.2.1"}); err != nil {
+		t.Fatalf("CreateAnswer() error = %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = c.DeleteRecord(ctx, name, "A")
+		_ = (&zone.Zone{Name: apex}).Delete(ctx, db)
+	})
+
+	// The zone created for the record stays garbage collected
+	z := &zone.Zone{Name: apex}
+	if err := z.FindOrCreate(ctx, db); err != nil {
+		t.Fatalf("FindOrCreate() error = %v", err)
+	}
+
+	if !z.AutoCreated {
+		t.Errorf("FindOrCreate() of the zone created for %s adopted it", name)
+	}
+
+	created := &zone.Zone{Name: "zone-find-test-created.test."}
+	if err := created.FindOrCreate(ctx, db); err != nil {
+		t.Fatalf("FindOrCreate() error = %v", err)
+	}
+
+	t.Cleanup(func() { _ = created.Delete(ctx, db) })
+
+	if created.AutoCreated || created.UUID == uuid.Nil {
+		t.Errorf("FindOrCreate() of a new zone = %+v, want it stored and not auto created", created)
+	}
+}
+
+// zoneOf returns the name of the zone the record is linked to
+func zoneOf(t *testing.T, db *sqlx.DB, name string) string {
+	t.Helper()
+
+	dbZone, err := models.Zones(
+		qm.InnerJoin("records ON records.zone_id = zones.id"),
+		qm.Where("records.record=?", name),
+	).One(context.Background(), db)
+	if err != nil {
+		t.Fatalf("finding the zone of %s: %v", name, err)
+	}
+
+	return dbZone.Name
+}
+
+func TestCollector(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	c := newTestClientWithDB(t, db)
+
+	const (
+		name    = "www.collector-test.test."
+		apex    = "collector-test.test."
+		kept    = "www.collector-kept.test."
+		created = "collector-kept.test."
+	)
+
+	o := &owner.Owner{Name: "collector-test", Origin: "test", Service: "web"}
+	a := &answer.Answer{Target: "192.0.2.1", TTL: 60}
+
+	// Zones created through the API are never collected
+	if err := (&zone.Zone{Name: created}).Create(ctx, db); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = c.DeleteRecord(ctx, name, "A")
+		_ = c.DeleteRecord(ctx, kept, "A")
+		_ = (&zone.Zone{Name: apex}).Delete(ctx, db)
+		_ = (&zone.Zone{Name: created}).Delete(ctx, db)
+	})
+
+	for _, n := range []string{name, kept} {
+		if err := c.CreateAnswer(ctx, n, "A", o, a); err != nil {
+			t.Fatalf("CreateAnswer() error = %v", err)
+		}
+
+		if err := c.DeleteAnswer(ctx, n, "A", o, a); err != nil {
+			t.Fatalf("DeleteAnswer() error = %v", err)
+		}
+	}
+
+	find := func(name string) *zone.Zone {
+		t.Helper()
+
+		z := &zone.Zone{Name: name}
+		if err := z.Find(ctx, db); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+
+			t.Fatalf("finding zone %s: %v", name, err)
+		}
+
+		return z
+	}
+
+	waiting := &collector.Collector{Logger: zap.NewNop().Sugar(), DB: db, GracePeriod: time.Hour}
+	expired := &collector.Collector{Logger: zap.NewNop().Sugar(), DB: db}
+
+	if err := waiting.Collect(ctx); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	if z := find(apex); z == nil || z.EmptySince == nil {
+		t.Fatalf("zone %s within its grace period = %+v, want it kept and marked empty", apex, z)
+	}
+
+	// An answer added within the grace period keeps the zone
+	if err := c.CreateAnswer(ctx, name, "A", o, a); err != nil {
+		t.Fatalf("CreateAnswer() error = %v", err)
+	}
+
+	if err := expired.Collect(ctx); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	if z := find(apex); z == nil || z.EmptySince != nil {
+		t.Fatalf("zone %s with an answer = %+v, want it kept and not marked empty", apex, z)
+	}
+
+	if err := c.DeleteAnswer(ctx, name, "A", o, a); err != nil {
+		t.Fatalf("DeleteAnswer() error = %v", err)
+	}
+
+	// The first run marks the zone empty, the next removes it
+	for i := 0; i < 2; i++ {
+		if err := expired.Collect(ctx); err != nil {
+			t.Fatalf("Collect() error = %v", err)
+		}
+	}
+
+	if z := find(apex); z != nil {
+		t.Errorf("zone %s after its grace period = %+v, want it removed", apex, z)
+	}
+
+	if _, err := c.GetRecord(ctx, name, "A"); !errors.Is(err, client.ErrorNotFound) {
+		t.Errorf("GetRecord() of a record of a removed zone error = %v, want %v", err, client.ErrorNotFound)
+	}
+
+	if z := find(created); z == nil || z.EmptySince != nil {
+		t.Errorf("zone %s created through the API = %+v, want it kept and not marked empty", created, z)
+	}
+}
+
+func TestRelinkZone(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	c := newTestClientWithDB(t, db)
+
+	const (
+		name   = "a.b.relink-test.test."
+		auto   = "b.relink-test.test."
+		nested = "a.b.relink-test.test."
+	)
+
+	o := &owner.Owner{Name: "relink-test", Origin: "test", Service: "web"}
+	if err := c.CreateAnswer(ctx, name, "A", o, &answer.Answer{Target: "192.0.2.1", TTL: 60}); err != nil {
+		t.Fatalf("CreateAnswer() error = %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = c.DeleteRecord(ctx, name, "A")
+		_ = (&zone.Zone{Name: nested}).Delete(ctx, db)
+		_ = (&zone.Zone{Name: auto}).Delete(ctx, db)
+	})
+
+	if got := zoneOf(t, db, name); got != auto {
+		t.Fatalf("zone of %s = %s, want the zone created for it %s", name, got, auto)
+	}
+
+	// The record moves to a longer zone created later, and back when that
+	// zone is deleted
+	z := &zone.Zone{Name: nested}
+	if err := z.Create(ctx, db); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if got := zoneOf(t, db, name); got != nested {
+		t.Errorf("zone of %s = %s, want %s", name, got, nested)
+	}
+
+	if err := z.Delete(ctx, db); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	if got := zoneOf(t, db, name); got != auto {
+		t.Errorf("zone of %s after deleting %s = %s, want %s", name, nested, got, auto)
+	}
+}
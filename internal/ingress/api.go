@@ -0,0 +1,101 @@
+package ingress
+
+import (
+	"context"
+	"errors"
+
+	answer "go.hollow.sh/dnscontroller/pkg/api/v1/answers"
+	"go.hollow.sh/dnscontroller/pkg/api/v1/client"
+	owner "go.hollow.sh/dnscontroller/pkg/api/v1/owners"
+	record "go.hollow.sh/dnscontroller/pkg/api/v1/records"
+)
+
+// srvType is the record type the controller registers
+const srvType = "SRV"
+
+// API is the part of the dnscontroller API the controller uses
+type API interface {
+	// Upsert creates or updates the answer of an endpoint
+	Upsert(ctx context.Context, o *owner.Owner, e Endpoint) error
+	// Delete removes the answer of an endpoint, an answer that is already
+	// gone isn't an error
+	Delete(ctx context.Context, o *owner.Owner, e Endpoint) error
+	// Endpoints returns the SRV answers of the owners with the name and
+	// origin, along with the service of their owner
+	Endpoints(ctx context.Context, name, origin string) ([]Endpoint, error)
+}
+
+// Client calls the dnscontroller API with the API client
+type Client struct {
+	API *client.Client
+}
+
+// Upsert creates or updates the answer of an endpoint
+func (c *Client) Upsert(ctx context.Context, o *owner.Owner, e Endpoint) error {
+	return c.API.CreateAnswer(ctx, e.Record, srvType, o, endpointAnswer(e))
+}
+
+// Delete removes the answer of an endpoint
+func (c *Client) Delete(ctx context.Context, o *owner.Owner, e Endpoint) error {
+	err := c.API.DeleteAnswer(ctx, e.Record, srvType, o, endpointAnswer(e))
+	if errors.Is(err, client.ErrorNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+// Endpoints lists the SRV records with answers from the owners, then keeps
+// their answers
+func (c *Client) Endpoints(ctx context.Context, name, origin string) ([]Endpoint, error) {
+	endpoints := []Endpoint{}
+
+	records, err := c.API.AllRecords(ctx, &record.ListParams{Type: srvType, Owner: name, Origin: origin})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range records {
+		answers, err := c.API.ListAnswers(ctx, r.Name, srvType)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, a := range answers {
+			if a.Owner == nil || a.Owner.Name != name || a.Owner.Origin != origin {
+				continue
+			}
+
+			endpoints = append(endpoints, Endpoint{
+				Service:  a.Owner.Service,
+				Record:   r.Name,
+				Target:   a.Target,
+				Port:     value(a.Port),
+				Priority: value(a.Priority),
+				Weight:   value(a.Weight),
+				Protocol: a.Protocol,
+			})
+		}
+	}
+
+	return endpoints, nil
+}
+
+func endpointAnswer(e Endpoint) *answer.Answer {
+	return &answer.Answer{
+		Target:   e.Target,
+		TTL:      answer.DefaultTTL,
+		Port:     &e.Port,
+		Priority: &e.Priority,
+		Weight:   &e.Weight,
+		Protocol: e.Protocol,
+	}
+}
+
+func value(v *int64) int64 {
+	if v == nil {
+		return 0
+	}
+
+	return *v
+}
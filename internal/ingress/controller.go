@@ -0,0 +1,325 @@
+package ingress
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	networkinglisters "k8s.io/client-go/listers/networking/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// DefaultWorkers is the number of Ingresses synced at once when none is set
+const DefaultWorkers = 2
+
+// orphans is the queue key of the registered answers no Ingress wants, it
+// can't be the key of an Ingress
+const orphans = "/orphans"
+
+// Controller watches Ingresses and keeps their answers in dnscontroller. The
+// answers are registered under an owner per service, named after the
+// cluster. An Ingress is told apart by the answers of its hosts, so a removed
+// Ingress only takes away the answers no other Ingress has.
+type Controller struct {
+	Logger *zap.SugaredLogger
+	Client kubernetes.Interface
+	API    API
+	// Cluster is the owner name of every answer the controller registers
+	Cluster string
+	// Domain is the SRV record domain for Ingresses without the domain
+	// annotation
+	Domain string
+	// Namespace limits the watch to one namespace, all namespaces are watched
+	// when it is empty
+	Namespace string
+	Resync    time.Duration
+	Workers   int
+
+	lister networkinglisters.IngressLister
+	queue  workqueue.TypedRateLimitingInterface[string]
+	mu     sync.Mutex
+	// registered has the answers in dnscontroller by the Ingress they are
+	// for, answers of hosts that several Ingresses have are in each of them
+	registered map[string][]Endpoint
+}
+
+// Run syncs Ingresses until the context is done. Once the cache has synced
+// the answers registered by the cluster are handed to the Ingresses with
+// their hosts, and the ones no Ingress has are removed.
+func (c *Controller) Run(ctx context.Context) error {
+	registered, err := c.API.Endpoints(ctx, c.Cluster, OwnerOrigin)
+	if err != nil {
+		return err
+	}
+
+	c.registered = map[string][]Endpoint{}
+	c.queue = workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[string]())
+
+	defer c.queue.ShutDown()
+
+	factory := informers.NewSharedInformerFactoryWithOptions(c.Client, c.Resync, informers.WithNamespace(c.Namespace))
+	informer := factory.Networking().V1().Ingresses()
+	c.lister = informer.Lister()
+
+	if _, err := informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueue,
+		UpdateFunc: func(_, obj interface{}) { c.enqueue(obj) },
+		DeleteFunc: c.enqueue,
+	}); err != nil {
+		return err
+	}
+
+	factory.Start(ctx.Done())
+	defer factory.Shutdown()
+
+	for typ, ok := range factory.WaitForCacheSync(ctx.Done()) {
+		if !ok {
+			c.Logger.Errorw("failed syncing cache", "type", typ)
+			return ctx.Err()
+		}
+	}
+
+	if err := c.adopt(registered); err != nil {
+		return err
+	}
+
+	workers := c.Workers
+	if workers < 1 {
+		workers = DefaultWorkers
+	}
+
+	for i := 0; i < workers; i++ {
+		go wait.UntilWithContext(ctx, c.work, time.Second)
+	}
+
+	<-ctx.Done()
+
+	return ctx.Err()
+}
+
+// adopt hands the registered answers to the Ingresses with their hosts and
+// queues them. The answers no Ingress has are queued as orphans, unless an
+// Ingress can't be parsed, since they may be its answers.
+func (c *Controller) adopt(registered []Endpoint) error {
+	ingresses, err := c.lister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+
+	byHost := map[string][]string{}
+	parsed := true
+
+	for _, ing := range ingresses {
+		key, err := cache.MetaNamespaceKeyFunc(ing)
+		if err != nil {
+			return err
+		}
+
+		wanted, err := c.endpoints(ing)
+		if err != nil {
+			parsed = false
+			continue
+		}
+
+		for _, e := range wanted {
+			byHost[e.host()] = append(byHost[e.host()], key)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, e := range registered {
+		keys := byHost[e.host()]
+		if len(keys) == 0 {
+			if !parsed {
+				c.Logger.Warnw("keeping answer without an ingress while ingresses can't be parsed", "record", e.Record, "target", e.Target)
+				continue
+			}
+
+			keys = []string{orphans}
+		}
+
+		for _, key := range keys {
+			c.registered[key] = append(c.registered[key], e)
+		}
+	}
+
+	for key := range c.registered {
+		c.queue.Add(key)
+	}
+
+	return nil
+}
+
+func (c *Controller) enqueue(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		c.Logger.Errorw("failed getting ingress key", "error", err)
+		return
+	}
+
+	c.queue.Add(key)
+}
+
+// work syncs queued Ingresses until the queue is shut down, a failed sync is
+// retried with a backoff
+func (c *Controller) work(ctx context.Context) {
+	for {
+		key, shutdown := c.queue.Get()
+		if shutdown {
+			return
+		}
+
+		if err := c.sync(ctx, key); err != nil {
+			c.Logger.Errorw("failed syncing ingress", "ingress", key, "error", err)
+			c.queue.AddRateLimited(key)
+		} else {
+			c.queue.Forget(key)
+		}
+
+		c.queue.Done(key)
+	}
+}
+
+// sync registers the endpoints of an Ingress and removes the ones it no longer
+// has, a deleted Ingress has none. An answer another Ingress has is kept.
+func (c *Controller) sync(ctx context.Context, key string) error {
+	wanted, err := c.wanted(key)
+	if errors.Is(err, errorSkipped) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	previous := c.registered[key]
+	c.mu.Unlock()
+
+	keep := map[string]bool{}
+	for _, e := range wanted {
+		keep[e.key()] = true
+	}
+
+	stale := []Endpoint{}
+
+	for _, e := range previous {
+		if !keep[e.key()] {
+			stale = append(stale, e)
+		}
+	}
+
+	// Both are tracked until the sync is done, so an Ingress removed after a
+	// failed sync still has all of its answers removed
+	c.setRegistered(key, append(append([]Endpoint{}, wanted...), stale...))
+
+	// The wanted answers go first, so a host moving to another owner is
+	// always answered
+	for _, e := range wanted {
+		if err := c.API.Upsert(ctx, Owner(c.Cluster, e.Service), e); err != nil {
+			return err
+		}
+	}
+
+	for _, e := range stale {
+		if c.registeredElsewhere(key, e) {
+			continue
+		}
+
+		if err := c.API.Delete(ctx, Owner(c.Cluster, e.Service), e); err != nil {
+			return err
+		}
+
+		c.Logger.Infow("removed endpoint", "ingress", key, "record", e.Record, "target", e.Target)
+	}
+
+	c.setRegistered(key, wanted)
+
+	return nil
+}
+
+// errorSkipped is when an Ingress can't be parsed, retrying won't fix its
+// annotations so its current answers are kept until they are
+var errorSkipped = errors.New("ingress skipped")
+
+// wanted returns the endpoints of the Ingress with the key, the orphans and
+// a deleted Ingress have none
+func (c *Controller) wanted(key string) ([]Endpoint, error) {
+	if key == orphans {
+		return nil, nil
+	}
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	ing, err := c.lister.Ingresses(namespace).Get(name)
+
+	switch {
+	case apierrors.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	wanted, err := c.endpoints(ing)
+	if err != nil {
+		c.Logger.Warnw("skipping ingress", "ingress", key, "error", err)
+		return nil, errorSkipped
+	}
+
+	return wanted, nil
+}
+
+// registeredElsewhere reports whether another Ingress has the answer
+func (c *Controller) registeredElsewhere(key string, e Endpoint) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k, endpoints := range c.registered {
+		if k == key {
+			continue
+		}
+
+		for _, other := range endpoints {
+			if other.key() == e.key() {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (c *Controller) endpoints(ing *networkingv1.Ingress) ([]Endpoint, error) {
+	// An Ingress being deleted is gone as far as DNS is concerned
+	if ing.DeletionTimestamp != nil {
+		return nil, nil
+	}
+
+	return Endpoints(ing, c.Domain)
+}
+
+func (c *Controller) setRegistered(key string, endpoints []Endpoint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(endpoints) == 0 {
+		delete(c.registered, key)
+		return
+	}
+
+	c.registered[key] = endpoints
+}
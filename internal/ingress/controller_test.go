@@ -0,0 +1,261 @@
+package ingress
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	owner "go.hollow.sh/dnscontroller/pkg/api/v1/owners"
+)
+
+// fakeAPI keeps the answers in memory by owner service
+type fakeAPI struct {
+	mu      sync.Mutex
+	answers map[string]map[string]Endpoint
+}
+
+func newFakeAPI() *fakeAPI {
+	return &fakeAPI{answers: map[string]map[string]Endpoint{}}
+}
+
+func (f *fakeAPI) Upsert(ctx context.Context, o *owner.Owner, e Endpoint) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.answers[o.Service] == nil {
+		f.answers[o.Service] = map[string]Endpoint{}
+	}
+
+	f.answers[o.Service][e.host()] = e
+
+	return nil
+}
+
+func (f *fakeAPI) Delete(ctx context.Context, o *owner.Owner, e Endpoint) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.answers[o.Service], e.host())
+
+	if len(f.answers[o.Service]) == 0 {
+		delete(f.answers, o.Service)
+	}
+
+	return nil
+}
+
+func (f *fakeAPI) Endpoints(ctx context.Context, name, origin string) ([]Endpoint, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	endpoints := []Endpoint{}
+
+	for service, answers := range f.answers {
+		for _, e := range answers {
+			e.Service = service
+			endpoints = append(endpoints, e)
+		}
+	}
+
+	return endpoints, nil
+}
+
+// targets returns the sorted targets of an owner service
+func (f *fakeAPI) targets(service string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	targets := []string{}
+	for _, e := range f.answers[service] {
+		targets = append(targets, e.Target)
+	}
+
+	sort.Strings(targets)
+
+	return targets
+}
+
+func newIngress(name string, annotations map[string]string, tlsHosts []string, hosts ...string) *networkingv1.Ingress {
+	ing := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Annotations: annotations},
+	}
+
+	for _, h := range hosts {
+		ing.Spec.Rules = append(ing.Spec.Rules, networkingv1.IngressRule{Host: h})
+	}
+
+	if len(tlsHosts) > 0 {
+		ing.Spec.TLS = []networkingv1.IngressTLS{{Hosts: tlsHosts}}
+	}
+
+	return ing
+}
+
+func TestEndpoints(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		domain      string
+		tlsHosts    []string
+		hosts       []string
+		want        []Endpoint
+		wantErr     error
+	}{
+		{
+			name:  "not annotated",
+			hosts: []string{"web.example.com"},
+		},
+		{
+			name:        "defaults",
+			annotations: map[string]string{AnnotationService: "web"},
+			domain:      "example.com",
+			tlsHosts:    []string{"secure.cluster-a.example.com"},
+			hosts:       []string{"plain.cluster-a.example.com", "Secure.cluster-a.example.com", "*.cluster-a.example.com", ""},
+			want: []Endpoint{
+				{Service: "web", Record: "_web._tcp.example.com.", Target: "plain.cluster-a.example.com.", Port: 80, Protocol: "tcp"},
+				{Service: "web", Record: "_web._tcp.example.com.", Target: "secure.cluster-a.example.com.", Port: 443, Protocol: "tls"},
+			},
+		},
+		{
+			name: "annotations",
+			annotations: map[string]string{
+				AnnotationService:  "ldap",
+				AnnotationProtocol: "udp",
+				AnnotationDomain:   "example.net",
+				AnnotationPort:     "389",
+				AnnotationPriority: "10",
+				AnnotationWeight:   "5",
+			},
+			domain: "example.com",
+			hosts:  []string{"ldap.cluster-a.example.com", "ldap.cluster-a.example.com"},
+			want: []Endpoint{
+				{Service: "ldap", Record: "_ldap._udp.example.net.", Target: "ldap.cluster-a.example.com.", Port: 389, Priority: 10, Weight: 5, Protocol: "tcp"},
+			},
+		},
+		{
+			name:        "no domain",
+			annotations: map[string]string{AnnotationService: "web"},
+			hosts:       []string{"web.example.com"},
+			wantErr:     ErrorNoDomain,
+		},
+		{
+			name:        "invalid port",
+			annotations: map[string]string{AnnotationService: "web", AnnotationPort: "70000"},
+			domain:      "example.com",
+			hosts:       []string{"web.example.com"},
+			wantErr:     ErrorInvalidAnnotation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Endpoints(newIngress("web", tt.annotations, tt.tlsHosts, tt.hosts...), tt.domain)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Endpoints() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("Endpoints() = %v, want %v", got, tt.want)
+			}
+
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Endpoints()[%d] = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestController(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	annotations := map[string]string{AnnotationService: "web"}
+	ing := newIngress("web", annotations, nil, "web.cluster-a.example.com", "www.cluster-a.example.com")
+	// Shares a host with the first Ingress
+	canary := newIngress("canary", annotations, nil, "web.cluster-a.example.com")
+	client := fake.NewClientset(ing, canary)
+
+	api := newFakeAPI()
+	// Registered before the controller started, its Ingress is gone
+	_ = api.Upsert(ctx, Owner("cluster-a", "default/gone"), Endpoint{Record: "_web._tcp.example.com.", Target: "gone.cluster-a.example.com.", Port: 80})
+	// Registered under the owner of the Ingress, like earlier versions did
+	_ = api.Upsert(ctx, Owner("cluster-a", "default/web"), Endpoint{Record: "_web._tcp.example.com.", Target: "www.cluster-a.example.com.", Port: 80})
+
+	c := &Controller{
+		Logger:  zap.NewNop().Sugar(),
+		Client:  client,
+		API:     api,
+		Cluster: "cluster-a",
+		Domain:  "example.com",
+	}
+
+	done := make(chan error)
+
+	go func() { done <- c.Run(ctx) }()
+
+	eventually(t, "ingresses registered", func() bool {
+		got := api.targets("web")
+		return len(got) == 2 && got[0] == "web.cluster-a.example.com." && got[1] == "www.cluster-a.example.com."
+	})
+
+	eventually(t, "stale ingress removed", func() bool { return len(api.targets("default/gone")) == 0 })
+	eventually(t, "answer moved off the ingress owner", func() bool { return len(api.targets("default/web")) == 0 })
+
+	ing = newIngress("web", annotations, nil, "web.cluster-a.example.com")
+	if _, err := client.NetworkingV1().Ingresses("default").Update(ctx, ing, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	eventually(t, "removed host withdrawn", func() bool {
+		got := api.targets("web")
+		return len(got) == 1 && got[0] == "web.cluster-a.example.com."
+	})
+
+	if err := client.NetworkingV1().Ingresses("default").Delete(ctx, "web", metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The canary still has the host, give the controller the time to sync
+	// the deletion before checking it was kept
+	time.Sleep(100 * time.Millisecond)
+
+	if got := api.targets("web"); len(got) != 1 {
+		t.Fatalf("answers after deleting an ingress sharing a host = %v, want the shared host kept", got)
+	}
+
+	if err := client.NetworkingV1().Ingresses("default").Delete(ctx, "canary", metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	eventually(t, "deleted ingresses withdrawn", func() bool { return len(api.targets("web")) == 0 })
+
+	cancel()
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func eventually(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
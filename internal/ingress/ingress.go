@@ -0,0 +1,179 @@
+// Package ingress has a kubernetes controller that registers the hosts of
+// networking/v1 Ingress objects as answers of a SRV record, so the endpoints
+// of every cluster serving a service are listed under one name
+package ingress
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+
+	owner "go.hollow.sh/dnscontroller/pkg/api/v1/owners"
+	record "go.hollow.sh/dnscontroller/pkg/api/v1/records"
+)
+
+const (
+	// annotationPrefix is shared by the annotations the controller reads
+	annotationPrefix = "dnscontroller.hollow.sh/"
+
+	// AnnotationService is the SRV service label, an Ingress without it is
+	// ignored
+	AnnotationService = annotationPrefix + "service"
+	// AnnotationProtocol is the SRV protocol label, defaults to tcp
+	AnnotationProtocol = annotationPrefix + "protocol"
+	// AnnotationDomain is the domain the SRV record is in, defaults to the
+	// controller's domain
+	AnnotationDomain = annotationPrefix + "domain"
+	// AnnotationPort is the port of the answers, defaults to 443 for hosts
+	// with TLS and 80 otherwise
+	AnnotationPort = annotationPrefix + "port"
+	// AnnotationPriority is the priority of the answers, defaults to 0
+	AnnotationPriority = annotationPrefix + "priority"
+	// AnnotationWeight is the weight of the answers, defaults to 0
+	AnnotationWeight = annotationPrefix + "weight"
+
+	// OwnerOrigin is the origin of the owners the controller registers under
+	OwnerOrigin = "kubernetes"
+
+	defaultProtocol = "tcp"
+	httpPort        = 80
+	httpsPort       = 443
+	maxDetailValue  = 65535
+)
+
+var (
+	// ErrorNoDomain when neither the Ingress nor the controller have a domain
+	ErrorNoDomain = errors.New("no domain for the srv record")
+	// ErrorInvalidAnnotation when an annotation can't be parsed
+	ErrorInvalidAnnotation = errors.New("invalid annotation")
+)
+
+// Endpoint is a SRV answer registered for an Ingress host
+type Endpoint struct {
+	// Service is the service of the owner the answer is registered under
+	Service  string
+	Record   string
+	Target   string
+	Port     int64
+	Priority int64
+	Weight   int64
+	// Protocol is how the endpoint is health checked
+	Protocol string
+}
+
+// key identifies the answer of an endpoint, a changed port or weight is an
+// update of the same answer
+func (e Endpoint) key() string {
+	return e.Service + "/" + e.Record + "/" + e.Target
+}
+
+// host identifies the endpoint whatever owner it is registered under
+func (e Endpoint) host() string {
+	return e.Record + "/" + e.Target
+}
+
+// Owner returns the owner the answers of a service are registered under by
+// the controller of a cluster. Ingresses of the same service share it, and
+// are told apart by their answers.
+func Owner(cluster, service string) *owner.Owner {
+	return &owner.Owner{Name: cluster, Origin: OwnerOrigin, Service: service}
+}
+
+// Endpoints returns the answers wanted for an Ingress, one for each of its
+// hosts. An Ingress without the service annotation has none.
+func Endpoints(ing *networkingv1.Ingress, domain string) ([]Endpoint, error) {
+	service := ing.Annotations[AnnotationService]
+	if service == "" {
+		return nil, nil
+	}
+
+	protocol := ing.Annotations[AnnotationProtocol]
+	if protocol == "" {
+		protocol = defaultProtocol
+	}
+
+	if d := ing.Annotations[AnnotationDomain]; d != "" {
+		domain = d
+	}
+
+	if domain == "" {
+		return nil, ErrorNoDomain
+	}
+
+	name, err := record.CanonicalName(fmt.Sprintf("_%s._%s.%s", service, protocol, domain))
+	if err != nil {
+		return nil, err
+	}
+
+	priority, err := detail(ing, AnnotationPriority, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	weight, err := detail(ing, AnnotationWeight, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsHosts := map[string]bool{}
+
+	for _, t := range ing.Spec.TLS {
+		for _, h := range t.Hosts {
+			tlsHosts[strings.ToLower(h)] = true
+		}
+	}
+
+	endpoints := []Endpoint{}
+	seen := map[string]bool{}
+
+	for _, rule := range ing.Spec.Rules {
+		host := strings.ToLower(rule.Host)
+
+		// A SRV target has to be a single host
+		if host == "" || strings.HasPrefix(host, "*") || seen[host] {
+			continue
+		}
+
+		seen[host] = true
+
+		target, err := record.CanonicalName(host)
+		if err != nil {
+			return nil, err
+		}
+
+		e := Endpoint{Service: service, Record: name, Target: target, Priority: priority, Weight: weight, Protocol: "tcp"}
+
+		defaultPort := int64(httpPort)
+		if tlsHosts[host] {
+			defaultPort = httpsPort
+			e.Protocol = "tls"
+		}
+
+		e.Port, err = detail(ing, AnnotationPort, defaultPort)
+		if err != nil {
+			return nil, err
+		}
+
+		endpoints = append(endpoints, e)
+	}
+
+	return endpoints, nil
+}
+
+// detail parses a numeric annotation
+func detail(ing *networkingv1.Ingress, annotation string, def int64) (int64, error) {
+	v, ok := ing.Annotations[annotation]
+	if !ok {
+		return def, nil
+	}
+
+	i, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || i < 0 || i > maxDetailValue {
+		return 0, fmt.Errorf("%w: %s=%q", ErrorInvalidAnnotation, annotation, v)
+	}
+
+	return i, nil
+}
@@ -32,6 +32,8 @@ type AnswerDetail struct {
 	Weight    null.Int64  `boil:"weight" json:"weight,omitempty" toml:"weight" yaml:"weight,omitempty"`
 	CreatedAt time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
 	UpdatedAt time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	Flags     null.Int64  `boil:"flags" json:"flags,omitempty" toml:"flags" yaml:"flags,omitempty"`
+	Tag       null.String `boil:"tag" json:"tag,omitempty" toml:"tag" yaml:"tag,omitempty"`
 
 	R *answerDetailR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L answerDetailL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -46,6 +48,8 @@ var AnswerDetailColumns = struct {
 	Weight    string
 	CreatedAt string
 	UpdatedAt string
+	Flags     string
+	Tag       string
 }{
 	ID:        "id",
 	AnswerID:  "answer_id",
@@ -55,6 +59,8 @@ var AnswerDetailColumns = struct {
 	Weight:    "weight",
 	CreatedAt: "created_at",
 	UpdatedAt: "updated_at",
+	Flags:     "flags",
+	Tag:       "tag",
 }
 
 var AnswerDetailTableColumns = struct {
@@ -66,6 +72,8 @@ var AnswerDetailTableColumns = struct {
 	Weight    string
 	CreatedAt string
 	UpdatedAt string
+	Flags     string
+	Tag       string
 }{
 	ID:        "answer_details.id",
 	AnswerID:  "answer_details.answer_id",
@@ -75,6 +83,8 @@ var AnswerDetailTableColumns = struct {
 	Weight:    "answer_details.weight",
 	CreatedAt: "answer_details.created_at",
 	UpdatedAt: "answer_details.updated_at",
+	Flags:     "answer_details.flags",
+	Tag:       "answer_details.tag",
 }
 
 // Generated where
@@ -208,6 +218,8 @@ var AnswerDetailWhere = struct {
 	Weight    whereHelpernull_Int64
 	CreatedAt whereHelpertime_Time
 	UpdatedAt whereHelpertime_Time
+	Flags     whereHelpernull_Int64
+	Tag       whereHelpernull_String
 }{
 	ID:        whereHelperstring{field: "\"answer_details\".\"id\""},
 	AnswerID:  whereHelperstring{field: "\"answer_details\".\"answer_id\""},
@@ -217,6 +229,8 @@ var AnswerDetailWhere = struct {
 	Weight:    whereHelpernull_Int64{field: "\"answer_details\".\"weight\""},
 	CreatedAt: whereHelpertime_Time{field: "\"answer_details\".\"created_at\""},
 	UpdatedAt: whereHelpertime_Time{field: "\"answer_details\".\"updated_at\""},
+	Flags:     whereHelpernull_Int64{field: "\"answer_details\".\"flags\""},
+	Tag:       whereHelpernull_String{field: "\"answer_details\".\"tag\""},
 }
 
 // AnswerDetailRels is where relationship names are stored.
@@ -247,9 +261,9 @@ func (r *answerDetailR) GetAnswer() *Answer {
 type answerDetailL struct{}
 
 var (
-	answerDetailAllColumns            = []string{"id", "answer_id", "port", "priority", "protocol", "weight", "created_at", "updated_at"}
+	answerDetailAllColumns            = []string{"id", "answer_id", "port", "priority", "protocol", "weight", "created_at", "updated_at", "flags", "tag"}
 	answerDetailColumnsWithoutDefault = []string{"answer_id", "created_at", "updated_at"}
-	answerDetailColumnsWithDefault    = []string{"id", "port", "priority", "protocol", "weight"}
+	answerDetailColumnsWithDefault    = []string{"id", "port", "priority", "protocol", "weight", "flags", "tag"}
 	answerDetailPrimaryKeyColumns     = []string{"id"}
 	answerDetailGeneratedColumns      = []string{}
 )
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -23,62 +24,97 @@ import (
 
 // Answer is an object representing the database table.
 type Answer struct {
-	ID         string    `boil:"id" json:"id" toml:"id" yaml:"id"`
-	Target     string    `boil:"target" json:"target" toml:"target" yaml:"target"`
-	Type       string    `boil:"type" json:"type" toml:"type" yaml:"type"`
-	TTL        int64     `boil:"ttl" json:"ttl" toml:"ttl" yaml:"ttl"`
-	HasDetails bool      `boil:"has_details" json:"has_details" toml:"has_details" yaml:"has_details"`
-	OwnerID    string    `boil:"owner_id" json:"owner_id" toml:"owner_id" yaml:"owner_id"`
-	RecordID   string    `boil:"record_id" json:"record_id" toml:"record_id" yaml:"record_id"`
-	CreatedAt  time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt  time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	ID              string      `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Target          string      `boil:"target" json:"target" toml:"target" yaml:"target"`
+	Type            string      `boil:"type" json:"type" toml:"type" yaml:"type"`
+	TTL             int64       `boil:"ttl" json:"ttl" toml:"ttl" yaml:"ttl"`
+	HasDetails      bool        `boil:"has_details" json:"has_details" toml:"has_details" yaml:"has_details"`
+	OwnerID         string      `boil:"owner_id" json:"owner_id" toml:"owner_id" yaml:"owner_id"`
+	RecordID        string      `boil:"record_id" json:"record_id" toml:"record_id" yaml:"record_id"`
+	CreatedAt       time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt       time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	Healthy         bool        `boil:"healthy" json:"healthy" toml:"healthy" yaml:"healthy"`
+	HealthSuccesses int64       `boil:"health_successes" json:"health_successes" toml:"health_successes" yaml:"health_successes"`
+	HealthFailures  int64       `boil:"health_failures" json:"health_failures" toml:"health_failures" yaml:"health_failures"`
+	HealthCheckedAt null.Time   `boil:"health_checked_at" json:"health_checked_at,omitempty" toml:"health_checked_at" yaml:"health_checked_at,omitempty"`
+	HealthError     null.String `boil:"health_error" json:"health_error,omitempty" toml:"health_error" yaml:"health_error,omitempty"`
+	LeaseSeconds    null.Int64  `boil:"lease_seconds" json:"lease_seconds,omitempty" toml:"lease_seconds" yaml:"lease_seconds,omitempty"`
+	ExpiresAt       null.Time   `boil:"expires_at" json:"expires_at,omitempty" toml:"expires_at" yaml:"expires_at,omitempty"`
 
 	R *answerR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L answerL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var AnswerColumns = struct {
-	ID         string
-	Target     string
-	Type       string
-	TTL        string
-	HasDetails string
-	OwnerID    string
-	RecordID   string
-	CreatedAt  string
-	UpdatedAt  string
+	ID              string
+	Target          string
+	Type            string
+	TTL             string
+	HasDetails      string
+	OwnerID         string
+	RecordID        string
+	CreatedAt       string
+	UpdatedAt       string
+	Healthy         string
+	HealthSuccesses string
+	HealthFailures  string
+	HealthCheckedAt string
+	HealthError     string
+	LeaseSeconds    string
+	ExpiresAt       string
 }{
-	ID:         "id",
-	Target:     "target",
-	Type:       "type",
-	TTL:        "ttl",
-	HasDetails: "has_details",
-	OwnerID:    "owner_id",
-	RecordID:   "record_id",
-	CreatedAt:  "created_at",
-	UpdatedAt:  "updated_at",
+	ID:              "id",
+	Target:          "target",
+	Type:            "type",
+	TTL:             "ttl",
+	HasDetails:      "has_details",
+	OwnerID:         "owner_id",
+	RecordID:        "record_id",
+	CreatedAt:       "created_at",
+	UpdatedAt:       "updated_at",
+	Healthy:         "healthy",
+	HealthSuccesses: "health_successes",
+	HealthFailures:  "health_failures",
+	HealthCheckedAt: "health_checked_at",
+	HealthError:     "health_error",
+	LeaseSeconds:    "lease_seconds",
+	ExpiresAt:       "expires_at",
 }
 
 var AnswerTableColumns = struct {
-	ID         string
-	Target     string
-	Type       string
-	TTL        string
-	HasDetails string
-	OwnerID    string
-	RecordID   string
-	CreatedAt  string
-	UpdatedAt  string
+	ID              string
+	Target          string
+	Type            string
+	TTL             string
+	HasDetails      string
+	OwnerID         string
+	RecordID        string
+	CreatedAt       string
+	UpdatedAt       string
+	Healthy         string
+	HealthSuccesses string
+	HealthFailures  string
+	HealthCheckedAt string
+	HealthError     string
+	LeaseSeconds    string
+	ExpiresAt       string
 }{
-	ID:         "answers.id",
-	Target:     "answers.target",
-	Type:       "answers.type",
-	TTL:        "answers.ttl",
-	HasDetails: "answers.has_details",
-	OwnerID:    "answers.owner_id",
-	RecordID:   "answers.record_id",
-	CreatedAt:  "answers.created_at",
-	UpdatedAt:  "answers.updated_at",
+	ID:              "answers.id",
+	Target:          "answers.target",
+	Type:            "answers.type",
+	TTL:             "answers.ttl",
+	HasDetails:      "answers.has_details",
+	OwnerID:         "answers.owner_id",
+	RecordID:        "answers.record_id",
+	CreatedAt:       "answers.created_at",
+	UpdatedAt:       "answers.updated_at",
+	Healthy:         "answers.healthy",
+	HealthSuccesses: "answers.health_successes",
+	HealthFailures:  "answers.health_failures",
+	HealthCheckedAt: "answers.health_checked_at",
+	HealthError:     "answers.health_error",
+	LeaseSeconds:    "answers.lease_seconds",
+	ExpiresAt:       "answers.expires_at",
 }
 
 // Generated where
@@ -115,26 +151,64 @@ func (w whereHelperbool) LTE(x bool) qm.QueryMod { return qmhelper.Where(w.field
 func (w whereHelperbool) GT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
 func (w whereHelperbool) GTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
 
+type whereHelpernull_Time struct{ field string }
+
+func (w whereHelpernull_Time) EQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Time) NEQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Time) LT(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Time) LTE(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Time) GT(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
 var AnswerWhere = struct {
-	ID         whereHelperstring
-	Target     whereHelperstring
-	Type       whereHelperstring
-	TTL        whereHelperint64
-	HasDetails whereHelperbool
-	OwnerID    whereHelperstring
-	RecordID   whereHelperstring
-	CreatedAt  whereHelpertime_Time
-	UpdatedAt  whereHelpertime_Time
+	ID              whereHelperstring
+	Target          whereHelperstring
+	Type            whereHelperstring
+	TTL             whereHelperint64
+	HasDetails      whereHelperbool
+	OwnerID         whereHelperstring
+	RecordID        whereHelperstring
+	CreatedAt       whereHelpertime_Time
+	UpdatedAt       whereHelpertime_Time
+	Healthy         whereHelperbool
+	HealthSuccesses whereHelperint64
+	HealthFailures  whereHelperint64
+	HealthCheckedAt whereHelpernull_Time
+	HealthError     whereHelpernull_String
+	LeaseSeconds    whereHelpernull_Int64
+	ExpiresAt       whereHelpernull_Time
 }{
-	ID:         whereHelperstring{field: "\"answers\".\"id\""},
-	Target:     whereHelperstring{field: "\"answers\".\"target\""},
-	Type:       whereHelperstring{field: "\"answers\".\"type\""},
-	TTL:        whereHelperint64{field: "\"answers\".\"ttl\""},
-	HasDetails: whereHelperbool{field: "\"answers\".\"has_details\""},
-	OwnerID:    whereHelperstring{field: "\"answers\".\"owner_id\""},
-	RecordID:   whereHelperstring{field: "\"answers\".\"record_id\""},
-	CreatedAt:  whereHelpertime_Time{field: "\"answers\".\"created_at\""},
-	UpdatedAt:  whereHelpertime_Time{field: "\"answers\".\"updated_at\""},
+	ID:              whereHelperstring{field: "\"answers\".\"id\""},
+	Target:          whereHelperstring{field: "\"answers\".\"target\""},
+	Type:            whereHelperstring{field: "\"answers\".\"type\""},
+	TTL:             whereHelperint64{field: "\"answers\".\"ttl\""},
+	HasDetails:      whereHelperbool{field: "\"answers\".\"has_details\""},
+	OwnerID:         whereHelperstring{field: "\"answers\".\"owner_id\""},
+	RecordID:        whereHelperstring{field: "\"answers\".\"record_id\""},
+	CreatedAt:       whereHelpertime_Time{field: "\"answers\".\"created_at\""},
+	UpdatedAt:       whereHelpertime_Time{field: "\"answers\".\"updated_at\""},
+	Healthy:         whereHelperbool{field: "\"answers\".\"healthy\""},
+	HealthSuccesses: whereHelperint64{field: "\"answers\".\"health_successes\""},
+	HealthFailures:  whereHelperint64{field: "\"answers\".\"health_failures\""},
+	HealthCheckedAt: whereHelpernull_Time{field: "\"answers\".\"health_checked_at\""},
+	HealthError:     whereHelpernull_String{field: "\"answers\".\"health_error\""},
+	LeaseSeconds:    whereHelpernull_Int64{field: "\"answers\".\"lease_seconds\""},
+	ExpiresAt:       whereHelpernull_Time{field: "\"answers\".\"expires_at\""},
 }
 
 // AnswerRels is where relationship names are stored.
@@ -185,9 +259,9 @@ func (r *answerR) GetAnswerDetail() *AnswerDetail {
 type answerL struct{}
 
 var (
-	answerAllColumns            = []string{"id", "target", "type", "ttl", "has_details", "owner_id", "record_id", "created_at", "updated_at"}
+	answerAllColumns            = []string{"id", "target", "type", "ttl", "has_details", "owner_id", "record_id", "created_at", "updated_at", "healthy", "health_successes", "health_failures", "health_checked_at", "health_error", "lease_seconds", "expires_at"}
 	answerColumnsWithoutDefault = []string{"target", "type", "has_details", "owner_id", "record_id", "created_at", "updated_at"}
-	answerColumnsWithDefault    = []string{"id", "ttl"}
+	answerColumnsWithDefault    = []string{"id", "ttl", "healthy", "health_successes", "health_failures", "health_checked_at", "health_error", "lease_seconds", "expires_at"}
 	answerPrimaryKeyColumns     = []string{"id"}
 	answerGeneratedColumns      = []string{}
 )
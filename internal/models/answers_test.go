@@ -946,7 +946,7 @@ func testAnswersSelect(t *testing.T) {
 }
 
 var (
-	answerDBTypes = map[string]string{`ID`: `uuid`, `Target`: `string`, `Type`: `string`, `TTL`: `int8`, `HasDetails`: `bool`, `OwnerID`: `uuid`, `RecordID`: `uuid`, `CreatedAt`: `timestamptz`, `UpdatedAt`: `timestamptz`}
+	answerDBTypes = map[string]string{`ID`: `uuid`, `Target`: `string`, `Type`: `string`, `TTL`: `int8`, `HasDetails`: `bool`, `OwnerID`: `uuid`, `RecordID`: `uuid`, `CreatedAt`: `timestamptz`, `UpdatedAt`: `timestamptz`, `Healthy`: `bool`, `HealthSuccesses`: `int8`, `HealthFailures`: `int8`, `HealthCheckedAt`: `timestamptz`, `HealthError`: `string`, `LeaseSeconds`: `int8`, `ExpiresAt`: `timestamptz`}
 	_             = bytes.MinRead
 )
 
@@ -14,8 +14,15 @@ import "testing"
 func TestParent(t *testing.T) {
 	t.Run("AnswerDetails", testAnswerDetails)
 	t.Run("Answers", testAnswers)
+	t.Run("AuditEvents", testAuditEvents)
+	t.Run("Locks", testLocks)
+	t.Run("Outboxes", testOutboxes)
 	t.Run("Owners", testOwners)
 	t.Run("Records", testRecords)
+	t.Run("WebhookDeadLetters", testWebhookDeadLetters)
+	t.Run("WebhookDeliveries", testWebhookDeliveries)
+	t.Run("Webhooks", testWebhooks)
+	t.Run("Zones", testZones)
 }
 
 func TestSoftDelete(t *testing.T) {}
@@ -27,71 +34,141 @@ func TestSliceSoftDeleteAll(t *testing.T) {}
 func TestDelete(t *testing.T) {
 	t.Run("AnswerDetails", testAnswerDetailsDelete)
 	t.Run("Answers", testAnswersDelete)
+	t.Run("AuditEvents", testAuditEventsDelete)
+	t.Run("Locks", testLocksDelete)
+	t.Run("Outboxes", testOutboxesDelete)
 	t.Run("Owners", testOwnersDelete)
 	t.Run("Records", testRecordsDelete)
+	t.Run("WebhookDeadLetters", testWebhookDeadLettersDelete)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesDelete)
+	t.Run("Webhooks", testWebhooksDelete)
+	t.Run("Zones", testZonesDelete)
 }
 
 func TestQueryDeleteAll(t *testing.T) {
 	t.Run("AnswerDetails", testAnswerDetailsQueryDeleteAll)
 	t.Run("Answers", testAnswersQueryDeleteAll)
+	t.Run("AuditEvents", testAuditEventsQueryDeleteAll)
+	t.Run("Locks", testLocksQueryDeleteAll)
+	t.Run("Outboxes", testOutboxesQueryDeleteAll)
 	t.Run("Owners", testOwnersQueryDeleteAll)
 	t.Run("Records", testRecordsQueryDeleteAll)
+	t.Run("WebhookDeadLetters", testWebhookDeadLettersQueryDeleteAll)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesQueryDeleteAll)
+	t.Run("Webhooks", testWebhooksQueryDeleteAll)
+	t.Run("Zones", testZonesQueryDeleteAll)
 }
 
 func TestSliceDeleteAll(t *testing.T) {
 	t.Run("AnswerDetails", testAnswerDetailsSliceDeleteAll)
 	t.Run("Answers", testAnswersSliceDeleteAll)
+	t.Run("AuditEvents", testAuditEventsSliceDeleteAll)
+	t.Run("Locks", testLocksSliceDeleteAll)
+	t.Run("Outboxes", testOutboxesSliceDeleteAll)
 	t.Run("Owners", testOwnersSliceDeleteAll)
 	t.Run("Records", testRecordsSliceDeleteAll)
+	t.Run("WebhookDeadLetters", testWebhookDeadLettersSliceDeleteAll)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesSliceDeleteAll)
+	t.Run("Webhooks", testWebhooksSliceDeleteAll)
+	t.Run("Zones", testZonesSliceDeleteAll)
 }
 
 func TestExists(t *testing.T) {
 	t.Run("AnswerDetails", testAnswerDetailsExists)
 	t.Run("Answers", testAnswersExists)
+	t.Run("AuditEvents", testAuditEventsExists)
+	t.Run("Locks", testLocksExists)
+	t.Run("Outboxes", testOutboxesExists)
 	t.Run("Owners", testOwnersExists)
 	t.Run("Records", testRecordsExists)
+	t.Run("WebhookDeadLetters", testWebhookDeadLettersExists)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesExists)
+	t.Run("Webhooks", testWebhooksExists)
+	t.Run("Zones", testZonesExists)
 }
 
 func TestFind(t *testing.T) {
 	t.Run("AnswerDetails", testAnswerDetailsFind)
 	t.Run("Answers", testAnswersFind)
+	t.Run("AuditEvents", testAuditEventsFind)
+	t.Run("Locks", testLocksFind)
+	t.Run("Outboxes", testOutboxesFind)
 	t.Run("Owners", testOwnersFind)
 	t.Run("Records", testRecordsFind)
+	t.Run("WebhookDeadLetters", testWebhookDeadLettersFind)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesFind)
+	t.Run("Webhooks", testWebhooksFind)
+	t.Run("Zones", testZonesFind)
 }
 
 func TestBind(t *testing.T) {
 	t.Run("AnswerDetails", testAnswerDetailsBind)
 	t.Run("Answers", testAnswersBind)
+	t.Run("AuditEvents", testAuditEventsBind)
+	t.Run("Locks", testLocksBind)
+	t.Run("Outboxes", testOutboxesBind)
 	t.Run("Owners", testOwnersBind)
 	t.Run("Records", testRecordsBind)
+	t.Run("WebhookDeadLetters", testWebhookDeadLettersBind)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesBind)
+	t.Run("Webhooks", testWebhooksBind)
+	t.Run("Zones", testZonesBind)
 }
 
 func TestOne(t *testing.T) {
 	t.Run("AnswerDetails", testAnswerDetailsOne)
 	t.Run("Answers", testAnswersOne)
+	t.Run("AuditEvents", testAuditEventsOne)
+	t.Run("Locks", testLocksOne)
+	t.Run("Outboxes", testOutboxesOne)
 	t.Run("Owners", testOwnersOne)
 	t.Run("Records", testRecordsOne)
+	t.Run("WebhookDeadLetters", testWebhookDeadLettersOne)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesOne)
+	t.Run("Webhooks", testWebhooksOne)
+	t.Run("Zones", testZonesOne)
 }
 
 func TestAll(t *testing.T) {
 	t.Run("AnswerDetails", testAnswerDetailsAll)
 	t.Run("Answers", testAnswersAll)
+	t.Run("AuditEvents", testAuditEventsAll)
+	t.Run("Locks", testLocksAll)
+	t.Run("Outboxes", testOutboxesAll)
 	t.Run("Owners", testOwnersAll)
 	t.Run("Records", testRecordsAll)
+	t.Run("WebhookDeadLetters", testWebhookDeadLettersAll)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesAll)
+	t.Run("Webhooks", testWebhooksAll)
+	t.Run("Zones", testZonesAll)
 }
 
 func TestCount(t *testing.T) {
 	t.Run("AnswerDetails", testAnswerDetailsCount)
 	t.Run("Answers", testAnswersCount)
+	t.Run("AuditEvents", testAuditEventsCount)
+	t.Run("Locks", testLocksCount)
+	t.Run("Outboxes", testOutboxesCount)
 	t.Run("Owners", testOwnersCount)
 	t.Run("Records", testRecordsCount)
+	t.Run("WebhookDeadLetters", testWebhookDeadLettersCount)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesCount)
+	t.Run("Webhooks", testWebhooksCount)
+	t.Run("Zones", testZonesCount)
 }
 
 func TestHooks(t *testing.T) {
 	t.Run("AnswerDetails", testAnswerDetailsHooks)
 	t.Run("Answers", testAnswersHooks)
+	t.Run("AuditEvents", testAuditEventsHooks)
+	t.Run("Locks", testLocksHooks)
+	t.Run("Outboxes", testOutboxesHooks)
 	t.Run("Owners", testOwnersHooks)
 	t.Run("Records", testRecordsHooks)
+	t.Run("WebhookDeadLetters", testWebhookDeadLettersHooks)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesHooks)
+	t.Run("Webhooks", testWebhooksHooks)
+	t.Run("Zones", testZonesHooks)
 }
 
 func TestInsert(t *testing.T) {
@@ -99,10 +176,24 @@ func TestInsert(t *testing.T) {
 	t.Run("AnswerDetails", testAnswerDetailsInsertWhitelist)
 	t.Run("Answers", testAnswersInsert)
 	t.Run("Answers", testAnswersInsertWhitelist)
+	t.Run("AuditEvents", testAuditEventsInsert)
+	t.Run("AuditEvents", testAuditEventsInsertWhitelist)
+	t.Run("Locks", testLocksInsert)
+	t.Run("Locks", testLocksInsertWhitelist)
+	t.Run("Outboxes", testOutboxesInsert)
+	t.Run("Outboxes", testOutboxesInsertWhitelist)
 	t.Run("Owners", testOwnersInsert)
 	t.Run("Owners", testOwnersInsertWhitelist)
 	t.Run("Records", testRecordsInsert)
 	t.Run("Records", testRecordsInsertWhitelist)
+	t.Run("WebhookDeadLetters", testWebhookDeadLettersInsert)
+	t.Run("WebhookDeadLetters", testWebhookDeadLettersInsertWhitelist)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesInsert)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesInsertWhitelist)
+	t.Run("Webhooks", testWebhooksInsert)
+	t.Run("Webhooks", testWebhooksInsertWhitelist)
+	t.Run("Zones", testZonesInsert)
+	t.Run("Zones", testZonesInsertWhitelist)
 }
 
 // TestToOne tests cannot be run in parallel
@@ -111,6 +202,9 @@ func TestToOne(t *testing.T) {
 	t.Run("AnswerDetailToAnswerUsingAnswer", testAnswerDetailToOneAnswerUsingAnswer)
 	t.Run("AnswerToRecordUsingRecord", testAnswerToOneRecordUsingRecord)
 	t.Run("AnswerToOwnerUsingOwner", testAnswerToOneOwnerUsingOwner)
+	t.Run("RecordToZoneUsingZone", testRecordToOneZoneUsingZone)
+	t.Run("WebhookDeadLetterToWebhookUsingWebhook", testWebhookDeadLetterToOneWebhookUsingWebhook)
+	t.Run("WebhookDeliveryToWebhookUsingWebhook", testWebhookDeliveryToOneWebhookUsingWebhook)
 }
 
 // TestOneToOne tests cannot be run in parallel
@@ -124,6 +218,9 @@ func TestOneToOne(t *testing.T) {
 func TestToMany(t *testing.T) {
 	t.Run("OwnerToAnswers", testOwnerToManyAnswers)
 	t.Run("RecordToAnswers", testRecordToManyAnswers)
+	t.Run("WebhookToWebhookDeadLetters", testWebhookToManyWebhookDeadLetters)
+	t.Run("WebhookToWebhookDeliveries", testWebhookToManyWebhookDeliveries)
+	t.Run("ZoneToRecords", testZoneToManyRecords)
 }
 
 // TestToOneSet tests cannot be run in parallel
@@ -132,11 +229,16 @@ func TestToOneSet(t *testing.T) {
 	t.Run("AnswerDetailToAnswerUsingAnswerDetail", testAnswerDetailToOneSetOpAnswerUsingAnswer)
 	t.Run("AnswerToRecordUsingAnswers", testAnswerToOneSetOpRecordUsingRecord)
 	t.Run("AnswerToOwnerUsingAnswers", testAnswerToOneSetOpOwnerUsingOwner)
+	t.Run("RecordToZoneUsingRecords", testRecordToOneSetOpZoneUsingZone)
+	t.Run("WebhookDeadLetterToWebhookUsingWebhookDeadLetters", testWebhookDeadLetterToOneSetOpWebhookUsingWebhook)
+	t.Run("WebhookDeliveryToWebhookUsingWebhookDeliveries", testWebhookDeliveryToOneSetOpWebhookUsingWebhook)
 }
 
 // TestToOneRemove tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToOneRemove(t *testing.T) {}
+func TestToOneRemove(t *testing.T) {
+	t.Run("RecordToZoneUsingRecords", testRecordToOneRemoveOpZoneUsingZone)
+}
 
 // TestOneToOneSet tests cannot be run in parallel
 // or deadlocks can occur.
@@ -153,47 +255,89 @@ func TestOneToOneRemove(t *testing.T) {}
 func TestToManyAdd(t *testing.T) {
 	t.Run("OwnerToAnswers", testOwnerToManyAddOpAnswers)
 	t.Run("RecordToAnswers", testRecordToManyAddOpAnswers)
+	t.Run("WebhookToWebhookDeadLetters", testWebhookToManyAddOpWebhookDeadLetters)
+	t.Run("WebhookToWebhookDeliveries", testWebhookToManyAddOpWebhookDeliveries)
+	t.Run("ZoneToRecords", testZoneToManyAddOpRecords)
 }
 
 // TestToManySet tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToManySet(t *testing.T) {}
+func TestToManySet(t *testing.T) {
+	t.Run("ZoneToRecords", testZoneToManySetOpRecords)
+}
 
 // TestToManyRemove tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToManyRemove(t *testing.T) {}
+func TestToManyRemove(t *testing.T) {
+	t.Run("ZoneToRecords", testZoneToManyRemoveOpRecords)
+}
 
 func TestReload(t *testing.T) {
 	t.Run("AnswerDetails", testAnswerDetailsReload)
 	t.Run("Answers", testAnswersReload)
+	t.Run("AuditEvents", testAuditEventsReload)
+	t.Run("Locks", testLocksReload)
+	t.Run("Outboxes", testOutboxesReload)
 	t.Run("Owners", testOwnersReload)
 	t.Run("Records", testRecordsReload)
+	t.Run("WebhookDeadLetters", testWebhookDeadLettersReload)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesReload)
+	t.Run("Webhooks", testWebhooksReload)
+	t.Run("Zones", testZonesReload)
 }
 
 func TestReloadAll(t *testing.T) {
 	t.Run("AnswerDetails", testAnswerDetailsReloadAll)
 	t.Run("Answers", testAnswersReloadAll)
+	t.Run("AuditEvents", testAuditEventsReloadAll)
+	t.Run("Locks", testLocksReloadAll)
+	t.Run("Outboxes", testOutboxesReloadAll)
 	t.Run("Owners", testOwnersReloadAll)
 	t.Run("Records", testRecordsReloadAll)
+	t.Run("WebhookDeadLetters", testWebhookDeadLettersReloadAll)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesReloadAll)
+	t.Run("Webhooks", testWebhooksReloadAll)
+	t.Run("Zones", testZonesReloadAll)
 }
 
 func TestSelect(t *testing.T) {
 	t.Run("AnswerDetails", testAnswerDetailsSelect)
 	t.Run("Answers", testAnswersSelect)
+	t.Run("AuditEvents", testAuditEventsSelect)
+	t.Run("Locks", testLocksSelect)
+	t.Run("Outboxes", testOutboxesSelect)
 	t.Run("Owners", testOwnersSelect)
 	t.Run("Records", testRecordsSelect)
+	t.Run("WebhookDeadLetters", testWebhookDeadLettersSelect)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesSelect)
+	t.Run("Webhooks", testWebhooksSelect)
+	t.Run("Zones", testZonesSelect)
 }
 
 func TestUpdate(t *testing.T) {
 	t.Run("AnswerDetails", testAnswerDetailsUpdate)
 	t.Run("Answers", testAnswersUpdate)
+	t.Run("AuditEvents", testAuditEventsUpdate)
+	t.Run("Locks", testLocksUpdate)
+	t.Run("Outboxes", testOutboxesUpdate)
 	t.Run("Owners", testOwnersUpdate)
 	t.Run("Records", testRecordsUpdate)
+	t.Run("WebhookDeadLetters", testWebhookDeadLettersUpdate)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesUpdate)
+	t.Run("Webhooks", testWebhooksUpdate)
+	t.Run("Zones", testZonesUpdate)
 }
 
 func TestSliceUpdateAll(t *testing.T) {
 	t.Run("AnswerDetails", testAnswerDetailsSliceUpdateAll)
 	t.Run("Answers", testAnswersSliceUpdateAll)
+	t.Run("AuditEvents", testAuditEventsSliceUpdateAll)
+	t.Run("Locks", testLocksSliceUpdateAll)
+	t.Run("Outboxes", testOutboxesSliceUpdateAll)
 	t.Run("Owners", testOwnersSliceUpdateAll)
 	t.Run("Records", testRecordsSliceUpdateAll)
+	t.Run("WebhookDeadLetters", testWebhookDeadLettersSliceUpdateAll)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesSliceUpdateAll)
+	t.Run("Webhooks", testWebhooksSliceUpdateAll)
+	t.Run("Zones", testZonesSliceUpdateAll)
 }
@@ -4,13 +4,27 @@
 package models
 
 var TableNames = struct {
-	AnswerDetails string
-	Answers       string
-	Owners        string
-	Records       string
+	AnswerDetails      string
+	Answers            string
+	AuditEvents        string
+	Locks              string
+	Outbox             string
+	Owners             string
+	Records            string
+	WebhookDeadLetters string
+	WebhookDeliveries  string
+	Webhooks           string
+	Zones              string
 }{
-	AnswerDetails: "answer_details",
-	Answers:       "answers",
-	Owners:        "owners",
-	Records:       "records",
+	AnswerDetails:      "answer_details",
+	Answers:            "answers",
+	AuditEvents:        "audit_events",
+	Locks:              "locks",
+	Outbox:             "outbox",
+	Owners:             "owners",
+	Records:            "records",
+	WebhookDeadLetters: "webhook_dead_letters",
+	WebhookDeliveries:  "webhook_deliveries",
+	Webhooks:           "webhooks",
+	Zones:              "zones",
 }
@@ -8,6 +8,13 @@ import "testing"
 func TestUpsert(t *testing.T) {
 	t.Run("AnswerDetails", testAnswerDetailsUpsert)
 	t.Run("Answers", testAnswersUpsert)
+	t.Run("AuditEvents", testAuditEventsUpsert)
+	t.Run("Locks", testLocksUpsert)
+	t.Run("Outboxes", testOutboxesUpsert)
 	t.Run("Owners", testOwnersUpsert)
 	t.Run("Records", testRecordsUpsert)
+	t.Run("WebhookDeadLetters", testWebhookDeadLettersUpsert)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesUpsert)
+	t.Run("Webhooks", testWebhooksUpsert)
+	t.Run("Zones", testZonesUpsert)
 }
@@ -0,0 +1,732 @@
+// Code generated by SQLBoiler 4.13.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/randomize"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/strmangle"
+)
+
+func testLocksUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(lockAllColumns) == len(lockPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := Lock{}
+	if err = randomize.Struct(seed, &o, lockDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize Lock struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert Lock: %s", err)
+	}
+
+	count, err := Locks().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, lockDBTypes, false, lockPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize Lock struct: %s", err)
+	}
+
+	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert Lock: %s", err)
+	}
+
+	count, err = Locks().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testLocks(t *testing.T) {
+	t.Parallel()
+
+	query := Locks()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testLocksDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Lock{}
+	if err = randomize.Struct(seed, o, lockDBTypes, true, lockColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lock struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := Locks().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testLocksQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Lock{}
+	if err = randomize.Struct(seed, o, lockDBTypes, true, lockColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lock struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := Locks().DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := Locks().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testLocksSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Lock{}
+	if err = randomize.Struct(seed, o, lockDBTypes, true, lockColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lock struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := LockSlice{o}
+
+	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := Locks().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testLocksExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Lock{}
+	if err = randomize.Struct(seed, o, lockDBTypes, true, lockColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lock struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := LockExists(ctx, tx, o.Name)
+	if err != nil {
+		t.Errorf("Unable to check if Lock exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected LockExists to return true, but got false.")
+	}
+}
+
+func testLocksFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Lock{}
+	if err = randomize.Struct(seed, o, lockDBTypes, true, lockColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lock struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	lockFound, err := FindLock(ctx, tx, o.Name)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if lockFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testLocksBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Lock{}
+	if err = randomize.Struct(seed, o, lockDBTypes, true, lockColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lock struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = Locks().Bind(ctx, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testLocksOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Lock{}
+	if err = randomize.Struct(seed, o, lockDBTypes, true, lockColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lock struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := Locks().One(ctx, tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testLocksAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	lockOne := &Lock{}
+	lockTwo := &Lock{}
+	if err = randomize.Struct(seed, lockOne, lockDBTypes, false, lockColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lock struct: %s", err)
+	}
+	if err = randomize.Struct(seed, lockTwo, lockDBTypes, false, lockColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lock struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = lockOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = lockTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := Locks().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testLocksCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	lockOne := &Lock{}
+	lockTwo := &Lock{}
+	if err = randomize.Struct(seed, lockOne, lockDBTypes, false, lockColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lock struct: %s", err)
+	}
+	if err = randomize.Struct(seed, lockTwo, lockDBTypes, false, lockColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lock struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = lockOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = lockTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Locks().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func lockBeforeInsertHook(ctx context.Context, e boil.ContextExecutor, o *Lock) error {
+	*o = Lock{}
+	return nil
+}
+
+func lockAfterInsertHook(ctx context.Context, e boil.ContextExecutor, o *Lock) error {
+	*o = Lock{}
+	return nil
+}
+
+func lockAfterSelectHook(ctx context.Context, e boil.ContextExecutor, o *Lock) error {
+	*o = Lock{}
+	return nil
+}
+
+func lockBeforeUpdateHook(ctx context.Context, e boil.ContextExecutor, o *Lock) error {
+	*o = Lock{}
+	return nil
+}
+
+func lockAfterUpdateHook(ctx context.Context, e boil.ContextExecutor, o *Lock) error {
+	*o = Lock{}
+	return nil
+}
+
+func lockBeforeDeleteHook(ctx context.Context, e boil.ContextExecutor, o *Lock) error {
+	*o = Lock{}
+	return nil
+}
+
+func lockAfterDeleteHook(ctx context.Context, e boil.ContextExecutor, o *Lock) error {
+	*o = Lock{}
+	return nil
+}
+
+func lockBeforeUpsertHook(ctx context.Context, e boil.ContextExecutor, o *Lock) error {
+	*o = Lock{}
+	return nil
+}
+
+func lockAfterUpsertHook(ctx context.Context, e boil.ContextExecutor, o *Lock) error {
+	*o = Lock{}
+	return nil
+}
+
+func testLocksHooks(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	ctx := context.Background()
+	empty := &Lock{}
+	o := &Lock{}
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, o, lockDBTypes, false); err != nil {
+		t.Errorf("Unable to randomize Lock object: %s", err)
+	}
+
+	AddLockHook(boil.BeforeInsertHook, lockBeforeInsertHook)
+	if err = o.doBeforeInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
+	}
+	lockBeforeInsertHooks = []LockHook{}
+
+	AddLockHook(boil.AfterInsertHook, lockAfterInsertHook)
+	if err = o.doAfterInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
+	}
+	lockAfterInsertHooks = []LockHook{}
+
+	AddLockHook(boil.AfterSelectHook, lockAfterSelectHook)
+	if err = o.doAfterSelectHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
+	}
+	lockAfterSelectHooks = []LockHook{}
+
+	AddLockHook(boil.BeforeUpdateHook, lockBeforeUpdateHook)
+	if err = o.doBeforeUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
+	}
+	lockBeforeUpdateHooks = []LockHook{}
+
+	AddLockHook(boil.AfterUpdateHook, lockAfterUpdateHook)
+	if err = o.doAfterUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
+	}
+	lockAfterUpdateHooks = []LockHook{}
+
+	AddLockHook(boil.BeforeDeleteHook, lockBeforeDeleteHook)
+	if err = o.doBeforeDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
+	}
+	lockBeforeDeleteHooks = []LockHook{}
+
+	AddLockHook(boil.AfterDeleteHook, lockAfterDeleteHook)
+	if err = o.doAfterDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
+	}
+	lockAfterDeleteHooks = []LockHook{}
+
+	AddLockHook(boil.BeforeUpsertHook, lockBeforeUpsertHook)
+	if err = o.doBeforeUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
+	}
+	lockBeforeUpsertHooks = []LockHook{}
+
+	AddLockHook(boil.AfterUpsertHook, lockAfterUpsertHook)
+	if err = o.doAfterUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
+	}
+	lockAfterUpsertHooks = []LockHook{}
+}
+
+func testLocksInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Lock{}
+	if err = randomize.Struct(seed, o, lockDBTypes, true, lockColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lock struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Locks().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testLocksInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Lock{}
+	if err = randomize.Struct(seed, o, lockDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize Lock struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Whitelist(lockColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Locks().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testLocksReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Lock{}
+	if err = randomize.Struct(seed, o, lockDBTypes, true, lockColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lock struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testLocksReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Lock{}
+	if err = randomize.Struct(seed, o, lockDBTypes, true, lockColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lock struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := LockSlice{o}
+
+	if err = slice.ReloadAll(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testLocksSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Lock{}
+	if err = randomize.Struct(seed, o, lockDBTypes, true, lockColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lock struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := Locks().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	lockDBTypes = map[string]string{`Name`: `string`, `Holder`: `string`, `ExpiresAt`: `timestamptz`}
+	_           = bytes.MinRead
+)
+
+func testLocksUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(lockPrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(lockAllColumns) == len(lockPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Lock{}
+	if err = randomize.Struct(seed, o, lockDBTypes, true, lockColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lock struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Locks().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, lockDBTypes, true, lockPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize Lock struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testLocksSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(lockAllColumns) == len(lockPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Lock{}
+	if err = randomize.Struct(seed, o, lockDBTypes, true, lockColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Lock struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Locks().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, lockDBTypes, true, lockPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize Lock struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(lockAllColumns, lockPrimaryKeyColumns) {
+		fields = lockAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			lockAllColumns,
+			lockPrimaryKeyColumns,
+		)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := LockSlice{o}
+	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
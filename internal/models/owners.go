@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -23,12 +24,13 @@ import (
 
 // Owner is an object representing the database table.
 type Owner struct {
-	ID        string    `boil:"id" json:"id" toml:"id" yaml:"id"`
-	Name      string    `boil:"name" json:"name" toml:"name" yaml:"name"`
-	Origin    string    `boil:"origin" json:"origin" toml:"origin" yaml:"origin"`
-	Service   string    `boil:"service" json:"service" toml:"service" yaml:"service"`
-	CreatedAt time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	ID        string      `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Name      string      `boil:"name" json:"name" toml:"name" yaml:"name"`
+	Origin    string      `boil:"origin" json:"origin" toml:"origin" yaml:"origin"`
+	Service   string      `boil:"service" json:"service" toml:"service" yaml:"service"`
+	CreatedAt time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	Principal null.String `boil:"principal" json:"principal,omitempty" toml:"principal" yaml:"principal,omitempty"`
 
 	R *ownerR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L ownerL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -41,6 +43,7 @@ var OwnerColumns = struct {
 	Service   string
 	CreatedAt string
 	UpdatedAt string
+	Principal string
 }{
 	ID:        "id",
 	Name:      "name",
@@ -48,6 +51,7 @@ var OwnerColumns = struct {
 	Service:   "service",
 	CreatedAt: "created_at",
 	UpdatedAt: "updated_at",
+	Principal: "principal",
 }
 
 var OwnerTableColumns = struct {
@@ -57,6 +61,7 @@ var OwnerTableColumns = struct {
 	Service   string
 	CreatedAt string
 	UpdatedAt string
+	Principal string
 }{
 	ID:        "owners.id",
 	Name:      "owners.name",
@@ -64,6 +69,7 @@ var OwnerTableColumns = struct {
 	Service:   "owners.service",
 	CreatedAt: "owners.created_at",
 	UpdatedAt: "owners.updated_at",
+	Principal: "owners.principal",
 }
 
 // Generated where
@@ -75,6 +81,7 @@ var OwnerWhere = struct {
 	Service   whereHelperstring
 	CreatedAt whereHelpertime_Time
 	UpdatedAt whereHelpertime_Time
+	Principal whereHelpernull_String
 }{
 	ID:        whereHelperstring{field: "\"owners\".\"id\""},
 	Name:      whereHelperstring{field: "\"owners\".\"name\""},
@@ -82,6 +89,7 @@ var OwnerWhere = struct {
 	Service:   whereHelperstring{field: "\"owners\".\"service\""},
 	CreatedAt: whereHelpertime_Time{field: "\"owners\".\"created_at\""},
 	UpdatedAt: whereHelpertime_Time{field: "\"owners\".\"updated_at\""},
+	Principal: whereHelpernull_String{field: "\"owners\".\"principal\""},
 }
 
 // OwnerRels is where relationship names are stored.
@@ -112,9 +120,9 @@ func (r *ownerR) GetAnswers() AnswerSlice {
 type ownerL struct{}
 
 var (
-	ownerAllColumns            = []string{"id", "name", "origin", "service", "created_at", "updated_at"}
+	ownerAllColumns            = []string{"id", "name", "origin", "service", "created_at", "updated_at", "principal"}
 	ownerColumnsWithoutDefault = []string{"name", "origin", "service", "created_at", "updated_at"}
-	ownerColumnsWithDefault    = []string{"id"}
+	ownerColumnsWithDefault    = []string{"id", "principal"}
 	ownerPrimaryKeyColumns     = []string{"id"}
 	ownerGeneratedColumns      = []string{}
 )
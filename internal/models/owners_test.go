@@ -770,7 +770,7 @@ func testOwnersSelect(t *testing.T) {
 }
 
 var (
-	ownerDBTypes = map[string]string{`ID`: `uuid`, `Name`: `string`, `Origin`: `string`, `Service`: `string`, `CreatedAt`: `timestamptz`, `UpdatedAt`: `timestamptz`}
+	ownerDBTypes = map[string]string{`ID`: `uuid`, `Name`: `string`, `Origin`: `string`, `Service`: `string`, `CreatedAt`: `timestamptz`, `UpdatedAt`: `timestamptz`, `Principal`: `string`}
 	_            = bytes.MinRead
 )
 
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -23,11 +24,12 @@ import (
 
 // Record is an object representing the database table.
 type Record struct {
-	ID         string    `boil:"id" json:"id" toml:"id" yaml:"id"`
-	Record     string    `boil:"record" json:"record" toml:"record" yaml:"record"`
-	RecordType string    `boil:"record_type" json:"record_type" toml:"record_type" yaml:"record_type"`
-	CreatedAt  time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt  time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	ID         string      `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Record     string      `boil:"record" json:"record" toml:"record" yaml:"record"`
+	RecordType string      `boil:"record_type" json:"record_type" toml:"record_type" yaml:"record_type"`
+	CreatedAt  time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt  time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	ZoneID     null.String `boil:"zone_id" json:"zone_id,omitempty" toml:"zone_id" yaml:"zone_id,omitempty"`
 
 	R *recordR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L recordL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -39,12 +41,14 @@ var RecordColumns = struct {
 	RecordType string
 	CreatedAt  string
 	UpdatedAt  string
+	ZoneID     string
 }{
 	ID:         "id",
 	Record:     "record",
 	RecordType: "record_type",
 	CreatedAt:  "created_at",
 	UpdatedAt:  "updated_at",
+	ZoneID:     "zone_id",
 }
 
 var RecordTableColumns = struct {
@@ -53,12 +57,14 @@ var RecordTableColumns = struct {
 	RecordType string
 	CreatedAt  string
 	UpdatedAt  string
+	ZoneID     string
 }{
 	ID:         "records.id",
 	Record:     "records.record",
 	RecordType: "records.record_type",
 	CreatedAt:  "records.created_at",
 	UpdatedAt:  "records.updated_at",
+	ZoneID:     "records.zone_id",
 }
 
 // Generated where
@@ -69,23 +75,28 @@ var RecordWhere = struct {
 	RecordType whereHelperstring
 	CreatedAt  whereHelpertime_Time
 	UpdatedAt  whereHelpertime_Time
+	ZoneID     whereHelpernull_String
 }{
 	ID:         whereHelperstring{field: "\"records\".\"id\""},
 	Record:     whereHelperstring{field: "\"records\".\"record\""},
 	RecordType: whereHelperstring{field: "\"records\".\"record_type\""},
 	CreatedAt:  whereHelpertime_Time{field: "\"records\".\"created_at\""},
 	UpdatedAt:  whereHelpertime_Time{field: "\"records\".\"updated_at\""},
+	ZoneID:     whereHelpernull_String{field: "\"records\".\"zone_id\""},
 }
 
 // RecordRels is where relationship names are stored.
 var RecordRels = struct {
+	Zone    string
 	Answers string
 }{
+	Zone:    "Zone",
 	Answers: "Answers",
 }
 
 // recordR is where relationships are stored.
 type recordR struct {
+	Zone    *Zone       `boil:"Zone" json:"Zone" toml:"Zone" yaml:"Zone"`
 	Answers AnswerSlice `boil:"Answers" json:"Answers" toml:"Answers" yaml:"Answers"`
 }
 
@@ -94,6 +105,13 @@ func (*recordR) NewStruct() *recordR {
 	return &recordR{}
 }
 
+func (r *recordR) GetZone() *Zone {
+	if r == nil {
+		return nil
+	}
+	return r.Zone
+}
+
 func (r *recordR) GetAnswers() AnswerSlice {
 	if r == nil {
 		return nil
@@ -105,9 +123,9 @@ func (r *recordR) GetAnswers() AnswerSlice {
 type recordL struct{}
 
 var (
-	recordAllColumns            = []string{"id", "record", "record_type", "created_at", "updated_at"}
+	recordAllColumns            = []string{"id", "record", "record_type", "created_at", "updated_at", "zone_id"}
 	recordColumnsWithoutDefault = []string{"record", "record_type", "created_at", "updated_at"}
-	recordColumnsWithDefault    = []string{"id"}
+	recordColumnsWithDefault    = []string{"id", "zone_id"}
 	recordPrimaryKeyColumns     = []string{"id"}
 	recordGeneratedColumns      = []string{}
 )
@@ -390,6 +408,17 @@ func (q recordQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (boo
 	return count > 0, nil
 }
 
+// Zone pointed to by the foreign key.
+func (o *Record) Zone(mods ...qm.QueryMod) zoneQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.ZoneID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Zones(queryMods...)
+}
+
 // Answers retrieves all the answer's Answers with an executor.
 func (o *Record) Answers(mods ...qm.QueryMod) answerQuery {
 	var queryMods []qm.QueryMod
@@ -404,6 +433,130 @@ func (o *Record) Answers(mods ...qm.QueryMod) answerQuery {
 	return Answers(queryMods...)
 }
 
+// LoadZone allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (recordL) LoadZone(ctx context.Context, e boil.ContextExecutor, singular bool, maybeRecord interface{}, mods queries.Applicator) error {
+	var slice []*Record
+	var object *Record
+
+	if singular {
+		var ok bool
+		object, ok = maybeRecord.(*Record)
+		if !ok {
+			object = new(Record)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeRecord)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeRecord))
+			}
+		}
+	} else {
+		s, ok := maybeRecord.(*[]*Record)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeRecord)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeRecord))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &recordR{}
+		}
+		if !queries.IsNil(object.ZoneID) {
+			args = append(args, object.ZoneID)
+		}
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &recordR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.ZoneID) {
+					continue Outer
+				}
+			}
+
+			if !queries.IsNil(obj.ZoneID) {
+				args = append(args, obj.ZoneID)
+			}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`zones`),
+		qm.WhereIn(`zones.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Zone")
+	}
+
+	var resultSlice []*Zone
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Zone")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for zones")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for zones")
+	}
+
+	if len(recordAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Zone = foreign
+		if foreign.R == nil {
+			foreign.R = &zoneR{}
+		}
+		foreign.R.Records = append(foreign.R.Records, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.ZoneID, foreign.ID) {
+				local.R.Zone = foreign
+				if foreign.R == nil {
+					foreign.R = &zoneR{}
+				}
+				foreign.R.Records = append(foreign.R.Records, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadAnswers allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (recordL) LoadAnswers(ctx context.Context, e boil.ContextExecutor, singular bool, maybeRecord interface{}, mods queries.Applicator) error {
@@ -518,6 +671,86 @@ func (recordL) LoadAnswers(ctx context.Context, e boil.ContextExecutor, singular
 	return nil
 }
 
+// SetZone of the record to the related item.
+// Sets o.R.Zone to related.
+// Adds o to related.R.Records.
+func (o *Record) SetZone(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Zone) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"records\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"zone_id"}),
+		strmangle.WhereClause("\"", "\"", 2, recordPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.ZoneID, related.ID)
+	if o.R == nil {
+		o.R = &recordR{
+			Zone: related,
+		}
+	} else {
+		o.R.Zone = related
+	}
+
+	if related.R == nil {
+		related.R = &zoneR{
+			Records: RecordSlice{o},
+		}
+	} else {
+		related.R.Records = append(related.R.Records, o)
+	}
+
+	return nil
+}
+
+// RemoveZone relationship.
+// Sets o.R.Zone to nil.
+// Removes o from all passed in related items' relationships struct.
+func (o *Record) RemoveZone(ctx context.Context, exec boil.ContextExecutor, related *Zone) error {
+	var err error
+
+	queries.SetScanner(&o.ZoneID, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("zone_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.Zone = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.Records {
+		if queries.Equal(o.ZoneID, ri.ZoneID) {
+			continue
+		}
+
+		ln := len(related.R.Records)
+		if ln > 1 && i < ln-1 {
+			related.R.Records[i] = related.R.Records[ln-1]
+		}
+		related.R.Records = related.R.Records[:ln-1]
+		break
+	}
+	return nil
+}
+
 // AddAnswers adds the given related objects to the existing relationships
 // of the record, optionally inserting them as new records.
 // Appends related to o.R.Answers.
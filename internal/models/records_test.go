@@ -695,6 +695,165 @@ func testRecordToManyAddOpAnswers(t *testing.T) {
 		}
 	}
 }
+func testRecordToOneZoneUsingZone(t *testing.T) {
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var local Record
+	var foreign Zone
+
+	seed := randomize.NewSeed()
+	if err := randomize.Struct(seed, &local, recordDBTypes, true, recordColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Record struct: %s", err)
+	}
+	if err := randomize.Struct(seed, &foreign, zoneDBTypes, false, zoneColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Zone struct: %s", err)
+	}
+
+	if err := foreign.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	queries.Assign(&local.ZoneID, foreign.ID)
+	if err := local.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	check, err := local.Zone().One(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !queries.Equal(check.ID, foreign.ID) {
+		t.Errorf("want: %v, got %v", foreign.ID, check.ID)
+	}
+
+	slice := RecordSlice{&local}
+	if err = local.L.LoadZone(ctx, tx, false, (*[]*Record)(&slice), nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.Zone == nil {
+		t.Error("struct should have been eager loaded")
+	}
+
+	local.R.Zone = nil
+	if err = local.L.LoadZone(ctx, tx, true, &local, nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.Zone == nil {
+		t.Error("struct should have been eager loaded")
+	}
+}
+
+func testRecordToOneSetOpZoneUsingZone(t *testing.T) {
+	var err error
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var a Record
+	var b, c Zone
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, recordDBTypes, false, strmangle.SetComplement(recordPrimaryKeyColumns, recordColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &b, zoneDBTypes, false, strmangle.SetComplement(zonePrimaryKeyColumns, zoneColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &c, zoneDBTypes, false, strmangle.SetComplement(zonePrimaryKeyColumns, zoneColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, x := range []*Zone{&b, &c} {
+		err = a.SetZone(ctx, tx, i != 0, x)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if a.R.Zone != x {
+			t.Error("relationship struct not set to correct value")
+		}
+
+		if x.R.Records[0] != &a {
+			t.Error("failed to append to foreign relationship struct")
+		}
+		if !queries.Equal(a.ZoneID, x.ID) {
+			t.Error("foreign key was wrong value", a.ZoneID)
+		}
+
+		zero := reflect.Zero(reflect.TypeOf(a.ZoneID))
+		reflect.Indirect(reflect.ValueOf(&a.ZoneID)).Set(zero)
+
+		if err = a.Reload(ctx, tx); err != nil {
+			t.Fatal("failed to reload", err)
+		}
+
+		if !queries.Equal(a.ZoneID, x.ID) {
+			t.Error("foreign key was wrong value", a.ZoneID, x.ID)
+		}
+	}
+}
+
+func testRecordToOneRemoveOpZoneUsingZone(t *testing.T) {
+	var err error
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var a Record
+	var b Zone
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, recordDBTypes, false, strmangle.SetComplement(recordPrimaryKeyColumns, recordColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &b, zoneDBTypes, false, strmangle.SetComplement(zonePrimaryKeyColumns, zoneColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = a.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = a.SetZone(ctx, tx, true, &b); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = a.RemoveZone(ctx, tx, &b); err != nil {
+		t.Error("failed to remove relationship")
+	}
+
+	count, err := a.Zone().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 0 {
+		t.Error("want no relationships remaining")
+	}
+
+	if a.R.Zone != nil {
+		t.Error("R struct entry should be nil")
+	}
+
+	if !queries.IsValuerNil(a.ZoneID) {
+		t.Error("foreign key value should be nil")
+	}
+
+	if len(b.R.Records) != 0 {
+		t.Error("failed to remove a from b's relationships")
+	}
+}
 
 func testRecordsReload(t *testing.T) {
 	t.Parallel()
@@ -770,7 +929,7 @@ func testRecordsSelect(t *testing.T) {
 }
 
 var (
-	recordDBTypes = map[string]string{`ID`: `uuid`, `Record`: `string`, `RecordType`: `string`, `CreatedAt`: `timestamptz`, `UpdatedAt`: `timestamptz`}
+	recordDBTypes = map[string]string{`ID`: `uuid`, `Record`: `string`, `RecordType`: `string`, `CreatedAt`: `timestamptz`, `UpdatedAt`: `timestamptz`, `ZoneID`: `uuid`}
 	_             = bytes.MinRead
 )
 
@@ -0,0 +1,1164 @@
+// Code generated by SQLBoiler 4.13.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// WebhookDeadLetter is an object representing the database table.
+type WebhookDeadLetter struct {
+	ID             string      `boil:"id" json:"id" toml:"id" yaml:"id"`
+	WebhookID      string      `boil:"webhook_id" json:"webhook_id" toml:"webhook_id" yaml:"webhook_id"`
+	EventID        string      `boil:"event_id" json:"event_id" toml:"event_id" yaml:"event_id"`
+	Event          string      `boil:"event" json:"event" toml:"event" yaml:"event"`
+	Payload        types.JSON  `boil:"payload" json:"payload" toml:"payload" yaml:"payload"`
+	Attempts       int64       `boil:"attempts" json:"attempts" toml:"attempts" yaml:"attempts"`
+	LastStatusCode null.Int64  `boil:"last_status_code" json:"last_status_code,omitempty" toml:"last_status_code" yaml:"last_status_code,omitempty"`
+	LastError      null.String `boil:"last_error" json:"last_error,omitempty" toml:"last_error" yaml:"last_error,omitempty"`
+	CreatedAt      time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+
+	R *webhookDeadLetterR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L webhookDeadLetterL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var WebhookDeadLetterColumns = struct {
+	ID             string
+	WebhookID      string
+	EventID        string
+	Event          string
+	Payload        string
+	Attempts       string
+	LastStatusCode string
+	LastError      string
+	CreatedAt      string
+}{
+	ID:             "id",
+	WebhookID:      "webhook_id",
+	EventID:        "event_id",
+	Event:          "event",
+	Payload:        "payload",
+	Attempts:       "attempts",
+	LastStatusCode: "last_status_code",
+	LastError:      "last_error",
+	CreatedAt:      "created_at",
+}
+
+var WebhookDeadLetterTableColumns = struct {
+	ID             string
+	WebhookID      string
+	EventID        string
+	Event          string
+	Payload        string
+	Attempts       string
+	LastStatusCode string
+	LastError      string
+	CreatedAt      string
+}{
+	ID:             "webhook_dead_letters.id",
+	WebhookID:      "webhook_dead_letters.webhook_id",
+	EventID:        "webhook_dead_letters.event_id",
+	Event:          "webhook_dead_letters.event",
+	Payload:        "webhook_dead_letters.payload",
+	Attempts:       "webhook_dead_letters.attempts",
+	LastStatusCode: "webhook_dead_letters.last_status_code",
+	LastError:      "webhook_dead_letters.last_error",
+	CreatedAt:      "webhook_dead_letters.created_at",
+}
+
+// Generated where
+
+type whereHelpertypes_JSON struct{ field string }
+
+func (w whereHelpertypes_JSON) EQ(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.EQ, x)
+}
+func (w whereHelpertypes_JSON) NEQ(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+}
+func (w whereHelpertypes_JSON) LT(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpertypes_JSON) LTE(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpertypes_JSON) GT(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpertypes_JSON) GTE(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+var WebhookDeadLetterWhere = struct {
+	ID             whereHelperstring
+	WebhookID      whereHelperstring
+	EventID        whereHelperstring
+	Event          whereHelperstring
+	Payload        whereHelpertypes_JSON
+	Attempts       whereHelperint64
+	LastStatusCode whereHelpernull_Int64
+	LastError      whereHelpernull_String
+	CreatedAt      whereHelpertime_Time
+}{
+	ID:             whereHelperstring{field: "\"webhook_dead_letters\".\"id\""},
+	WebhookID:      whereHelperstring{field: "\"webhook_dead_letters\".\"webhook_id\""},
+	EventID:        whereHelperstring{field: "\"webhook_dead_letters\".\"event_id\""},
+	Event:          whereHelperstring{field: "\"webhook_dead_letters\".\"event\""},
+	Payload:        whereHelpertypes_JSON{field: "\"webhook_dead_letters\".\"payload\""},
+	Attempts:       whereHelperint64{field: "\"webhook_dead_letters\".\"attempts\""},
+	LastStatusCode: whereHelpernull_Int64{field: "\"webhook_dead_letters\".\"last_status_code\""},
+	LastError:      whereHelpernull_String{field: "\"webhook_dead_letters\".\"last_error\""},
+	CreatedAt:      whereHelpertime_Time{field: "\"webhook_dead_letters\".\"created_at\""},
+}
+
+// WebhookDeadLetterRels is where relationship names are stored.
+var WebhookDeadLetterRels = struct {
+	Webhook string
+}{
+	Webhook: "Webhook",
+}
+
+// webhookDeadLetterR is where relationships are stored.
+type webhookDeadLetterR struct {
+	Webhook *Webhook `boil:"Webhook" json:"Webhook" toml:"Webhook" yaml:"Webhook"`
+}
+
+// NewStruct creates a new relationship struct
+func (*webhookDeadLetterR) NewStruct() *webhookDeadLetterR {
+	return &webhookDeadLetterR{}
+}
+
+func (r *webhookDeadLetterR) GetWebhook() *Webhook {
+	if r == nil {
+		return nil
+	}
+	return r.Webhook
+}
+
+// webhookDeadLetterL is where Load methods for each relationship are stored.
+type webhookDeadLetterL struct{}
+
+var (
+	webhookDeadLetterAllColumns            = []string{"id", "webhook_id", "event_id", "event", "payload", "attempts", "last_status_code", "last_error", "created_at"}
+	webhookDeadLetterColumnsWithoutDefault = []string{"id", "webhook_id", "event_id", "event", "payload", "attempts", "created_at"}
+	webhookDeadLetterColumnsWithDefault    = []string{"last_status_code", "last_error"}
+	webhookDeadLetterPrimaryKeyColumns     = []string{"id"}
+	webhookDeadLetterGeneratedColumns      = []string{}
+)
+
+type (
+	// WebhookDeadLetterSlice is an alias for a slice of pointers to WebhookDeadLetter.
+	// This should almost always be used instead of []WebhookDeadLetter.
+	WebhookDeadLetterSlice []*WebhookDeadLetter
+	// WebhookDeadLetterHook is the signature for custom WebhookDeadLetter hook methods
+	WebhookDeadLetterHook func(context.Context, boil.ContextExecutor, *WebhookDeadLetter) error
+
+	webhookDeadLetterQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	webhookDeadLetterType                 = reflect.TypeOf(&WebhookDeadLetter{})
+	webhookDeadLetterMapping              = queries.MakeStructMapping(webhookDeadLetterType)
+	webhookDeadLetterPrimaryKeyMapping, _ = queries.BindMapping(webhookDeadLetterType, webhookDeadLetterMapping, webhookDeadLetterPrimaryKeyColumns)
+	webhookDeadLetterInsertCacheMut       sync.RWMutex
+	webhookDeadLetterInsertCache          = make(map[string]insertCache)
+	webhookDeadLetterUpdateCacheMut       sync.RWMutex
+	webhookDeadLetterUpdateCache          = make(map[string]updateCache)
+	webhookDeadLetterUpsertCacheMut       sync.RWMutex
+	webhookDeadLetterUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var webhookDeadLetterAfterSelectHooks []WebhookDeadLetterHook
+
+var webhookDeadLetterBeforeInsertHooks []WebhookDeadLetterHook
+var webhookDeadLetterAfterInsertHooks []WebhookDeadLetterHook
+
+var webhookDeadLetterBeforeUpdateHooks []WebhookDeadLetterHook
+var webhookDeadLetterAfterUpdateHooks []WebhookDeadLetterHook
+
+var webhookDeadLetterBeforeDeleteHooks []WebhookDeadLetterHook
+var webhookDeadLetterAfterDeleteHooks []WebhookDeadLetterHook
+
+var webhookDeadLetterBeforeUpsertHooks []WebhookDeadLetterHook
+var webhookDeadLetterAfterUpsertHooks []WebhookDeadLetterHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *WebhookDeadLetter) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range webhookDeadLetterAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *WebhookDeadLetter) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range webhookDeadLetterBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *WebhookDeadLetter) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range webhookDeadLetterAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *WebhookDeadLetter) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range webhookDeadLetterBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *WebhookDeadLetter) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range webhookDeadLetterAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *WebhookDeadLetter) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range webhookDeadLetterBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *WebhookDeadLetter) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range webhookDeadLetterAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *WebhookDeadLetter) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range webhookDeadLetterBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *WebhookDeadLetter) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range webhookDeadLetterAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddWebhookDeadLetterHook registers your hook function for all future operations.
+func AddWebhookDeadLetterHook(hookPoint boil.HookPoint, webhookDeadLetterHook WebhookDeadLetterHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		webhookDeadLetterAfterSelectHooks = append(webhookDeadLetterAfterSelectHooks, webhookDeadLetterHook)
+	case boil.BeforeInsertHook:
+		webhookDeadLetterBeforeInsertHooks = append(webhookDeadLetterBeforeInsertHooks, webhookDeadLetterHook)
+	case boil.AfterInsertHook:
+		webhookDeadLetterAfterInsertHooks = append(webhookDeadLetterAfterInsertHooks, webhookDeadLetterHook)
+	case boil.BeforeUpdateHook:
+		webhookDeadLetterBeforeUpdateHooks = append(webhookDeadLetterBeforeUpdateHooks, webhookDeadLetterHook)
+	case boil.AfterUpdateHook:
+		webhookDeadLetterAfterUpdateHooks = append(webhookDeadLetterAfterUpdateHooks, webhookDeadLetterHook)
+	case boil.BeforeDeleteHook:
+		webhookDeadLetterBeforeDeleteHooks = append(webhookDeadLetterBeforeDeleteHooks, webhookDeadLetterHook)
+	case boil.AfterDeleteHook:
+		webhookDeadLetterAfterDeleteHooks = append(webhookDeadLetterAfterDeleteHooks, webhookDeadLetterHook)
+	case boil.BeforeUpsertHook:
+		webhookDeadLetterBeforeUpsertHooks = append(webhookDeadLetterBeforeUpsertHooks, webhookDeadLetterHook)
+	case boil.AfterUpsertHook:
+		webhookDeadLetterAfterUpsertHooks = append(webhookDeadLetterAfterUpsertHooks, webhookDeadLetterHook)
+	}
+}
+
+// One returns a single webhookDeadLetter record from the query.
+func (q webhookDeadLetterQuery) One(ctx context.Context, exec boil.ContextExecutor) (*WebhookDeadLetter, error) {
+	o := &WebhookDeadLetter{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for webhook_dead_letters")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all WebhookDeadLetter records from the query.
+func (q webhookDeadLetterQuery) All(ctx context.Context, exec boil.ContextExecutor) (WebhookDeadLetterSlice, error) {
+	var o []*WebhookDeadLetter
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to WebhookDeadLetter slice")
+	}
+
+	if len(webhookDeadLetterAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all WebhookDeadLetter records in the query.
+func (q webhookDeadLetterQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count webhook_dead_letters rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q webhookDeadLetterQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if webhook_dead_letters exists")
+	}
+
+	return count > 0, nil
+}
+
+// Webhook pointed to by the foreign key.
+func (o *WebhookDeadLetter) Webhook(mods ...qm.QueryMod) webhookQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.WebhookID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Webhooks(queryMods...)
+}
+
+// LoadWebhook allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (webhookDeadLetterL) LoadWebhook(ctx context.Context, e boil.ContextExecutor, singular bool, maybeWebhookDeadLetter interface{}, mods queries.Applicator) error {
+	var slice []*WebhookDeadLetter
+	var object *WebhookDeadLetter
+
+	if singular {
+		var ok bool
+		object, ok = maybeWebhookDeadLetter.(*WebhookDeadLetter)
+		if !ok {
+			object = new(WebhookDeadLetter)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeWebhookDeadLetter)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeWebhookDeadLetter))
+			}
+		}
+	} else {
+		s, ok := maybeWebhookDeadLetter.(*[]*WebhookDeadLetter)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeWebhookDeadLetter)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeWebhookDeadLetter))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &webhookDeadLetterR{}
+		}
+		args = append(args, object.WebhookID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &webhookDeadLetterR{}
+			}
+
+			for _, a := range args {
+				if a == obj.WebhookID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.WebhookID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`webhooks`),
+		qm.WhereIn(`webhooks.id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Webhook")
+	}
+
+	var resultSlice []*Webhook
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Webhook")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for webhooks")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for webhooks")
+	}
+
+	if len(webhookDeadLetterAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Webhook = foreign
+		if foreign.R == nil {
+			foreign.R = &webhookR{}
+		}
+		foreign.R.WebhookDeadLetters = append(foreign.R.WebhookDeadLetters, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.WebhookID == foreign.ID {
+				local.R.Webhook = foreign
+				if foreign.R == nil {
+					foreign.R = &webhookR{}
+				}
+				foreign.R.WebhookDeadLetters = append(foreign.R.WebhookDeadLetters, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetWebhook of the webhookDeadLetter to the related item.
+// Sets o.R.Webhook to related.
+// Adds o to related.R.WebhookDeadLetters.
+func (o *WebhookDeadLetter) SetWebhook(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Webhook) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"webhook_dead_letters\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"webhook_id"}),
+		strmangle.WhereClause("\"", "\"", 2, webhookDeadLetterPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.WebhookID = related.ID
+	if o.R == nil {
+		o.R = &webhookDeadLetterR{
+			Webhook: related,
+		}
+	} else {
+		o.R.Webhook = related
+	}
+
+	if related.R == nil {
+		related.R = &webhookR{
+			WebhookDeadLetters: WebhookDeadLetterSlice{o},
+		}
+	} else {
+		related.R.WebhookDeadLetters = append(related.R.WebhookDeadLetters, o)
+	}
+
+	return nil
+}
+
+// WebhookDeadLetters retrieves all the records using an executor.
+func WebhookDeadLetters(mods ...qm.QueryMod) webhookDeadLetterQuery {
+	mods = append(mods, qm.From("\"webhook_dead_letters\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"webhook_dead_letters\".*"})
+	}
+
+	return webhookDeadLetterQuery{q}
+}
+
+// FindWebhookDeadLetter retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindWebhookDeadLetter(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*WebhookDeadLetter, error) {
+	webhookDeadLetterObj := &WebhookDeadLetter{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"webhook_dead_letters\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, webhookDeadLetterObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from webhook_dead_letters")
+	}
+
+	if err = webhookDeadLetterObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return webhookDeadLetterObj, err
+	}
+
+	return webhookDeadLetterObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *WebhookDeadLetter) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no webhook_dead_letters provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(webhookDeadLetterColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	webhookDeadLetterInsertCacheMut.RLock()
+	cache, cached := webhookDeadLetterInsertCache[key]
+	webhookDeadLetterInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			webhookDeadLetterAllColumns,
+			webhookDeadLetterColumnsWithDefault,
+			webhookDeadLetterColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(webhookDeadLetterType, webhookDeadLetterMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(webhookDeadLetterType, webhookDeadLetterMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"webhook_dead_letters\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"webhook_dead_letters\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into webhook_dead_letters")
+	}
+
+	if !cached {
+		webhookDeadLetterInsertCacheMut.Lock()
+		webhookDeadLetterInsertCache[key] = cache
+		webhookDeadLetterInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the WebhookDeadLetter.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *WebhookDeadLetter) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	webhookDeadLetterUpdateCacheMut.RLock()
+	cache, cached := webhookDeadLetterUpdateCache[key]
+	webhookDeadLetterUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			webhookDeadLetterAllColumns,
+			webhookDeadLetterPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update webhook_dead_letters, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"webhook_dead_letters\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, webhookDeadLetterPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(webhookDeadLetterType, webhookDeadLetterMapping, append(wl, webhookDeadLetterPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update webhook_dead_letters row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for webhook_dead_letters")
+	}
+
+	if !cached {
+		webhookDeadLetterUpdateCacheMut.Lock()
+		webhookDeadLetterUpdateCache[key] = cache
+		webhookDeadLetterUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q webhookDeadLetterQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for webhook_dead_letters")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for webhook_dead_letters")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o WebhookDeadLetterSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), webhookDeadLetterPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"webhook_dead_letters\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, webhookDeadLetterPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in webhookDeadLetter slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all webhookDeadLetter")
+	}
+	return rowsAff, nil
+}
+
+// Delete deletes a single WebhookDeadLetter record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *WebhookDeadLetter) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no WebhookDeadLetter provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), webhookDeadLetterPrimaryKeyMapping)
+	sql := "DELETE FROM \"webhook_dead_letters\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from webhook_dead_letters")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for webhook_dead_letters")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q webhookDeadLetterQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no webhookDeadLetterQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from webhook_dead_letters")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for webhook_dead_letters")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o WebhookDeadLetterSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(webhookDeadLetterBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), webhookDeadLetterPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"webhook_dead_letters\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, webhookDeadLetterPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from webhookDeadLetter slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for webhook_dead_letters")
+	}
+
+	if len(webhookDeadLetterAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *WebhookDeadLetter) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindWebhookDeadLetter(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *WebhookDeadLetterSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := WebhookDeadLetterSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), webhookDeadLetterPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"webhook_dead_letters\".* FROM \"webhook_dead_letters\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, webhookDeadLetterPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in WebhookDeadLetterSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// WebhookDeadLetterExists checks if the WebhookDeadLetter row exists.
+func WebhookDeadLetterExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"webhook_dead_letters\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if webhook_dead_letters exists")
+	}
+
+	return exists, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *WebhookDeadLetter) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no webhook_dead_letters provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(webhookDeadLetterColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	webhookDeadLetterUpsertCacheMut.RLock()
+	cache, cached := webhookDeadLetterUpsertCache[key]
+	webhookDeadLetterUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			webhookDeadLetterAllColumns,
+			webhookDeadLetterColumnsWithDefault,
+			webhookDeadLetterColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			webhookDeadLetterAllColumns,
+			webhookDeadLetterPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert webhook_dead_letters, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(webhookDeadLetterPrimaryKeyColumns))
+			copy(conflict, webhookDeadLetterPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryCockroachDB(dialect, "\"webhook_dead_letters\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(webhookDeadLetterType, webhookDeadLetterMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(webhookDeadLetterType, webhookDeadLetterMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.DebugMode {
+		_, _ = fmt.Fprintln(boil.DebugWriter, cache.query)
+		_, _ = fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // CockcorachDB doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert webhook_dead_letters")
+	}
+
+	if !cached {
+		webhookDeadLetterUpsertCacheMut.Lock()
+		webhookDeadLetterUpsertCache[key] = cache
+		webhookDeadLetterUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
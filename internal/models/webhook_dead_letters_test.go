@@ -0,0 +1,841 @@
+// Code generated by SQLBoiler 4.13.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/randomize"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/strmangle"
+)
+
+func testWebhookDeadLettersUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(webhookDeadLetterAllColumns) == len(webhookDeadLetterPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := WebhookDeadLetter{}
+	if err = randomize.Struct(seed, &o, webhookDeadLetterDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize WebhookDeadLetter struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert WebhookDeadLetter: %s", err)
+	}
+
+	count, err := WebhookDeadLetters().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, webhookDeadLetterDBTypes, false, webhookDeadLetterPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize WebhookDeadLetter struct: %s", err)
+	}
+
+	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert WebhookDeadLetter: %s", err)
+	}
+
+	count, err = WebhookDeadLetters().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testWebhookDeadLetters(t *testing.T) {
+	t.Parallel()
+
+	query := WebhookDeadLetters()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testWebhookDeadLettersDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &WebhookDeadLetter{}
+	if err = randomize.Struct(seed, o, webhookDeadLetterDBTypes, true, webhookDeadLetterColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WebhookDeadLetter struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := WebhookDeadLetters().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testWebhookDeadLettersQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &WebhookDeadLetter{}
+	if err = randomize.Struct(seed, o, webhookDeadLetterDBTypes, true, webhookDeadLetterColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WebhookDeadLetter struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := WebhookDeadLetters().DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := WebhookDeadLetters().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testWebhookDeadLettersSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &WebhookDeadLetter{}
+	if err = randomize.Struct(seed, o, webhookDeadLetterDBTypes, true, webhookDeadLetterColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WebhookDeadLetter struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := WebhookDeadLetterSlice{o}
+
+	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := WebhookDeadLetters().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testWebhookDeadLettersExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &WebhookDeadLetter{}
+	if err = randomize.Struct(seed, o, webhookDeadLetterDBTypes, true, webhookDeadLetterColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WebhookDeadLetter struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := WebhookDeadLetterExists(ctx, tx, o.ID)
+	if err != nil {
+		t.Errorf("Unable to check if WebhookDeadLetter exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected WebhookDeadLetterExists to return true, but got false.")
+	}
+}
+
+func testWebhookDeadLettersFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &WebhookDeadLetter{}
+	if err = randomize.Struct(seed, o, webhookDeadLetterDBTypes, true, webhookDeadLetterColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WebhookDeadLetter struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	webhookDeadLetterFound, err := FindWebhookDeadLetter(ctx, tx, o.ID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if webhookDeadLetterFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testWebhookDeadLettersBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &WebhookDeadLetter{}
+	if err = randomize.Struct(seed, o, webhookDeadLetterDBTypes, true, webhookDeadLetterColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WebhookDeadLetter struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = WebhookDeadLetters().Bind(ctx, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testWebhookDeadLettersOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &WebhookDeadLetter{}
+	if err = randomize.Struct(seed, o, webhookDeadLetterDBTypes, true, webhookDeadLetterColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WebhookDeadLetter struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := WebhookDeadLetters().One(ctx, tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testWebhookDeadLettersAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	webhookDeadLetterOne := &WebhookDeadLetter{}
+	webhookDeadLetterTwo := &WebhookDeadLetter{}
+	if err = randomize.Struct(seed, webhookDeadLetterOne, webhookDeadLetterDBTypes, false, webhookDeadLetterColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WebhookDeadLetter struct: %s", err)
+	}
+	if err = randomize.Struct(seed, webhookDeadLetterTwo, webhookDeadLetterDBTypes, false, webhookDeadLetterColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WebhookDeadLetter struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = webhookDeadLetterOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = webhookDeadLetterTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := WebhookDeadLetters().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testWebhookDeadLettersCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	webhookDeadLetterOne := &WebhookDeadLetter{}
+	webhookDeadLetterTwo := &WebhookDeadLetter{}
+	if err = randomize.Struct(seed, webhookDeadLetterOne, webhookDeadLetterDBTypes, false, webhookDeadLetterColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WebhookDeadLetter struct: %s", err)
+	}
+	if err = randomize.Struct(seed, webhookDeadLetterTwo, webhookDeadLetterDBTypes, false, webhookDeadLetterColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WebhookDeadLetter struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = webhookDeadLetterOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = webhookDeadLetterTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := WebhookDeadLetters().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func webhookDeadLetterBeforeInsertHook(ctx context.Context, e boil.ContextExecutor, o *WebhookDeadLetter) error {
+	*o = WebhookDeadLetter{}
+	return nil
+}
+
+func webhookDeadLetterAfterInsertHook(ctx context.Context, e boil.ContextExecutor, o *WebhookDeadLetter) error {
+	*o = WebhookDeadLetter{}
+	return nil
+}
+
+func webhookDeadLetterAfterSelectHook(ctx context.Context, e boil.ContextExecutor, o *WebhookDeadLetter) error {
+	*o = WebhookDeadLetter{}
+	return nil
+}
+
+func webhookDeadLetterBeforeUpdateHook(ctx context.Context, e boil.ContextExecutor, o *WebhookDeadLetter) error {
+	*o = WebhookDeadLetter{}
+	return nil
+}
+
+func webhookDeadLetterAfterUpdateHook(ctx context.Context, e boil.ContextExecutor, o *WebhookDeadLetter) error {
+	*o = WebhookDeadLetter{}
+	return nil
+}
+
+func webhookDeadLetterBeforeDeleteHook(ctx context.Context, e boil.ContextExecutor, o *WebhookDeadLetter) error {
+	*o = WebhookDeadLetter{}
+	return nil
+}
+
+func webhookDeadLetterAfterDeleteHook(ctx context.Context, e boil.ContextExecutor, o *WebhookDeadLetter) error {
+	*o = WebhookDeadLetter{}
+	return nil
+}
+
+func webhookDeadLetterBeforeUpsertHook(ctx context.Context, e boil.ContextExecutor, o *WebhookDeadLetter) error {
+	*o = WebhookDeadLetter{}
+	return nil
+}
+
+func webhookDeadLetterAfterUpsertHook(ctx context.Context, e boil.ContextExecutor, o *WebhookDeadLetter) error {
+	*o = WebhookDeadLetter{}
+	return nil
+}
+
+func testWebhookDeadLettersHooks(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	ctx := context.Background()
+	empty := &WebhookDeadLetter{}
+	o := &WebhookDeadLetter{}
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, o, webhookDeadLetterDBTypes, false); err != nil {
+		t.Errorf("Unable to randomize WebhookDeadLetter object: %s", err)
+	}
+
+	AddWebhookDeadLetterHook(boil.BeforeInsertHook, webhookDeadLetterBeforeInsertHook)
+	if err = o.doBeforeInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
+	}
+	webhookDeadLetterBeforeInsertHooks = []WebhookDeadLetterHook{}
+
+	AddWebhookDeadLetterHook(boil.AfterInsertHook, webhookDeadLetterAfterInsertHook)
+	if err = o.doAfterInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
+	}
+	webhookDeadLetterAfterInsertHooks = []WebhookDeadLetterHook{}
+
+	AddWebhookDeadLetterHook(boil.AfterSelectHook, webhookDeadLetterAfterSelectHook)
+	if err = o.doAfterSelectHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
+	}
+	webhookDeadLetterAfterSelectHooks = []WebhookDeadLetterHook{}
+
+	AddWebhookDeadLetterHook(boil.BeforeUpdateHook, webhookDeadLetterBeforeUpdateHook)
+	if err = o.doBeforeUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
+	}
+	webhookDeadLetterBeforeUpdateHooks = []WebhookDeadLetterHook{}
+
+	AddWebhookDeadLetterHook(boil.AfterUpdateHook, webhookDeadLetterAfterUpdateHook)
+	if err = o.doAfterUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
+	}
+	webhookDeadLetterAfterUpdateHooks = []WebhookDeadLetterHook{}
+
+	AddWebhookDeadLetterHook(boil.BeforeDeleteHook, webhookDeadLetterBeforeDeleteHook)
+	if err = o.doBeforeDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
+	}
+	webhookDeadLetterBeforeDeleteHooks = []WebhookDeadLetterHook{}
+
+	AddWebhookDeadLetterHook(boil.AfterDeleteHook, webhookDeadLetterAfterDeleteHook)
+	if err = o.doAfterDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
+	}
+	webhookDeadLetterAfterDeleteHooks = []WebhookDeadLetterHook{}
+
+	AddWebhookDeadLetterHook(boil.BeforeUpsertHook, webhookDeadLetterBeforeUpsertHook)
+	if err = o.doBeforeUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
+	}
+	webhookDeadLetterBeforeUpsertHooks = []WebhookDeadLetterHook{}
+
+	AddWebhookDeadLetterHook(boil.AfterUpsertHook, webhookDeadLetterAfterUpsertHook)
+	if err = o.doAfterUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
+	}
+	webhookDeadLetterAfterUpsertHooks = []WebhookDeadLetterHook{}
+}
+
+func testWebhookDeadLettersInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &WebhookDeadLetter{}
+	if err = randomize.Struct(seed, o, webhookDeadLetterDBTypes, true, webhookDeadLetterColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WebhookDeadLetter struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := WebhookDeadLetters().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testWebhookDeadLettersInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &WebhookDeadLetter{}
+	if err = randomize.Struct(seed, o, webhookDeadLetterDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize WebhookDeadLetter struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Whitelist(webhookDeadLetterColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := WebhookDeadLetters().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testWebhookDeadLetterToOneWebhookUsingWebhook(t *testing.T) {
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var local WebhookDeadLetter
+	var foreign Webhook
+
+	seed := randomize.NewSeed()
+	if err := randomize.Struct(seed, &local, webhookDeadLetterDBTypes, false, webhookDeadLetterColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WebhookDeadLetter struct: %s", err)
+	}
+	if err := randomize.Struct(seed, &foreign, webhookDBTypes, false, webhookColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Webhook struct: %s", err)
+	}
+
+	if err := foreign.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	local.WebhookID = foreign.ID
+	if err := local.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	check, err := local.Webhook().One(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if check.ID != foreign.ID {
+		t.Errorf("want: %v, got %v", foreign.ID, check.ID)
+	}
+
+	slice := WebhookDeadLetterSlice{&local}
+	if err = local.L.LoadWebhook(ctx, tx, false, (*[]*WebhookDeadLetter)(&slice), nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.Webhook == nil {
+		t.Error("struct should have been eager loaded")
+	}
+
+	local.R.Webhook = nil
+	if err = local.L.LoadWebhook(ctx, tx, true, &local, nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.Webhook == nil {
+		t.Error("struct should have been eager loaded")
+	}
+}
+
+func testWebhookDeadLetterToOneSetOpWebhookUsingWebhook(t *testing.T) {
+	var err error
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var a WebhookDeadLetter
+	var b, c Webhook
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, webhookDeadLetterDBTypes, false, strmangle.SetComplement(webhookDeadLetterPrimaryKeyColumns, webhookDeadLetterColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &b, webhookDBTypes, false, strmangle.SetComplement(webhookPrimaryKeyColumns, webhookColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &c, webhookDBTypes, false, strmangle.SetComplement(webhookPrimaryKeyColumns, webhookColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, x := range []*Webhook{&b, &c} {
+		err = a.SetWebhook(ctx, tx, i != 0, x)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if a.R.Webhook != x {
+			t.Error("relationship struct not set to correct value")
+		}
+
+		if x.R.WebhookDeadLetters[0] != &a {
+			t.Error("failed to append to foreign relationship struct")
+		}
+		if a.WebhookID != x.ID {
+			t.Error("foreign key was wrong value", a.WebhookID)
+		}
+
+		zero := reflect.Zero(reflect.TypeOf(a.WebhookID))
+		reflect.Indirect(reflect.ValueOf(&a.WebhookID)).Set(zero)
+
+		if err = a.Reload(ctx, tx); err != nil {
+			t.Fatal("failed to reload", err)
+		}
+
+		if a.WebhookID != x.ID {
+			t.Error("foreign key was wrong value", a.WebhookID, x.ID)
+		}
+	}
+}
+
+func testWebhookDeadLettersReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &WebhookDeadLetter{}
+	if err = randomize.Struct(seed, o, webhookDeadLetterDBTypes, true, webhookDeadLetterColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WebhookDeadLetter struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testWebhookDeadLettersReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &WebhookDeadLetter{}
+	if err = randomize.Struct(seed, o, webhookDeadLetterDBTypes, true, webhookDeadLetterColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WebhookDeadLetter struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := WebhookDeadLetterSlice{o}
+
+	if err = slice.ReloadAll(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testWebhookDeadLettersSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &WebhookDeadLetter{}
+	if err = randomize.Struct(seed, o, webhookDeadLetterDBTypes, true, webhookDeadLetterColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WebhookDeadLetter struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := WebhookDeadLetters().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	webhookDeadLetterDBTypes = map[string]string{`ID`: `uuid`, `WebhookID`: `uuid`, `EventID`: `uuid`, `Event`: `string`, `Payload`: `jsonb`, `Attempts`: `int8`, `LastStatusCode`: `int8`, `LastError`: `string`, `CreatedAt`: `timestamptz`}
+	_                        = bytes.MinRead
+)
+
+func testWebhookDeadLettersUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(webhookDeadLetterPrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(webhookDeadLetterAllColumns) == len(webhookDeadLetterPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &WebhookDeadLetter{}
+	if err = randomize.Struct(seed, o, webhookDeadLetterDBTypes, true, webhookDeadLetterColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WebhookDeadLetter struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := WebhookDeadLetters().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, webhookDeadLetterDBTypes, true, webhookDeadLetterPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize WebhookDeadLetter struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testWebhookDeadLettersSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(webhookDeadLetterAllColumns) == len(webhookDeadLetterPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &WebhookDeadLetter{}
+	if err = randomize.Struct(seed, o, webhookDeadLetterDBTypes, true, webhookDeadLetterColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize WebhookDeadLetter struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := WebhookDeadLetters().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, webhookDeadLetterDBTypes, true, webhookDeadLetterPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize WebhookDeadLetter struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(webhookDeadLetterAllColumns, webhookDeadLetterPrimaryKeyColumns) {
+		fields = webhookDeadLetterAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			webhookDeadLetterAllColumns,
+			webhookDeadLetterPrimaryKeyColumns,
+		)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := WebhookDeadLetterSlice{o}
+	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
@@ -0,0 +1,1060 @@
+// Code generated by SQLBoiler 4.13.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/randomize"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/strmangle"
+)
+
+func testZonesUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(zoneAllColumns) == len(zonePrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := Zone{}
+	if err = randomize.Struct(seed, &o, zoneDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize Zone struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert Zone: %s", err)
+	}
+
+	count, err := Zones().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, zoneDBTypes, false, zonePrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize Zone struct: %s", err)
+	}
+
+	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert Zone: %s", err)
+	}
+
+	count, err = Zones().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testZones(t *testing.T) {
+	t.Parallel()
+
+	query := Zones()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testZonesDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Zone{}
+	if err = randomize.Struct(seed, o, zoneDBTypes, true, zoneColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Zone struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := Zones().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testZonesQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Zone{}
+	if err = randomize.Struct(seed, o, zoneDBTypes, true, zoneColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Zone struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := Zones().DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := Zones().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testZonesSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Zone{}
+	if err = randomize.Struct(seed, o, zoneDBTypes, true, zoneColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Zone struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := ZoneSlice{o}
+
+	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := Zones().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testZonesExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Zone{}
+	if err = randomize.Struct(seed, o, zoneDBTypes, true, zoneColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Zone struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := ZoneExists(ctx, tx, o.ID)
+	if err != nil {
+		t.Errorf("Unable to check if Zone exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected ZoneExists to return true, but got false.")
+	}
+}
+
+func testZonesFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Zone{}
+	if err = randomize.Struct(seed, o, zoneDBTypes, true, zoneColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Zone struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	zoneFound, err := FindZone(ctx, tx, o.ID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if zoneFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testZonesBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Zone{}
+	if err = randomize.Struct(seed, o, zoneDBTypes, true, zoneColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Zone struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = Zones().Bind(ctx, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testZonesOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Zone{}
+	if err = randomize.Struct(seed, o, zoneDBTypes, true, zoneColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Zone struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := Zones().One(ctx, tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testZonesAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	zoneOne := &Zone{}
+	zoneTwo := &Zone{}
+	if err = randomize.Struct(seed, zoneOne, zoneDBTypes, false, zoneColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Zone struct: %s", err)
+	}
+	if err = randomize.Struct(seed, zoneTwo, zoneDBTypes, false, zoneColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Zone struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = zoneOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = zoneTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := Zones().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testZonesCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	zoneOne := &Zone{}
+	zoneTwo := &Zone{}
+	if err = randomize.Struct(seed, zoneOne, zoneDBTypes, false, zoneColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Zone struct: %s", err)
+	}
+	if err = randomize.Struct(seed, zoneTwo, zoneDBTypes, false, zoneColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Zone struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = zoneOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = zoneTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Zones().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func zoneBeforeInsertHook(ctx context.Context, e boil.ContextExecutor, o *Zone) error {
+	*o = Zone{}
+	return nil
+}
+
+func zoneAfterInsertHook(ctx context.Context, e boil.ContextExecutor, o *Zone) error {
+	*o = Zone{}
+	return nil
+}
+
+func zoneAfterSelectHook(ctx context.Context, e boil.ContextExecutor, o *Zone) error {
+	*o = Zone{}
+	return nil
+}
+
+func zoneBeforeUpdateHook(ctx context.Context, e boil.ContextExecutor, o *Zone) error {
+	*o = Zone{}
+	return nil
+}
+
+func zoneAfterUpdateHook(ctx context.Context, e boil.ContextExecutor, o *Zone) error {
+	*o = Zone{}
+	return nil
+}
+
+func zoneBeforeDeleteHook(ctx context.Context, e boil.ContextExecutor, o *Zone) error {
+	*o = Zone{}
+	return nil
+}
+
+func zoneAfterDeleteHook(ctx context.Context, e boil.ContextExecutor, o *Zone) error {
+	*o = Zone{}
+	return nil
+}
+
+func zoneBeforeUpsertHook(ctx context.Context, e boil.ContextExecutor, o *Zone) error {
+	*o = Zone{}
+	return nil
+}
+
+func zoneAfterUpsertHook(ctx context.Context, e boil.ContextExecutor, o *Zone) error {
+	*o = Zone{}
+	return nil
+}
+
+func testZonesHooks(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	ctx := context.Background()
+	empty := &Zone{}
+	o := &Zone{}
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, o, zoneDBTypes, false); err != nil {
+		t.Errorf("Unable to randomize Zone object: %s", err)
+	}
+
+	AddZoneHook(boil.BeforeInsertHook, zoneBeforeInsertHook)
+	if err = o.doBeforeInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
+	}
+	zoneBeforeInsertHooks = []ZoneHook{}
+
+	AddZoneHook(boil.AfterInsertHook, zoneAfterInsertHook)
+	if err = o.doAfterInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
+	}
+	zoneAfterInsertHooks = []ZoneHook{}
+
+	AddZoneHook(boil.AfterSelectHook, zoneAfterSelectHook)
+	if err = o.doAfterSelectHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
+	}
+	zoneAfterSelectHooks = []ZoneHook{}
+
+	AddZoneHook(boil.BeforeUpdateHook, zoneBeforeUpdateHook)
+	if err = o.doBeforeUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
+	}
+	zoneBeforeUpdateHooks = []ZoneHook{}
+
+	AddZoneHook(boil.AfterUpdateHook, zoneAfterUpdateHook)
+	if err = o.doAfterUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
+	}
+	zoneAfterUpdateHooks = []ZoneHook{}
+
+	AddZoneHook(boil.BeforeDeleteHook, zoneBeforeDeleteHook)
+	if err = o.doBeforeDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
+	}
+	zoneBeforeDeleteHooks = []ZoneHook{}
+
+	AddZoneHook(boil.AfterDeleteHook, zoneAfterDeleteHook)
+	if err = o.doAfterDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
+	}
+	zoneAfterDeleteHooks = []ZoneHook{}
+
+	AddZoneHook(boil.BeforeUpsertHook, zoneBeforeUpsertHook)
+	if err = o.doBeforeUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
+	}
+	zoneBeforeUpsertHooks = []ZoneHook{}
+
+	AddZoneHook(boil.AfterUpsertHook, zoneAfterUpsertHook)
+	if err = o.doAfterUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
+	}
+	zoneAfterUpsertHooks = []ZoneHook{}
+}
+
+func testZonesInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Zone{}
+	if err = randomize.Struct(seed, o, zoneDBTypes, true, zoneColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Zone struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Zones().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testZonesInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Zone{}
+	if err = randomize.Struct(seed, o, zoneDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize Zone struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Whitelist(zoneColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Zones().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testZoneToManyRecords(t *testing.T) {
+	var err error
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var a Zone
+	var b, c Record
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, zoneDBTypes, true, zoneColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Zone struct: %s", err)
+	}
+
+	if err := a.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = randomize.Struct(seed, &b, recordDBTypes, false, recordColumnsWithDefault...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &c, recordDBTypes, false, recordColumnsWithDefault...); err != nil {
+		t.Fatal(err)
+	}
+
+	queries.Assign(&b.ZoneID, a.ID)
+	queries.Assign(&c.ZoneID, a.ID)
+	if err = b.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	check, err := a.Records().All(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bFound, cFound := false, false
+	for _, v := range check {
+		if queries.Equal(v.ZoneID, b.ZoneID) {
+			bFound = true
+		}
+		if queries.Equal(v.ZoneID, c.ZoneID) {
+			cFound = true
+		}
+	}
+
+	if !bFound {
+		t.Error("expected to find b")
+	}
+	if !cFound {
+		t.Error("expected to find c")
+	}
+
+	slice := ZoneSlice{&a}
+	if err = a.L.LoadRecords(ctx, tx, false, (*[]*Zone)(&slice), nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(a.R.Records); got != 2 {
+		t.Error("number of eager loaded records wrong, got:", got)
+	}
+
+	a.R.Records = nil
+	if err = a.L.LoadRecords(ctx, tx, true, &a, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(a.R.Records); got != 2 {
+		t.Error("number of eager loaded records wrong, got:", got)
+	}
+
+	if t.Failed() {
+		t.Logf("%#v", check)
+	}
+}
+
+func testZoneToManyAddOpRecords(t *testing.T) {
+	var err error
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var a Zone
+	var b, c, d, e Record
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, zoneDBTypes, false, strmangle.SetComplement(zonePrimaryKeyColumns, zoneColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	foreigners := []*Record{&b, &c, &d, &e}
+	for _, x := range foreigners {
+		if err = randomize.Struct(seed, x, recordDBTypes, false, strmangle.SetComplement(recordPrimaryKeyColumns, recordColumnsWithoutDefault)...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := a.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	foreignersSplitByInsertion := [][]*Record{
+		{&b, &c},
+		{&d, &e},
+	}
+
+	for i, x := range foreignersSplitByInsertion {
+		err = a.AddRecords(ctx, tx, i != 0, x...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		first := x[0]
+		second := x[1]
+
+		if !queries.Equal(a.ID, first.ZoneID) {
+			t.Error("foreign key was wrong value", a.ID, first.ZoneID)
+		}
+		if !queries.Equal(a.ID, second.ZoneID) {
+			t.Error("foreign key was wrong value", a.ID, second.ZoneID)
+		}
+
+		if first.R.Zone != &a {
+			t.Error("relationship was not added properly to the foreign slice")
+		}
+		if second.R.Zone != &a {
+			t.Error("relationship was not added properly to the foreign slice")
+		}
+
+		if a.R.Records[i*2] != first {
+			t.Error("relationship struct slice not set to correct value")
+		}
+		if a.R.Records[i*2+1] != second {
+			t.Error("relationship struct slice not set to correct value")
+		}
+
+		count, err := a.Records().Count(ctx, tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := int64((i + 1) * 2); count != want {
+			t.Error("want", want, "got", count)
+		}
+	}
+}
+
+func testZoneToManySetOpRecords(t *testing.T) {
+	var err error
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var a Zone
+	var b, c, d, e Record
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, zoneDBTypes, false, strmangle.SetComplement(zonePrimaryKeyColumns, zoneColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	foreigners := []*Record{&b, &c, &d, &e}
+	for _, x := range foreigners {
+		if err = randomize.Struct(seed, x, recordDBTypes, false, strmangle.SetComplement(recordPrimaryKeyColumns, recordColumnsWithoutDefault)...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = a.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	err = a.SetRecords(ctx, tx, false, &b, &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := a.Records().Count(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Error("count was wrong:", count)
+	}
+
+	err = a.SetRecords(ctx, tx, true, &d, &e)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count, err = a.Records().Count(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Error("count was wrong:", count)
+	}
+
+	if !queries.IsValuerNil(b.ZoneID) {
+		t.Error("want b's foreign key value to be nil")
+	}
+	if !queries.IsValuerNil(c.ZoneID) {
+		t.Error("want c's foreign key value to be nil")
+	}
+	if !queries.Equal(a.ID, d.ZoneID) {
+		t.Error("foreign key was wrong value", a.ID, d.ZoneID)
+	}
+	if !queries.Equal(a.ID, e.ZoneID) {
+		t.Error("foreign key was wrong value", a.ID, e.ZoneID)
+	}
+
+	if b.R.Zone != nil {
+		t.Error("relationship was not removed properly from the foreign struct")
+	}
+	if c.R.Zone != nil {
+		t.Error("relationship was not removed properly from the foreign struct")
+	}
+	if d.R.Zone != &a {
+		t.Error("relationship was not added properly to the foreign struct")
+	}
+	if e.R.Zone != &a {
+		t.Error("relationship was not added properly to the foreign struct")
+	}
+
+	if a.R.Records[0] != &d {
+		t.Error("relationship struct slice not set to correct value")
+	}
+	if a.R.Records[1] != &e {
+		t.Error("relationship struct slice not set to correct value")
+	}
+}
+
+func testZoneToManyRemoveOpRecords(t *testing.T) {
+	var err error
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var a Zone
+	var b, c, d, e Record
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, zoneDBTypes, false, strmangle.SetComplement(zonePrimaryKeyColumns, zoneColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	foreigners := []*Record{&b, &c, &d, &e}
+	for _, x := range foreigners {
+		if err = randomize.Struct(seed, x, recordDBTypes, false, strmangle.SetComplement(recordPrimaryKeyColumns, recordColumnsWithoutDefault)...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := a.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	err = a.AddRecords(ctx, tx, true, foreigners...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := a.Records().Count(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 4 {
+		t.Error("count was wrong:", count)
+	}
+
+	err = a.RemoveRecords(ctx, tx, foreigners[:2]...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count, err = a.Records().Count(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Error("count was wrong:", count)
+	}
+
+	if !queries.IsValuerNil(b.ZoneID) {
+		t.Error("want b's foreign key value to be nil")
+	}
+	if !queries.IsValuerNil(c.ZoneID) {
+		t.Error("want c's foreign key value to be nil")
+	}
+
+	if b.R.Zone != nil {
+		t.Error("relationship was not removed properly from the foreign struct")
+	}
+	if c.R.Zone != nil {
+		t.Error("relationship was not removed properly from the foreign struct")
+	}
+	if d.R.Zone != &a {
+		t.Error("relationship to a should have been preserved")
+	}
+	if e.R.Zone != &a {
+		t.Error("relationship to a should have been preserved")
+	}
+
+	if len(a.R.Records) != 2 {
+		t.Error("should have preserved two relationships")
+	}
+
+	// Removal doesn't do a stable deletion for performance so we have to flip the order
+	if a.R.Records[1] != &d {
+		t.Error("relationship to d should have been preserved")
+	}
+	if a.R.Records[0] != &e {
+		t.Error("relationship to e should have been preserved")
+	}
+}
+
+func testZonesReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Zone{}
+	if err = randomize.Struct(seed, o, zoneDBTypes, true, zoneColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Zone struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testZonesReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Zone{}
+	if err = randomize.Struct(seed, o, zoneDBTypes, true, zoneColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Zone struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := ZoneSlice{o}
+
+	if err = slice.ReloadAll(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testZonesSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Zone{}
+	if err = randomize.Struct(seed, o, zoneDBTypes, true, zoneColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Zone struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := Zones().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	zoneDBTypes = map[string]string{`ID`: `uuid`, `Name`: `string`, `AutoCreated`: `bool`, `EmptySince`: `timestamptz`, `CreatedAt`: `timestamptz`, `UpdatedAt`: `timestamptz`, `Serial`: `int8`}
+	_           = bytes.MinRead
+)
+
+func testZonesUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(zonePrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(zoneAllColumns) == len(zonePrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Zone{}
+	if err = randomize.Struct(seed, o, zoneDBTypes, true, zoneColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Zone struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Zones().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, zoneDBTypes, true, zonePrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize Zone struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testZonesSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(zoneAllColumns) == len(zonePrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &Zone{}
+	if err = randomize.Struct(seed, o, zoneDBTypes, true, zoneColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Zone struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := Zones().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, zoneDBTypes, true, zonePrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize Zone struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(zoneAllColumns, zonePrimaryKeyColumns) {
+		fields = zoneAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			zoneAllColumns,
+			zonePrimaryKeyColumns,
+		)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := ZoneSlice{o}
+	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
@@ -0,0 +1,219 @@
+// Package notifier sends the changes to records and answers to the webhooks
+// subscribed to them. The changes are read from the audit log after the
+// version each webhook was last queued up to, so every committed change is
+// queued once per webhook. Queued deliveries are retried with exponential
+// backoff until they succeed, or run out of attempts and are dead-lettered.
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/cockroachdb/cockroach-go/v2/crdb/crdbsqlx"
+	"github.com/jmoiron/sqlx"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"go.uber.org/zap"
+
+	"go.hollow.sh/dnscontroller/internal/models"
+	"go.hollow.sh/dnscontroller/pkg/api/v1/watch"
+	webhook "go.hollow.sh/dnscontroller/pkg/api/v1/webhooks"
+)
+
+// maxDeliveries is the number of due deliveries attempted per run
+const maxDeliveries = 100
+
+// Notifier queues and sends the deliveries of every webhook
+type Notifier struct {
+	Logger   *zap.SugaredLogger
+	DB       *sqlx.DB
+	Interval time.Duration
+	// Timeout limits each attempt
+	Timeout time.Duration
+	// MaxAttempts is how many times a delivery is attempted before it is
+	// dead-lettered
+	MaxAttempts int64
+	// Backoff is the wait before the first retry, it doubles for each retry
+	// after that up to MaxBackoff
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	HTTPClient *http.Client
+}
+
+// Run notifies every interval until the context is done
+func (n *Notifier) Run(ctx context.Context) error {
+	ticker := time.NewTicker(n.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := n.Notify(ctx); err != nil {
+			n.Logger.Errorw("failed notifying webhooks", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Notify queues the new changes of every webhook, then attempts the
+// deliveries that are due
+func (n *Notifier) Notify(ctx context.Context) error {
+	if err := n.Enqueue(ctx); err != nil {
+		return err
+	}
+
+	return n.Deliver(ctx)
+}
+
+// Enqueue queues a delivery of every change committed since the last run to
+// the webhooks it matches, a webhook that fails is logged and doesn't stop
+// the others
+func (n *Notifier) Enqueue(ctx context.Context) error {
+	dbWebhooks, err := models.Webhooks().All(ctx, n.DB)
+	if err != nil {
+		return err
+	}
+
+	for _, dbWebhook := range dbWebhooks {
+		if err := n.enqueue(ctx, dbWebhook); err != nil {
+			n.Logger.Errorw("failed queueing webhook deliveries", "webhook", dbWebhook.ID, "error", err)
+		}
+	}
+
+	return nil
+}
+
+func (n *Notifier) enqueue(ctx context.Context, dbWebhook *models.Webhook) error {
+	w := &webhook.Webhook{}
+	if err := w.FromDBModel(dbWebhook); err != nil {
+		return err
+	}
+
+	p, err := w.WatchParams(dbWebhook.Version)
+	if err != nil {
+		return err
+	}
+
+	events, err := watch.Next(ctx, n.DB, p)
+	if err != nil || len(events) == 0 {
+		return err
+	}
+
+	return crdbsqlx.ExecuteTx(ctx, n.DB, nil, func(tx *sqlx.Tx) error {
+		// Another notifier queued the changes when the version moved
+		res, err := tx.ExecContext(ctx, "UPDATE webhooks SET version = $1 WHERE id = $2 AND version = $3",
+			p.ResourceVersion(), dbWebhook.ID, dbWebhook.Version)
+		if err != nil {
+			return err
+		}
+
+		if moved, err := res.RowsAffected(); err != nil || moved == 0 {
+			return err
+		}
+
+		now := time.Now()
+
+		for _, e := range events {
+			if !w.Matches(e) {
+				continue
+			}
+
+			payload, err := json.Marshal(e)
+			if err != nil {
+				return err
+			}
+
+			dbDelivery := &models.WebhookDelivery{
+				WebhookID:     dbWebhook.ID,
+				EventID:       e.UUID.String(),
+				Event:         e.Name(),
+				Payload:       payload,
+				Status:        webhook.StatusPending,
+				NextAttemptAt: null.TimeFrom(now),
+			}
+
+			if err := dbDelivery.Insert(ctx, tx, boil.Infer()); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Deliver attempts the deliveries that are due once each, oldest first. A
+// delivery that fails to be stored is logged and doesn't stop the others.
+func (n *Notifier) Deliver(ctx context.Context) error {
+	due, err := models.WebhookDeliveries(
+		models.WebhookDeliveryWhere.Status.EQ(webhook.StatusPending),
+		qm.Where("next_attempt_at <= ?", time.Now()),
+		qm.Load(models.WebhookDeliveryRels.Webhook),
+		qm.OrderBy("next_attempt_at, created_at"),
+		qm.Limit(maxDeliveries),
+	).All(ctx, n.DB)
+	if err != nil {
+		return err
+	}
+
+	for _, dbDelivery := range due {
+		if err := n.deliver(ctx, dbDelivery); err != nil {
+			n.Logger.Errorw("failed storing webhook delivery", "delivery", dbDelivery.ID, "error", err)
+		}
+	}
+
+	return nil
+}
+
+func (n *Notifier) deliver(ctx context.Context, dbDelivery *models.WebhookDelivery) error {
+	// Claim the attempt until it times out, a delivery another notifier
+	// claimed has moved on from its next attempt
+	res, err := n.DB.ExecContext(ctx,
+		"UPDATE webhook_deliveries SET next_attempt_at = $1 WHERE id = $2 AND status = $3 AND next_attempt_at = $4",
+		time.Now().Add(n.Timeout), dbDelivery.ID, webhook.StatusPending, dbDelivery.NextAttemptAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	if claimed, err := res.RowsAffected(); err != nil || claimed == 0 {
+		return err
+	}
+
+	w := dbDelivery.R.Webhook
+	status, err := n.send(ctx, w.URL, w.Secret, dbDelivery)
+
+	dbDelivery.Attempts++
+	dbDelivery.LastStatusCode = null.NewInt64(int64(status), status != 0)
+	dbDelivery.LastError = null.String{}
+	dbDelivery.NextAttemptAt = null.Time{}
+
+	switch {
+	case err == nil:
+		dbDelivery.Status = webhook.StatusDelivered
+		dbDelivery.DeliveredAt = null.TimeFrom(time.Now())
+	case dbDelivery.Attempts >= n.MaxAttempts:
+		dbDelivery.Status = webhook.StatusDead
+		dbDelivery.LastError = null.StringFrom(err.Error())
+	default:
+		dbDelivery.LastError = null.StringFrom(err.Error())
+		dbDelivery.NextAttemptAt = null.TimeFrom(time.Now().Add(n.backoff(dbDelivery.Attempts)))
+	}
+
+	return crdbsqlx.ExecuteTx(ctx, n.DB, nil, func(tx *sqlx.Tx) error {
+		if _, err := dbDelivery.Update(ctx, tx, boil.Infer()); err != nil {
+			return err
+		}
+
+		if dbDelivery.Status != webhook.StatusDead {
+			return nil
+		}
+
+		return webhook.DeadLetter(dbDelivery).Insert(ctx, tx, boil.Infer())
+	})
+}
@@ -0,0 +1,75 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"go.hollow.sh/dnscontroller/internal/models"
+	webhook "go.hollow.sh/dnscontroller/pkg/api/v1/webhooks"
+)
+
+// ErrorDeliveryStatus is when a webhook answers a delivery with a status
+// outside of 2xx, the delivery is retried
+var ErrorDeliveryStatus = errors.New("webhook returned a failed status")
+
+// maxResponseBody is how much of a response is read, so the connection can be
+// reused
+const maxResponseBody = 64 << 10
+
+// send POSTs the payload of the delivery to the url signed with the secret,
+// and returns the status of the response
+func (n *Notifier) send(ctx context.Context, url, secret string, dbDelivery *models.WebhookDelivery) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, n.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(dbDelivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhook.SignatureHeader, webhook.Sign(secret, dbDelivery.Payload))
+	req.Header.Set(webhook.EventHeader, dbDelivery.Event)
+	req.Header.Set(webhook.DeliveryHeader, dbDelivery.ID)
+
+	client := n.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBody))
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, fmt.Errorf("%w: %s", ErrorDeliveryStatus, resp.Status)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// backoff returns the wait before the next attempt of a delivery attempted
+// the given number of times
+func (n *Notifier) backoff(attempts int64) time.Duration {
+	wait := n.Backoff
+
+	for i := int64(1); i < attempts && wait < n.MaxBackoff; i++ {
+		wait *= 2
+	}
+
+	if wait > n.MaxBackoff {
+		wait = n.MaxBackoff
+	}
+
+	return wait
+}
@@ -0,0 +1,87 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.hollow.sh/dnscontroller/internal/models"
+	webhook "go.hollow.sh/dnscontroller/pkg/api/v1/webhooks"
+)
+
+func TestSend(t *testing.T) {
+	const secret = "s3cret"
+
+	dbDelivery := &models.WebhookDelivery{
+		ID:      "00000000-0000-0000-0000-000000000001",
+		Event:   "answer.create",
+		Payload: []byte(`{"kind":"answer","type":"create","record":"www.example.com."}`),
+	}
+
+	tests := []struct {
+		name    string
+		status  int
+		wantErr error
+	}{
+		{name: "delivered", status: http.StatusNoContent},
+		{name: "failed", status: http.StatusServiceUnavailable, wantErr: ErrorDeliveryStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+
+				if !webhook.Verify(secret, body, r.Header.Get(webhook.SignatureHeader)) {
+					t.Errorf("signature %q doesn't verify", r.Header.Get(webhook.SignatureHeader))
+				}
+
+				if r.Header.Get(webhook.EventHeader) != dbDelivery.Event || r.Header.Get(webhook.DeliveryHeader) != dbDelivery.ID {
+					t.Errorf("unexpected headers %v", r.Header)
+				}
+
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			n := &Notifier{Timeout: time.Second}
+
+			status, err := n.send(context.Background(), srv.URL, secret, dbDelivery)
+			if status != tt.status || !errors.Is(err, tt.wantErr) {
+				t.Errorf("send() = %d, %v, want %d, %v", status, err, tt.status, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{}`)
+
+	if webhook.Verify("other", body, webhook.Sign("s3cret", body)) {
+		t.Error("Verify() = true for another secret")
+	}
+
+	if webhook.Verify("s3cret", []byte(`{"a":1}`), webhook.Sign("s3cret", body)) {
+		t.Error("Verify() = true for another body")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	n := &Notifier{Backoff: 10 * time.Second, MaxBackoff: time.Minute}
+
+	for attempts, want := range map[int64]time.Duration{
+		1: 10 * time.Second,
+		2: 20 * time.Second,
+		3: 40 * time.Second,
+		4: time.Minute,
+		9: time.Minute,
+	} {
+		if got := n.backoff(attempts); got != want {
+			t.Errorf("backoff(%d) = %s, want %s", attempts, got, want)
+		}
+	}
+}
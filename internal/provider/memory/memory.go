@@ -0,0 +1,99 @@
+// Package memory is an in-memory provider, it backs the reconciler tests and
+// dry runs against a scratch zone
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/miekg/dns"
+
+	"go.hollow.sh/dnscontroller/internal/provider"
+)
+
+// Provider keeps its zones in memory
+type Provider struct {
+	mu    sync.Mutex
+	zones map[string]map[string]*provider.Record
+}
+
+// New returns a provider hosting the given empty zones
+func New(zones ...string) *Provider {
+	p := &Provider{zones: map[string]map[string]*provider.Record{}}
+
+	for _, z := range zones {
+		p.zones[strings.ToLower(dns.Fqdn(z))] = map[string]*provider.Record{}
+	}
+
+	return p
+}
+
+// Zones lists the zones hosted by the provider
+func (p *Provider) Zones(ctx context.Context) ([]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	zones := make([]string, 0, len(p.zones))
+	for z := range p.zones {
+		zones = append(zones, z)
+	}
+
+	sort.Strings(zones)
+
+	return zones, nil
+}
+
+// Records lists the records of a zone
+func (p *Provider) Records(ctx context.Context, zone string) ([]*provider.Record, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	records, ok := p.zones[zone]
+	if !ok {
+		return nil, provider.ErrorZoneNotFound
+	}
+
+	list := make([]*provider.Record, 0, len(records))
+	for _, r := range records {
+		list = append(list, r)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Key() < list[j].Key()
+	})
+
+	return list, nil
+}
+
+// Apply applies the changes one by one, a change that fails doesn't stop the
+// rest of the batch
+func (p *Provider) Apply(ctx context.Context, zone string, changes []*provider.Change) ([]error, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	records, ok := p.zones[zone]
+	if !ok {
+		return nil, provider.ErrorZoneNotFound
+	}
+
+	errs := make([]error, len(changes))
+
+	for i, c := range changes {
+		_, exists := records[c.Record.Key()]
+
+		switch {
+		case c.Action == provider.ActionCreate && exists:
+			errs[i] = provider.ErrorRecordExists
+		case c.Action != provider.ActionCreate && !exists:
+			errs[i] = provider.ErrorRecordNotFound
+		case c.Action == provider.ActionDelete:
+			delete(records, c.Record.Key())
+		default:
+			records[c.Record.Key()] = provider.NewRecord(c.Record.RRs...)
+		}
+	}
+
+	return errs, nil
+}
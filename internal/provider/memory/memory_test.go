@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/miekg/dns"
+
+	"go.hollow.sh/dnscontroller/internal/provider"
+)
+
+func mustRecord(t *testing.T, rrs ...string) *provider.Record {
+	t.Helper()
+
+	parsed := make([]dns.RR, 0, len(rrs))
+
+	for _, s := range rrs {
+		rr, err := dns.NewRR(s)
+		if err != nil {
+			t.Fatalf("failed parsing %q: %s", s, err)
+		}
+
+		parsed = append(parsed, rr)
+	}
+
+	return provider.NewRecord(parsed...)
+}
+
+func TestApply(t *testing.T) {
+	ctx := context.Background()
+	p := New("Example.com")
+
+	zones, err := p.Zones(ctx)
+	if err != nil || len(zones) != 1 || zones[0] != "example.com." {
+		t.Fatalf("unexpected zones %v: %v", zones, err)
+	}
+
+	www := mustRecord(t, "www.example.com. 300 IN A 192.0.2.1")
+	updated := mustRecord(t, "www.example.com. 300 IN A 192.0.2.2")
+	missing := mustRecord(t, "api.example.com. 300 IN A 192.0.2.3")
+
+	errs, err := p.Apply(ctx, "example.com.", []*provider.Change{
+		{Action: provider.ActionCreate, Record: www},
+		{Action: provider.ActionCreate, Record: www},
+		{Action: provider.ActionUpdate, Record: missing},
+		{Action: provider.ActionDelete, Record: missing},
+	})
+	if err != nil {
+		t.Fatalf("unexpected batch error: %s", err)
+	}
+
+	expected := []error{nil, provider.ErrorRecordExists, provider.ErrorRecordNotFound, provider.ErrorRecordNotFound}
+	for i := range expected {
+		if !errors.Is(errs[i], expected[i]) {
+			t.Errorf("change %d: expected %v, got %v", i, expected[i], errs[i])
+		}
+	}
+
+	if _, err := p.Apply(ctx, "example.com.", []*provider.Change{{Action: provider.ActionUpdate, Record: updated}}); err != nil {
+		t.Fatalf("unexpected batch error: %s", err)
+	}
+
+	records, err := p.Records(ctx, "example.com.")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(records) != 1 || !records[0].Equal(updated) {
+		t.Errorf("expected the updated record, got %v", records)
+	}
+
+	if _, err := p.Apply(ctx, "example.net.", nil); !errors.Is(err, provider.ErrorZoneNotFound) {
+		t.Errorf("expected %v, got %v", provider.ErrorZoneNotFound, err)
+	}
+}
+
+func TestNewRecordSharesLowestTTL(t *testing.T) {
+	r := mustRecord(t,
+		"www.example.com. 600 IN A 192.0.2.2",
+		"WWW.example.com. 300 IN A 192.0.2.1",
+		"www.example.com. 900 IN A 192.0.2.2",
+	)
+
+	if r.Key() != "www.example.com./A" {
+		t.Errorf("unexpected key %s", r.Key())
+	}
+
+	if len(r.RRs) != 2 {
+		t.Fatalf("expected duplicates to be dropped, got %v", r.RRs)
+	}
+
+	for _, rr := range r.RRs {
+		if rr.Header().Ttl != 300 {
+			t.Errorf("expected ttl 300, got %s", rr)
+		}
+	}
+}
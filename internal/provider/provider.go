@@ -0,0 +1,142 @@
+// Package provider defines the interface to upstream DNS providers, the
+// records in the database are pushed to them by the reconciler
+package provider
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+var (
+	// ErrorZoneNotFound when a provider doesn't host a zone
+	ErrorZoneNotFound = errors.New("zone not found")
+	// ErrorRecordExists when a create is applied to an existing record
+	ErrorRecordExists = errors.New("record already exists")
+	// ErrorRecordNotFound when an update or delete is applied to a missing record
+	ErrorRecordNotFound = errors.New("record not found")
+)
+
+// Action is what a change does to a record
+type Action string
+
+const (
+	// ActionCreate adds a record that doesn't exist upstream
+	ActionCreate Action = "create"
+	// ActionUpdate replaces all the resource records of an existing record
+	ActionUpdate Action = "update"
+	// ActionDelete removes a record
+	ActionDelete Action = "delete"
+)
+
+// Provider is an upstream DNS provider
+type Provider interface {
+	// Zones lists the zones hosted by the provider
+	Zones(ctx context.Context) ([]string, error)
+	// Records lists the records of a zone
+	Records(ctx context.Context, zone string) ([]*Record, error)
+	// Apply applies a batch of changes to a zone. The returned errors line
+	// up with the changes and are nil for the changes that were applied, the
+	// error is set when the batch as a whole failed.
+	Apply(ctx context.Context, zone string, changes []*Change) ([]error, error)
+}
+
+// Record is the set of resource records of a name and type
+type Record struct {
+	Name string
+	Type string
+	RRs  []dns.RR
+}
+
+// Change is a change to a record, the record is the desired state for
+// creates and updates and the current state for deletes
+type Change struct {
+	Action Action
+	Record *Record
+}
+
+// NewRecord groups resource records of the same name and type into a record.
+// Duplicates are dropped, the rest share the lowest ttl (RFC 2181 5.2) and are
+// sorted so records can be compared.
+func NewRecord(rrs ...dns.RR) *Record {
+	r := &Record{}
+
+	var ttl uint32
+
+	for _, rr := range rrs {
+		if r.has(rr) {
+			continue
+		}
+
+		if len(r.RRs) == 0 || rr.Header().Ttl < ttl {
+			ttl = rr.Header().Ttl
+		}
+
+		r.RRs = append(r.RRs, dns.Copy(rr))
+	}
+
+	for _, rr := range r.RRs {
+		rr.Header().Ttl = ttl
+	}
+
+	sort.Slice(r.RRs, func(i, j int) bool {
+		return r.RRs[i].String() < r.RRs[j].String()
+	})
+
+	if len(r.RRs) > 0 {
+		hdr := r.RRs[0].Header()
+		r.Name = strings.ToLower(hdr.Name)
+		r.Type = dns.TypeToString[hdr.Rrtype]
+	}
+
+	return r
+}
+
+// Key identifies a record by its name and type
+func (r *Record) Key() string {
+	return r.Name + "/" + r.Type
+}
+
+// Equal reports whether both records have the same resource records and ttls
+func (r *Record) Equal(other *Record) bool {
+	if r.Key() != other.Key() || len(r.RRs) != len(other.RRs) {
+		return false
+	}
+
+	for i := range r.RRs {
+		if r.RRs[i].String() != other.RRs[i].String() {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *Record) has(rr dns.RR) bool {
+	for _, existing := range r.RRs {
+		if dns.IsDuplicate(existing, rr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// InZone reports whether the name is at or below the zone apex and not in one
+// of the more specific zones
+func InZone(name, zone string, zones []string) bool {
+	if !dns.IsSubDomain(zone, name) {
+		return false
+	}
+
+	for _, z := range zones {
+		if len(z) > len(zone) && dns.IsSubDomain(zone, z) && dns.IsSubDomain(z, name) {
+			return false
+		}
+	}
+
+	return true
+}
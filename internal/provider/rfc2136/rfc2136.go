@@ -0,0 +1,283 @@
+// Package rfc2136 is a provider for authoritative servers that accept dynamic
+// updates (RFC 2136) signed with TSIG (RFC 8945), like BIND and Knot
+package rfc2136
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+
+	"go.hollow.sh/dnscontroller/internal/provider"
+)
+
+var (
+	// ErrorUnsupportedAlgorithm when the TSIG algorithm isn't hmac-sha256 or hmac-sha512
+	ErrorUnsupportedAlgorithm = errors.New("unsupported TSIG algorithm")
+	// ErrorNoServer when the provider has no server to send updates to
+	ErrorNoServer = errors.New("no rfc2136 server")
+	// ErrorPrerequisite when the zone changed since it was transferred and
+	// the update was rejected by its prerequisites
+	ErrorPrerequisite = errors.New("update prerequisite failed, the zone drifted since it was read")
+)
+
+// RcodeError is returned when the server rejects a message
+type RcodeError struct {
+	Rcode int
+}
+
+func (e *RcodeError) Error() string {
+	return fmt.Sprintf("server responded %s", dns.RcodeToString[e.Rcode])
+}
+
+var (
+	// defaultTimeout bounds each exchange and transfer
+	defaultTimeout = 10 * time.Second
+	// tsigFudge is the allowed clock skew between us and the server
+	tsigFudge uint16 = 300
+)
+
+// supportedAlgorithms are the TSIG algorithms we sign with
+func supportedAlgorithms() map[string]bool {
+	return map[string]bool{
+		dns.HmacSHA256: true,
+		dns.HmacSHA512: true,
+	}
+}
+
+// Config contains the server and TSIG key of the provider
+type Config struct {
+	// Server is the host:port updates and transfers are sent to
+	Server string
+	// Zones are the zones hosted by the server
+	Zones []string
+	// KeyName, Secret and Algorithm are the TSIG key, the secret is base64
+	// encoded. Messages aren't signed when KeyName is empty.
+	KeyName   string
+	Secret    string
+	Algorithm string
+	Timeout   time.Duration
+}
+
+// Provider sends dynamic updates to a single server
+type Provider struct {
+	cfg    Config
+	client *dns.Client
+
+	// observed is the zone content of the last transfer, updates and
+	// deletes require it to be unchanged
+	mu       sync.Mutex
+	observed map[string]map[string]*provider.Record
+}
+
+// New returns a provider for the configured server
+func New(cfg Config) (*Provider, error) {
+	if cfg.Server == "" {
+		return nil, ErrorNoServer
+	}
+
+	if cfg.Timeout == 0 {
+		cfg.Timeout = defaultTimeout
+	}
+
+	zones := make([]string, 0, len(cfg.Zones))
+	for _, z := range cfg.Zones {
+		zones = append(zones, dns.CanonicalName(z))
+	}
+
+	cfg.Zones = zones
+
+	client := &dns.Client{Net: "tcp", Timeout: cfg.Timeout}
+
+	if cfg.KeyName != "" {
+		cfg.KeyName = dns.CanonicalName(cfg.KeyName)
+		cfg.Algorithm = dns.CanonicalName(cfg.Algorithm)
+
+		if !supportedAlgorithms()[cfg.Algorithm] {
+			return nil, ErrorUnsupportedAlgorithm
+		}
+
+		client.TsigSecret = map[string]string{cfg.KeyName: cfg.Secret}
+	}
+
+	return &Provider{
+		cfg:      cfg,
+		client:   client,
+		observed: map[string]map[string]*provider.Record{},
+	}, nil
+}
+
+// Zones lists the configured zones
+func (p *Provider) Zones(ctx context.Context) ([]string, error) {
+	return p.cfg.Zones, nil
+}
+
+// Records transfers the zone (RFC 5936), the result is kept to detect drift
+// when the changes are applied
+func (p *Provider) Records(ctx context.Context, zone string) ([]*provider.Record, error) {
+	m := &dns.Msg{}
+	m.SetAxfr(zone)
+	p.sign(m)
+
+	t := &dns.Transfer{
+		DialTimeout:  p.cfg.Timeout,
+		ReadTimeout:  p.cfg.Timeout,
+		WriteTimeout: p.cfg.Timeout,
+		TsigSecret:   p.client.TsigSecret,
+	}
+
+	envelopes, err := t.In(m, p.cfg.Server)
+	if err != nil {
+		return nil, err
+	}
+
+	grouped := map[string][]dns.RR{}
+	keys := []string{}
+
+	for env := range envelopes {
+		if env.Error != nil {
+			return nil, env.Error
+		}
+
+		for _, rr := range env.RR {
+			hdr := rr.Header()
+			key := strings.ToLower(hdr.Name) + "/" + dns.TypeToString[hdr.Rrtype]
+
+			if _, ok := grouped[key]; !ok {
+				keys = append(keys, key)
+			}
+
+			grouped[key] = append(grouped[key], rr)
+		}
+	}
+
+	records := make([]*provider.Record, 0, len(keys))
+	observed := map[string]*provider.Record{}
+
+	for _, key := range keys {
+		// NewRecord drops the SOA repeated at the end of the transfer
+		r := provider.NewRecord(grouped[key]...)
+		records = append(records, r)
+		observed[key] = r
+	}
+
+	p.mu.Lock()
+	p.observed[zone] = observed
+	p.mu.Unlock()
+
+	return records, nil
+}
+
+// Apply sends the batch as a single update. A batch is all or nothing, when it
+// is rejected the changes are retried one by one to find the failing ones.
+func (p *Provider) Apply(ctx context.Context, zone string, changes []*provider.Change) ([]error, error) {
+	errs := make([]error, len(changes))
+
+	err := p.update(ctx, zone, changes...)
+	if err == nil {
+		return errs, nil
+	}
+
+	// Other errors, like a bad key or a refused zone, fail every change
+	var rcodeErr *RcodeError
+	if !errors.Is(err, ErrorPrerequisite) && !(errors.As(err, &rcodeErr) && rcodeErr.Rcode == dns.RcodeNotZone) {
+		return nil, err
+	}
+
+	if len(changes) == 1 {
+		errs[0] = err
+		return errs, nil
+	}
+
+	for i, c := range changes {
+		errs[i] = p.update(ctx, zone, c)
+	}
+
+	return errs, nil
+}
+
+// update sends the changes in a single signed update message
+func (p *Provider) update(ctx context.Context, zone string, changes ...*provider.Change) error {
+	m := &dns.Msg{}
+	m.SetUpdate(zone)
+
+	for _, c := range changes {
+		p.addChange(m, zone, c)
+	}
+
+	p.sign(m)
+
+	resp, _, err := p.client.ExchangeContext(ctx, m, p.cfg.Server)
+	if err != nil {
+		return err
+	}
+
+	switch resp.Rcode {
+	case dns.RcodeSuccess:
+		return nil
+	case dns.RcodeYXDomain, dns.RcodeYXRrset, dns.RcodeNXRrset, dns.RcodeNameError:
+		return fmt.Errorf("%w: %s", ErrorPrerequisite, dns.RcodeToString[resp.Rcode])
+	}
+
+	return &RcodeError{Rcode: resp.Rcode}
+}
+
+// addChange adds the prerequisites and updates of a change (RFC 2136 2.4,
+// 2.5). Creates require the RRset not to exist, updates and deletes require it
+// to be as it was transferred, or at least to exist when it wasn't seen.
+func (p *Provider) addChange(m *dns.Msg, zone string, c *provider.Change) {
+	rrs := copyRRs(c.Record.RRs)
+	if len(rrs) == 0 {
+		return
+	}
+
+	if c.Action == provider.ActionCreate {
+		m.RRsetNotUsed(rrs[:1])
+		m.Insert(rrs)
+
+		return
+	}
+
+	if observed := p.observedRecord(zone, c.Record.Key()); observed != nil {
+		m.Used(copyRRs(observed.RRs))
+	} else {
+		m.RRsetUsed(rrs[:1])
+	}
+
+	m.RemoveRRset(rrs[:1])
+
+	if c.Action == provider.ActionUpdate {
+		m.Insert(rrs)
+	}
+}
+
+func (p *Provider) observedRecord(zone, key string) *provider.Record {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.observed[zone][key]
+}
+
+// sign adds the TSIG to a message, the client signs it when it's sent
+func (p *Provider) sign(m *dns.Msg) {
+	if p.cfg.KeyName == "" {
+		return
+	}
+
+	m.SetTsig(p.cfg.KeyName, p.cfg.Algorithm, tsigFudge, time.Now().Unix())
+}
+
+// copyRRs copies the resource records, building an update changes their
+// class and ttl in place
+func copyRRs(rrs []dns.RR) []dns.RR {
+	copied := make([]dns.RR, 0, len(rrs))
+	for _, rr := range rrs {
+		copied = append(copied, dns.Copy(rr))
+	}
+
+	return copied
+}
@@ -0,0 +1,360 @@
+package rfc2136
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+
+	"go.hollow.sh/dnscontroller/internal/provider"
+)
+
+const (
+	testZone    = "example.com."
+	testKeyName = "dnscontroller."
+)
+
+var testSecret = base64.StdEncoding.EncodeToString([]byte("a secret only the tests know"))
+
+func mustRR(t *testing.T, s string) dns.RR {
+	t.Helper()
+
+	rr, err := dns.NewRR(s)
+	if err != nil {
+		t.Fatalf("failed parsing %q: %s", s, err)
+	}
+
+	return rr
+}
+
+// standIn is a minimal authoritative server for a single zone, it serves
+// transfers and applies updates with their prerequisites
+type standIn struct {
+	mu        sync.Mutex
+	algorithm string
+	soa       dns.RR
+	rrs       []dns.RR
+}
+
+func (s *standIn) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
+	m := &dns.Msg{}
+	m.SetReply(r)
+
+	if r.IsTsig() == nil || w.TsigStatus() != nil {
+		m.Rcode = dns.RcodeNotAuth
+		_ = w.WriteMsg(m)
+
+		return
+	}
+
+	s.mu.Lock()
+
+	switch {
+	case r.Opcode == dns.OpcodeUpdate:
+		m.Rcode = s.update(r)
+	case r.Question[0].Qtype == dns.TypeAXFR:
+		m.Answer = append(append([]dns.RR{s.soa}, s.rrs...), s.soa)
+	default:
+		m.Rcode = dns.RcodeNotImplemented
+	}
+
+	s.mu.Unlock()
+
+	m.SetTsig(testKeyName, s.algorithm, tsigFudge, time.Now().Unix())
+	_ = w.WriteMsg(m)
+}
+
+// update checks the prerequisites and applies the update section
+// (RFC 2136 3.2, 3.4)
+func (s *standIn) update(r *dns.Msg) int {
+	valueDependent := map[string][]dns.RR{}
+
+	for _, rr := range r.Answer {
+		hdr := rr.Header()
+		rrset := s.rrset(hdr.Name, hdr.Rrtype)
+
+		switch hdr.Class {
+		case dns.ClassANY:
+			if len(rrset) == 0 {
+				return dns.RcodeNXRrset
+			}
+		case dns.ClassNONE:
+			if len(rrset) != 0 {
+				return dns.RcodeYXRrset
+			}
+		default:
+			key := dns.CanonicalName(hdr.Name) + dns.TypeToString[hdr.Rrtype]
+			valueDependent[key] = append(valueDependent[key], rr)
+		}
+	}
+
+	for _, expected := range valueDependent {
+		hdr := expected[0].Header()
+		if !sameRRs(s.rrset(hdr.Name, hdr.Rrtype), expected) {
+			return dns.RcodeNXRrset
+		}
+	}
+
+	for _, rr := range r.Ns {
+		hdr := rr.Header()
+
+		switch hdr.Class {
+		case dns.ClassANY:
+			kept := []dns.RR{}
+
+			for _, existing := range s.rrs {
+				eh := existing.Header()
+				if !dns.IsSubDomain(hdr.Name, eh.Name) || !dns.IsSubDomain(eh.Name, hdr.Name) || eh.Rrtype != hdr.Rrtype {
+					kept = append(kept, existing)
+				}
+			}
+
+			s.rrs = kept
+		default:
+			s.rrs = append(s.rrs, rr)
+		}
+	}
+
+	return dns.RcodeSuccess
+}
+
+func (s *standIn) rrset(name string, rrtype uint16) []dns.RR {
+	rrset := []dns.RR{}
+
+	for _, rr := range s.rrs {
+		hdr := rr.Header()
+		if dns.CanonicalName(hdr.Name) == dns.CanonicalName(name) && hdr.Rrtype == rrtype {
+			rrset = append(rrset, rr)
+		}
+	}
+
+	return rrset
+}
+
+func sameRRs(a, b []dns.RR) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for _, rr := range a {
+		found := false
+
+		for _, other := range b {
+			if dns.IsDuplicate(rr, other) {
+				found = true
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// set replaces the zone content behind the provider's back
+func (s *standIn) set(rrs ...dns.RR) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rrs = rrs
+}
+
+func startStandIn(t *testing.T, algorithm string) (*standIn, string) {
+	t.Helper()
+
+	s := &standIn{
+		algorithm: algorithm,
+		soa:       mustRR(t, "example.com. 3600 IN SOA ns.example.com. hostmaster.example.com. 1 3600 600 604800 300"),
+		rrs: []dns.RR{
+			mustRR(t, "example.com. 3600 IN NS ns.example.com."),
+			mustRR(t, "www.example.com. 300 IN A 192.0.2.1"),
+		},
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed listening: %s", err)
+	}
+
+	started := make(chan struct{})
+
+	srv := &dns.Server{
+		Listener:          l,
+		Handler:           s,
+		TsigSecret:        map[string]string{testKeyName: testSecret},
+		NotifyStartedFunc: func() { close(started) },
+		// The default rejects updates
+		MsgAcceptFunc: func(dh dns.Header) dns.MsgAcceptAction { return dns.MsgAccept },
+	}
+
+	go func() { _ = srv.ActivateAndServe() }()
+
+	<-started
+
+	t.Cleanup(func() { _ = srv.Shutdown() })
+
+	return s, l.Addr().String()
+}
+
+func newTestProvider(t *testing.T, addr, algorithm, secret string) *Provider {
+	t.Helper()
+
+	p, err := New(Config{
+		Server:    addr,
+		Zones:     []string{"Example.com"},
+		KeyName:   "dnscontroller",
+		Secret:    secret,
+		Algorithm: algorithm,
+		Timeout:   2 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("failed creating provider: %s", err)
+	}
+
+	return p
+}
+
+func keys(records []*provider.Record) map[string]*provider.Record {
+	byKey := map[string]*provider.Record{}
+	for _, r := range records {
+		byKey[r.Key()] = r
+	}
+
+	return byKey
+}
+
+func TestRecordsAndApply(t *testing.T) {
+	for _, algorithm := range []string{"hmac-sha256", "hmac-sha512."} {
+		t.Run(algorithm, func(t *testing.T) {
+			ctx := context.Background()
+			_, addr := startStandIn(t, dns.CanonicalName(algorithm))
+			p := newTestProvider(t, addr, algorithm, testSecret)
+
+			zones, _ := p.Zones(ctx)
+			if len(zones) != 1 || zones[0] != testZone {
+				t.Fatalf("unexpected zones %v", zones)
+			}
+
+			records, err := p.Records(ctx, testZone)
+			if err != nil {
+				t.Fatalf("failed transferring zone: %s", err)
+			}
+
+			if byKey := keys(records); len(byKey) != 3 || byKey["www.example.com./A"] == nil || byKey["example.com./SOA"] == nil {
+				t.Fatalf("unexpected records %v", records)
+			}
+
+			www := provider.NewRecord(mustRR(t, "www.example.com. 300 IN A 192.0.2.2"), mustRR(t, "www.example.com. 300 IN A 192.0.2.3"))
+			srv := provider.NewRecord(mustRR(t, "_http._tcp.example.com. 300 IN SRV 0 0 80 www.example.com."))
+
+			errs, err := p.Apply(ctx, testZone, []*provider.Change{
+				{Action: provider.ActionUpdate, Record: www},
+				{Action: provider.ActionCreate, Record: srv},
+			})
+			if err != nil {
+				t.Fatalf("unexpected batch error: %s", err)
+			}
+
+			for i, err := range errs {
+				if err != nil {
+					t.Errorf("change %d failed: %s", i, err)
+				}
+			}
+
+			records, err = p.Records(ctx, testZone)
+			if err != nil {
+				t.Fatalf("failed transferring zone: %s", err)
+			}
+
+			byKey := keys(records)
+			if !byKey["www.example.com./A"].Equal(www) || !byKey["_http._tcp.example.com./SRV"].Equal(srv) {
+				t.Errorf("changes weren't applied: %v", records)
+			}
+
+			errs, err = p.Apply(ctx, testZone, []*provider.Change{{Action: provider.ActionDelete, Record: srv}})
+			if err != nil || errs[0] != nil {
+				t.Fatalf("failed deleting record: %v %v", err, errs)
+			}
+
+			records, _ = p.Records(ctx, testZone)
+			if _, ok := keys(records)["_http._tcp.example.com./SRV"]; ok {
+				t.Errorf("record wasn't deleted: %v", records)
+			}
+		})
+	}
+}
+
+func TestApplyDetectsDrift(t *testing.T) {
+	ctx := context.Background()
+	s, addr := startStandIn(t, dns.HmacSHA256)
+	p := newTestProvider(t, addr, dns.HmacSHA256, testSecret)
+
+	if _, err := p.Records(ctx, testZone); err != nil {
+		t.Fatalf("failed transferring zone: %s", err)
+	}
+
+	// Someone changes the record after the transfer
+	s.set(
+		mustRR(t, "example.com. 3600 IN NS ns.example.com."),
+		mustRR(t, "www.example.com. 300 IN A 198.51.100.1"),
+	)
+
+	errs, err := p.Apply(ctx, testZone, []*provider.Change{
+		{Action: provider.ActionUpdate, Record: provider.NewRecord(mustRR(t, "www.example.com. 300 IN A 192.0.2.2"))},
+		{Action: provider.ActionCreate, Record: provider.NewRecord(mustRR(t, "api.example.com. 300 IN A 192.0.2.4"))},
+		{Action: provider.ActionCreate, Record: provider.NewRecord(mustRR(t, "example.com. 300 IN NS ns2.example.com."))},
+	})
+	if err != nil {
+		t.Fatalf("unexpected batch error: %s", err)
+	}
+
+	if !errors.Is(errs[0], ErrorPrerequisite) {
+		t.Errorf("expected the drifted update to fail, got %v", errs[0])
+	}
+
+	if errs[1] != nil {
+		t.Errorf("expected the create to succeed, got %s", errs[1])
+	}
+
+	if !errors.Is(errs[2], ErrorPrerequisite) {
+		t.Errorf("expected the create of an existing RRset to fail, got %v", errs[2])
+	}
+
+	records, _ := p.Records(ctx, testZone)
+	if www := keys(records)["www.example.com./A"]; www == nil || www.RRs[0].(*dns.A).A.String() != "198.51.100.1" {
+		t.Errorf("drifted record was overwritten: %v", records)
+	}
+}
+
+func TestBadKey(t *testing.T) {
+	ctx := context.Background()
+	_, addr := startStandIn(t, dns.HmacSHA256)
+	p := newTestProvider(t, addr, dns.HmacSHA256, base64.StdEncoding.EncodeToString([]byte("wrong")))
+
+	_, err := p.Apply(ctx, testZone, []*provider.Change{
+		{Action: provider.ActionCreate, Record: provider.NewRecord(mustRR(t, "api.example.com. 300 IN A 192.0.2.4"))},
+	})
+
+	var rcodeErr *RcodeError
+	if !errors.As(err, &rcodeErr) || rcodeErr.Rcode != dns.RcodeNotAuth {
+		t.Errorf("expected a NOTAUTH batch error, got %v", err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New(Config{Zones: []string{testZone}}); !errors.Is(err, ErrorNoServer) {
+		t.Errorf("expected %v, got %v", ErrorNoServer, err)
+	}
+
+	_, err := New(Config{Server: "127.0.0.1:53", KeyName: testKeyName, Secret: testSecret, Algorithm: "hmac-md5"})
+	if !errors.Is(err, ErrorUnsupportedAlgorithm) {
+		t.Errorf("expected %v, got %v", ErrorUnsupportedAlgorithm, err)
+	}
+}
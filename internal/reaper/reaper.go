@@ -0,0 +1,149 @@
+// Package reaper removes the answers whose lease expired, so the answers of an
+// owner that stopped renewing them go away on their own
+package reaper
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach-go/v2/crdb/crdbsqlx"
+	"github.com/jmoiron/sqlx"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"go.uber.org/zap"
+
+	"go.hollow.sh/dnscontroller/internal/models"
+	answer "go.hollow.sh/dnscontroller/pkg/api/v1/answers"
+	"go.hollow.sh/dnscontroller/pkg/api/v1/audit"
+	"go.hollow.sh/dnscontroller/pkg/api/v1/outbox"
+	record "go.hollow.sh/dnscontroller/pkg/api/v1/records"
+)
+
+// Actor is who the reaper's deletions are recorded as in the audit log
+const Actor = "system:reaper"
+
+// Reaper deletes expired answers, and the records they leave without answers
+type Reaper struct {
+	Logger   *zap.SugaredLogger
+	DB       *sqlx.DB
+	Interval time.Duration
+}
+
+// Run reaps every interval until the context is done
+func (r *Reaper) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.Reap(ctx); err != nil {
+			r.Logger.Errorw("failed reaping expired answers", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Reap deletes the expired answers in one transaction. A record is only
+// deleted when it has no answers left, so an answer added to it in the
+// meantime keeps it.
+func (r *Reaper) Reap(ctx context.Context) error {
+	ctx = audit.WithActor(ctx, audit.Actor{Subject: Actor})
+
+	var answers, records int64
+
+	err := crdbsqlx.ExecuteTx(ctx, r.DB, nil, func(tx *sqlx.Tx) error {
+		expired, err := models.Answers(
+			qm.Where("expires_at <= ?", time.Now()),
+			qm.Load(models.AnswerRels.Record),
+			qm.Load(models.AnswerRels.Owner),
+			qm.Load(models.AnswerRels.AnswerDetail),
+		).All(ctx, tx)
+		if err != nil || len(expired) == 0 {
+			return err
+		}
+
+		recordIDs := []interface{}{}
+		seen := map[string]bool{}
+
+		for _, dbAnswer := range expired {
+			if !seen[dbAnswer.RecordID] {
+				seen[dbAnswer.RecordID] = true
+				recordIDs = append(recordIDs, dbAnswer.RecordID)
+
+				if err := record.BumpSerial(ctx, tx, dbAnswer.RecordID); err != nil {
+					return err
+				}
+			}
+
+			if err := auditExpired(ctx, tx, dbAnswer); err != nil {
+				return err
+			}
+		}
+
+		if answers, err = expired.DeleteAll(ctx, tx); err != nil {
+			return err
+		}
+
+		empty, err := models.Records(
+			qm.WhereIn("id IN ?", recordIDs...),
+			qm.Where("NOT EXISTS (SELECT 1 FROM answers WHERE answers.record_id = records.id)"),
+		).All(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		for _, dbRecord := range empty {
+			e := &audit.Event{Operation: audit.RecordDelete, Record: dbRecord.Record, RecordType: dbRecord.RecordType}
+
+			before := &record.Record{}
+			if err := before.FromDBModel(dbRecord); err != nil {
+				return err
+			}
+
+			if err := audit.Write(ctx, tx, e, before, nil); err != nil {
+				return err
+			}
+
+			if err := outbox.WriteChange(ctx, tx, &outbox.Message{Event: e.Operation, Record: e.Record, RecordType: e.RecordType}, before, nil); err != nil {
+				return err
+			}
+		}
+
+		records, err = empty.DeleteAll(ctx, tx)
+
+		return err
+	})
+	if err != nil || answers == 0 {
+		return err
+	}
+
+	r.Logger.Infow("reaped expired answers", "answers", answers, "records", records)
+
+	return nil
+}
+
+// auditExpired records the removal of an expired answer in the audit log and
+// the outbox, the answer must have its record, owner and details loaded
+func auditExpired(ctx context.Context, exec boil.ContextExecutor, dbAnswer *models.Answer) error {
+	e := &audit.Event{
+		Operation:  audit.AnswerExpire,
+		Record:     dbAnswer.R.Record.Record,
+		RecordType: dbAnswer.R.Record.RecordType,
+		OwnerID:    dbAnswer.OwnerID,
+	}
+
+	before := &answer.Answer{}
+	if err := before.FromDBModel(dbAnswer); err != nil {
+		return err
+	}
+
+	if err := audit.Write(ctx, exec, e, before, nil); err != nil {
+		return err
+	}
+
+	return outbox.WriteChange(ctx, exec, &outbox.Message{Event: e.Operation, Record: e.Record, RecordType: e.RecordType, OwnerID: e.OwnerID}, before, nil)
+}
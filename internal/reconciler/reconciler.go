@@ -0,0 +1,324 @@
+// Package reconciler pushes the records in the database to an upstream
+// provider, creating, updating and deleting records until both agree
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/miekg/dns"
+	"go.uber.org/zap"
+
+	"go.hollow.sh/dnscontroller/internal/provider"
+)
+
+// DefaultBatchSize is the number of changes applied to a provider at once
+const DefaultBatchSize = 100
+
+// ErrorNoZones when no zones are configured and reconciling all the provider
+// zones wasn't asked for
+var ErrorNoZones = errors.New("no zones to reconcile")
+
+// Source is where the desired state of a zone comes from
+type Source interface {
+	// Records lists the records at or below the zone apex
+	Records(ctx context.Context, zone string) ([]*provider.Record, error)
+}
+
+// Reconciler diffs the desired state against a provider and applies the
+// changes
+type Reconciler struct {
+	Logger   *zap.SugaredLogger
+	Provider provider.Provider
+	Source   Source
+	// Zones limits the reconciled zones
+	Zones []string
+	// AllZones reconciles all the provider zones when Zones is empty
+	AllZones bool
+	// Owner is written to the markers of the records the reconciler owns,
+	// reconcilers sharing a zone need different owners
+	Owner string
+	// Adopt takes over records that exist upstream without a marker when
+	// the database has them
+	Adopt bool
+	// DryRun reports the changes without applying them
+	DryRun bool
+	// BatchSize is the number of changes applied at once
+	BatchSize int
+	// Interval is the time between runs
+	Interval time.Duration
+}
+
+// Result is the outcome of a single change
+type Result struct {
+	Zone    string
+	Change  *provider.Change
+	Applied bool
+	Err     error
+}
+
+// Report lists the changes of a run
+type Report struct {
+	Results []*Result
+}
+
+// Failed returns the changes that couldn't be applied
+func (r *Report) Failed() []*Result {
+	failed := []*Result{}
+
+	for _, res := range r.Results {
+		if res.Err != nil {
+			failed = append(failed, res)
+		}
+	}
+
+	return failed
+}
+
+// Run reconciles every interval until the context is done
+func (r *Reconciler) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.RunOnce(ctx); err != nil {
+			r.Logger.Errorw("failed reconciling", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce reconciles once and logs the outcome of every change
+func (r *Reconciler) RunOnce(ctx context.Context) (*Report, error) {
+	report, err := r.Reconcile(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.log(report)
+
+	return report, nil
+}
+
+// Reconcile runs once over all the zones. Failed changes are in the report,
+// the error is only set when the zones can't be listed.
+func (r *Reconciler) Reconcile(ctx context.Context) (*Report, error) {
+	zones, err := r.zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{}
+
+	for _, zone := range zones {
+		changes, err := r.diff(ctx, zone, zones)
+		if err != nil {
+			// Report the zone as a whole so the other zones still run
+			report.Results = append(report.Results, &Result{Zone: zone, Err: err})
+			continue
+		}
+
+		report.Results = append(report.Results, r.apply(ctx, zone, changes)...)
+	}
+
+	return report, nil
+}
+
+// zones returns the provider zones, limited to the configured ones
+func (r *Reconciler) zones(ctx context.Context) ([]string, error) {
+	hosted, err := r.Provider.Zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(r.Zones) == 0 {
+		if !r.AllZones {
+			return nil, ErrorNoZones
+		}
+
+		return hosted, nil
+	}
+
+	wanted := map[string]bool{}
+	for _, z := range r.Zones {
+		wanted[dns.CanonicalName(z)] = true
+	}
+
+	zones := []string{}
+
+	for _, z := range hosted {
+		if wanted[dns.CanonicalName(z)] {
+			zones = append(zones, z)
+		}
+	}
+
+	return zones, nil
+}
+
+// diff returns the changes that make the provider zone match the source.
+// Only the records with a marker of this reconciler are changed, the marker
+// is created before the record and deleted after it.
+func (r *Reconciler) diff(ctx context.Context, zone string, zones []string) ([]*provider.Change, error) {
+	desired, err := r.Source.Records(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := r.Provider.Records(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	currentByKey := map[string]*provider.Record{}
+	markers := map[string]*provider.Record{}
+
+	for _, rec := range current {
+		switch {
+		case !managed(rec, zone, zones):
+		case isMarker(rec):
+			markers[rec.Name] = rec
+		default:
+			currentByKey[rec.Key()] = rec
+		}
+	}
+
+	changes := []*provider.Change{}
+	desiredKeys := map[string]bool{}
+	wanted := map[string]bool{}
+
+	for _, rec := range desired {
+		if !managed(rec, zone, zones) || isMarker(rec) {
+			continue
+		}
+
+		desiredKeys[rec.Key()] = true
+		name := markerName(rec.Name, rec.Type)
+		owned := r.owns(markers[name])
+		existing, ok := currentByKey[rec.Key()]
+
+		if ok && !owned && !r.Adopt {
+			r.Logger.Warnw("record exists upstream without a marker, leaving it", "zone", zone, "record", rec.Key())
+			continue
+		}
+
+		wanted[name] = true
+
+		if !owned {
+			changes = append(changes, &provider.Change{Action: provider.ActionCreate, Record: r.marker(rec.Name, rec.Type)})
+		}
+
+		switch {
+		case !ok:
+			changes = append(changes, &provider.Change{Action: provider.ActionCreate, Record: rec})
+		case !existing.Equal(rec):
+			changes = append(changes, &provider.Change{Action: provider.ActionUpdate, Record: rec})
+		}
+	}
+
+	for _, rec := range current {
+		if _, ok := currentByKey[rec.Key()]; ok && !desiredKeys[rec.Key()] && r.owns(markers[markerName(rec.Name, rec.Type)]) {
+			changes = append(changes, &provider.Change{Action: provider.ActionDelete, Record: rec})
+		}
+	}
+
+	for _, rec := range current {
+		if markers[rec.Name] == rec && !wanted[rec.Name] && r.owns(rec) {
+			changes = append(changes, &provider.Change{Action: provider.ActionDelete, Record: rec})
+		}
+	}
+
+	return changes, nil
+}
+
+// apply applies the changes in batches, or only reports them on a dry run
+func (r *Reconciler) apply(ctx context.Context, zone string, changes []*provider.Change) []*Result {
+	results := make([]*Result, 0, len(changes))
+
+	if r.DryRun {
+		for _, c := range changes {
+			results = append(results, &Result{Zone: zone, Change: c})
+		}
+
+		return results
+	}
+
+	size := r.BatchSize
+	if size < 1 {
+		size = DefaultBatchSize
+	}
+
+	for start := 0; start < len(changes); start += size {
+		end := start + size
+		if end > len(changes) {
+			end = len(changes)
+		}
+
+		batch := changes[start:end]
+
+		errs, err := r.Provider.Apply(ctx, zone, batch)
+
+		for i, c := range batch {
+			res := &Result{Zone: zone, Change: c, Err: err}
+
+			if err == nil && i < len(errs) {
+				res.Err = errs[i]
+			}
+
+			res.Applied = res.Err == nil
+			results = append(results, res)
+		}
+	}
+
+	return results
+}
+
+func (r *Reconciler) log(report *Report) {
+	for _, res := range report.Results {
+		if res.Change == nil {
+			r.Logger.Errorw("failed reconciling zone", "zone", res.Zone, "error", res.Err)
+			continue
+		}
+
+		fields := []interface{}{
+			"zone", res.Zone,
+			"action", res.Change.Action,
+			"record", res.Change.Record.Key(),
+		}
+
+		if res.Err != nil {
+			r.Logger.Errorw("failed applying record change", append(fields, "error", res.Err)...)
+			continue
+		}
+
+		if r.DryRun {
+			r.Logger.Infow("planned record change", fields...)
+			continue
+		}
+
+		r.Logger.Infow("applied record change", fields...)
+	}
+}
+
+// managed reports whether the reconciler may diff a provider record, the SOA
+// and apex NS records belong to the provider. Records are only changed when
+// they also carry a marker.
+func managed(rec *provider.Record, zone string, zones []string) bool {
+	if !provider.InZone(rec.Name, zone, zones) {
+		return false
+	}
+
+	switch rec.Type {
+	case "SOA":
+		return false
+	case "NS":
+		return rec.Name != zone
+	}
+
+	return true
+}
@@ -0,0 +1,313 @@
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/miekg/dns"
+	"go.uber.org/zap"
+
+	"go.hollow.sh/dnscontroller/internal/provider"
+	"go.hollow.sh/dnscontroller/internal/provider/memory"
+)
+
+// staticSource serves a fixed desired state
+type staticSource struct {
+	records []*provider.Record
+	err     error
+}
+
+func (s *staticSource) Records(ctx context.Context, zone string) ([]*provider.Record, error) {
+	return s.records, s.err
+}
+
+var errUpstream = errors.New("upstream rejected the record")
+
+// failingProvider fails every change to one record
+type failingProvider struct {
+	*memory.Provider
+	key string
+}
+
+func (p *failingProvider) Apply(ctx context.Context, zone string, changes []*provider.Change) ([]error, error) {
+	errs, err := p.Provider.Apply(ctx, zone, changes)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, c := range changes {
+		if c.Record.Key() == p.key {
+			errs[i] = errUpstream
+		}
+	}
+
+	return errs, nil
+}
+
+func mustRecord(t *testing.T, rrs ...string) *provider.Record {
+	t.Helper()
+
+	parsed := make([]dns.RR, 0, len(rrs))
+
+	for _, s := range rrs {
+		rr, err := dns.NewRR(s)
+		if err != nil {
+			t.Fatalf("failed parsing %q: %s", s, err)
+		}
+
+		parsed = append(parsed, rr)
+	}
+
+	return provider.NewRecord(parsed...)
+}
+
+func seed(t *testing.T, p provider.Provider, zone string, records ...*provider.Record) {
+	t.Helper()
+
+	changes := make([]*provider.Change, 0, len(records))
+	for _, r := range records {
+		changes = append(changes, &provider.Change{Action: provider.ActionCreate, Record: r})
+	}
+
+	if _, err := p.Apply(context.Background(), zone, changes); err != nil {
+		t.Fatalf("failed seeding provider: %s", err)
+	}
+}
+
+func actions(report *Report) map[string]provider.Action {
+	got := map[string]provider.Action{}
+	for _, res := range report.Results {
+		got[res.Change.Record.Key()] = res.Change.Action
+	}
+
+	return got
+}
+
+// withMarkers returns the records followed by the markers of r
+func withMarkers(r *Reconciler, records ...*provider.Record) []*provider.Record {
+	marked := append([]*provider.Record{}, records...)
+	for _, rec := range records {
+		marked = append(marked, r.marker(rec.Name, rec.Type))
+	}
+
+	return marked
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	p := memory.New("example.com.")
+
+	desired := []*provider.Record{
+		mustRecord(t, "www.example.com. 300 IN A 192.0.2.1", "www.example.com. 300 IN A 192.0.2.2"),
+		mustRecord(t, `same.example.com. 300 IN TXT "unchanged"`),
+		mustRecord(t, "_http._tcp.example.com. 300 IN SRV 0 0 80 www.example.com."),
+		mustRecord(t, "other.example.net. 300 IN A 192.0.2.3"),
+		mustRecord(t, "hand.example.com. 300 IN A 192.0.2.4"),
+	}
+
+	r := &Reconciler{
+		Logger:    zap.NewNop().Sugar(),
+		Provider:  p,
+		Source:    &staticSource{records: desired},
+		AllZones:  true,
+		BatchSize: 2,
+	}
+
+	seed(t, p, "example.com.", append(
+		withMarkers(r,
+			mustRecord(t, "www.example.com. 300 IN A 192.0.2.1"),
+			mustRecord(t, "old.example.com. 300 IN A 192.0.2.9"),
+			mustRecord(t, `same.example.com. 300 IN TXT "unchanged"`),
+		),
+		mustRecord(t, "example.com. 3600 IN SOA ns.example.com. hostmaster.example.com. 1 3600 600 604800 300"),
+		mustRecord(t, "example.com. 3600 IN NS ns.example.com."),
+		mustRecord(t, "mail.example.com. 300 IN MX 10 mx.example.net."),
+		mustRecord(t, "hand.example.com. 300 IN A 192.0.2.1"),
+	)...)
+
+	report, err := r.Reconcile(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// The hand maintained records have no marker, so they are left alone
+	expected := map[string]provider.Action{
+		"www.example.com./A":                            provider.ActionUpdate,
+		"_http._tcp.example.com./SRV":                   provider.ActionCreate,
+		"dnscontroller-srv._http._tcp.example.com./TXT": provider.ActionCreate,
+		"old.example.com./A":                            provider.ActionDelete,
+		"dnscontroller-a.old.example.com./TXT":          provider.ActionDelete,
+	}
+
+	got := actions(report)
+	if len(got) != len(expected) {
+		t.Fatalf("expected %d changes, got %v", len(expected), got)
+	}
+
+	for key, action := range expected {
+		if got[key] != action {
+			t.Errorf("%s: expected %s, got %s", key, action, got[key])
+		}
+	}
+
+	if failed := report.Failed(); len(failed) != 0 {
+		t.Errorf("unexpected failures: %v", failed)
+	}
+
+	// A second run has nothing left to do
+	report, err = r.Reconcile(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(report.Results) != 0 {
+		t.Errorf("expected no changes, got %v", actions(report))
+	}
+}
+
+func TestReconcileAdopt(t *testing.T) {
+	ctx := context.Background()
+	p := memory.New("example.com.")
+
+	seed(t, p, "example.com.",
+		mustRecord(t, "*.example.com. 300 IN A 192.0.2.1"),
+		mustRecord(t, "mail.example.com. 300 IN MX 10 mx.example.net."),
+	)
+
+	r := &Reconciler{
+		Logger:   zap.NewNop().Sugar(),
+		Provider: p,
+		Source:   &staticSource{records: []*provider.Record{mustRecord(t, "*.example.com. 300 IN A 192.0.2.2")}},
+		Zones:    []string{"example.com."},
+		Owner:    "east",
+		Adopt:    true,
+	}
+
+	report, err := r.Reconcile(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]provider.Action{
+		"*.example.com./A":                          provider.ActionUpdate,
+		"dnscontroller-a-wildcard.example.com./TXT": provider.ActionCreate,
+	}
+
+	got := actions(report)
+	if len(got) != len(expected) {
+		t.Fatalf("expected %d changes, got %v", len(expected), got)
+	}
+
+	for key, action := range expected {
+		if got[key] != action {
+			t.Errorf("%s: expected %s, got %s", key, action, got[key])
+		}
+	}
+
+	// A reconciler with another owner doesn't touch what east owns
+	west := &Reconciler{
+		Logger:   zap.NewNop().Sugar(),
+		Provider: p,
+		Source:   &staticSource{},
+		Zones:    []string{"example.com."},
+		Owner:    "west",
+	}
+
+	report, err = west.Reconcile(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(report.Results) != 0 {
+		t.Errorf("expected no changes by another owner, got %v", actions(report))
+	}
+}
+
+func TestReconcileNoZones(t *testing.T) {
+	r := &Reconciler{
+		Logger:   zap.NewNop().Sugar(),
+		Provider: memory.New("example.com."),
+		Source:   &staticSource{},
+	}
+
+	if _, err := r.Reconcile(context.Background()); !errors.Is(err, ErrorNoZones) {
+		t.Errorf("expected ErrorNoZones, got %v", err)
+	}
+}
+
+func TestReconcileDryRun(t *testing.T) {
+	ctx := context.Background()
+	p := memory.New("example.com.")
+
+	r := &Reconciler{
+		Logger:   zap.NewNop().Sugar(),
+		Provider: p,
+		Source:   &staticSource{records: []*provider.Record{mustRecord(t, "www.example.com. 300 IN A 192.0.2.1")}},
+		AllZones: true,
+		DryRun:   true,
+	}
+
+	report, err := r.Reconcile(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(report.Results) != 2 || report.Results[0].Applied || report.Results[1].Applied {
+		t.Fatalf("expected the record and its marker to be planned, got %v", report.Results)
+	}
+
+	records, _ := p.Records(ctx, "example.com.")
+	if len(records) != 0 {
+		t.Errorf("dry run changed the provider: %v", records)
+	}
+}
+
+func TestReconcileReportsRecordErrors(t *testing.T) {
+	ctx := context.Background()
+	p := &failingProvider{Provider: memory.New("example.com.", "example.net."), key: "bad.example.com./A"}
+
+	r := &Reconciler{
+		Logger:   zap.NewNop().Sugar(),
+		Provider: p,
+		Source: &staticSource{records: []*provider.Record{
+			mustRecord(t, "bad.example.com. 300 IN A 192.0.2.1"),
+			mustRecord(t, "good.example.com. 300 IN A 192.0.2.2"),
+		}},
+		Zones: []string{"Example.com"},
+	}
+
+	report, err := r.Reconcile(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	failed := report.Failed()
+	if len(failed) != 1 || failed[0].Change.Record.Key() != "bad.example.com./A" || !errors.Is(failed[0].Err, errUpstream) {
+		t.Fatalf("expected only bad.example.com. to fail, got %v", failed)
+	}
+
+	if len(report.Results) != 4 {
+		t.Errorf("expected example.net. to be skipped, got %v", actions(report))
+	}
+}
+
+func TestReconcileSourceError(t *testing.T) {
+	errSource := errors.New("source unavailable")
+
+	r := &Reconciler{
+		Logger:   zap.NewNop().Sugar(),
+		Provider: memory.New("example.com."),
+		Source:   &staticSource{err: errSource},
+		AllZones: true,
+	}
+
+	report, err := r.Reconcile(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if failed := report.Failed(); len(failed) != 1 || !errors.Is(failed[0].Err, errSource) {
+		t.Errorf("expected the zone to fail, got %v", failed)
+	}
+}
@@ -0,0 +1,72 @@
+package reconciler
+
+import (
+	"strings"
+
+	"github.com/miekg/dns"
+
+	"go.hollow.sh/dnscontroller/internal/provider"
+)
+
+// DefaultOwner is the owner written to the markers when none is configured
+const DefaultOwner = "default"
+
+// markerPrefix starts the first label of the TXT records marking the records
+// a reconciler owns
+const markerPrefix = "dnscontroller-"
+
+// markerTTL is the ttl of the markers, they are only read by the reconciler
+const markerTTL = 300
+
+// markerName returns the name of the TXT record marking the record of a name
+// and type. A wildcard can only be the first label, so it moves into the
+// marker label.
+func markerName(name, rtype string) string {
+	label := markerPrefix + strings.ToLower(rtype)
+
+	if strings.HasPrefix(name, "*.") {
+		return label + "-wildcard." + strings.TrimPrefix(name, "*.")
+	}
+
+	return label + "." + name
+}
+
+// isMarker reports whether a record is a marker, markers are never diffed as
+// records themselves
+func isMarker(rec *provider.Record) bool {
+	return rec.Type == "TXT" && strings.HasPrefix(rec.Name, markerPrefix)
+}
+
+// heritage is the text of the markers of this reconciler
+func (r *Reconciler) heritage() string {
+	owner := r.Owner
+	if owner == "" {
+		owner = DefaultOwner
+	}
+
+	return "heritage=dnscontroller,owner=" + owner
+}
+
+// marker returns the marker of the record of a name and type
+func (r *Reconciler) marker(name, rtype string) *provider.Record {
+	return provider.NewRecord(&dns.TXT{
+		Hdr: dns.RR_Header{Name: markerName(name, rtype), Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: markerTTL},
+		Txt: []string{r.heritage()},
+	})
+}
+
+// owns reports whether a marker was written by this reconciler, markers of
+// other owners and missing markers leave the record to someone else
+func (r *Reconciler) owns(marker *provider.Record) bool {
+	if marker == nil {
+		return false
+	}
+
+	for _, rr := range marker.RRs {
+		if txt, ok := rr.(*dns.TXT); ok && strings.Join(txt.Txt, "") == r.heritage() {
+			return true
+		}
+	}
+
+	return false
+}
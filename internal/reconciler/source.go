@@ -0,0 +1,59 @@
+package reconciler
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/miekg/dns"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"go.hollow.sh/dnscontroller/internal/models"
+	"go.hollow.sh/dnscontroller/internal/provider"
+	answer "go.hollow.sh/dnscontroller/pkg/api/v1/answers"
+	record "go.hollow.sh/dnscontroller/pkg/api/v1/records"
+)
+
+// DBSource reads the desired state from the records and answers tables
+type DBSource struct {
+	DB *sqlx.DB
+}
+
+// Records lists the records at or below the zone apex, records without
+// answers are left out so they are removed upstream
+func (s *DBSource) Records(ctx context.Context, zone string) ([]*provider.Record, error) {
+	dbRecords, err := models.Records(
+		record.QMInZone(zone),
+		qm.Load(models.RecordRels.Answers, answer.QMServed()),
+		qm.Load(models.RecordRels.Answers+"."+models.AnswerRels.AnswerDetail),
+		qm.OrderBy("record, record_type"),
+	).All(ctx, s.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*provider.Record, 0, len(dbRecords))
+
+	for _, dbRecord := range dbRecords {
+		rrs := make([]dns.RR, 0, len(dbRecord.R.Answers))
+
+		for _, dbAnswer := range dbRecord.R.Answers {
+			a := &answer.Answer{}
+			if err := a.FromDBModel(dbAnswer); err != nil {
+				return nil, err
+			}
+
+			rr, err := a.RR(dbRecord.Record)
+			if err != nil {
+				return nil, err
+			}
+
+			rrs = append(rrs, rr)
+		}
+
+		if len(rrs) > 0 {
+			records = append(records, provider.NewRecord(rrs...))
+		}
+	}
+
+	return records, nil
+}
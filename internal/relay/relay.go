@@ -0,0 +1,173 @@
+// Package relay publishes the outbox messages to a sink. Messages are
+// published in commit order and marked delivered after they are, so a message
+// the relay published before it stopped is published again; delivery is at
+// least once. When a message fails, the later messages of its record wait for
+// it and the other records carry on.
+//
+// Every replica of the server may run a relay, only the one holding the relay
+// lock publishes. Two relays publishing at once would publish every message
+// twice and the messages of a record out of order.
+package relay
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+
+	"go.hollow.sh/dnscontroller/internal/sink"
+	"go.hollow.sh/dnscontroller/pkg/api/v1/outbox"
+)
+
+// DefaultBatchSize is the number of messages read at once by default
+const DefaultBatchSize = 100
+
+// lockName is the row of the locks table the relays contend for
+const lockName = "outbox-relay"
+
+// Relay publishes the pending outbox messages to the sink
+type Relay struct {
+	Logger   *zap.SugaredLogger
+	DB       *sqlx.DB
+	Sink     sink.Sink
+	Interval time.Duration
+	// BatchSize is the number of messages read at once
+	BatchSize int
+	// Retention is how long delivered messages are kept, they are kept
+	// forever when it is 0
+	Retention time.Duration
+	// Lock is how long the relay holds the relay lock without renewing it,
+	// it should be well above the time a batch takes. The relay doesn't take
+	// the lock when it is 0, for when it is the only relay.
+	Lock time.Duration
+	// Holder identifies the relay in the lock, a random id is used when it
+	// is empty
+	Holder string
+}
+
+// Run relays every interval until the context is done
+func (r *Relay) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+
+	// Another relay can take over right away rather than once the lock
+	// expires
+	defer r.release()
+
+	for {
+		if err := r.RelayAll(ctx); err != nil {
+			r.Logger.Errorw("failed relaying outbox messages", "error", err)
+		}
+
+		if r.Retention > 0 {
+			if _, err := outbox.Prune(ctx, r.DB, time.Now().Add(-r.Retention)); err != nil {
+				r.Logger.Errorw("failed pruning delivered outbox messages", "error", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayAll publishes the pending messages batch by batch until it read them
+// all. The records waiting on a failed message are skipped until the next
+// run, the batches after it still publish the other records. Nothing is
+// published while another relay holds the lock.
+func (r *Relay) RelayAll(ctx context.Context) error {
+	// Records whose messages wait on a failed one
+	waiting := map[string]bool{}
+
+	var after *outbox.Message
+
+	for {
+		// The lock is renewed for every batch
+		held, err := r.hold(ctx)
+		if err != nil || !held {
+			return err
+		}
+
+		last, err := r.relay(ctx, after, waiting)
+		if err != nil || last == nil {
+			return err
+		}
+
+		after = last
+	}
+}
+
+// relay publishes a batch of the pending messages committed after the given
+// one, and returns the last message it read
+func (r *Relay) relay(ctx context.Context, after *outbox.Message, waiting map[string]bool) (*outbox.Message, error) {
+	batchSize := r.BatchSize
+	if batchSize < 1 {
+		batchSize = DefaultBatchSize
+	}
+
+	messages, err := outbox.Pending(ctx, r.DB, after, batchSize)
+	if err != nil || len(messages) == 0 {
+		return nil, err
+	}
+
+	for _, m := range messages {
+		if waiting[m.Key()] {
+			continue
+		}
+
+		if err := r.Sink.Publish(ctx, m); err != nil {
+			r.Logger.Warnw("failed publishing outbox message", "message", m.UUID, "record", m.Key(), "error", err)
+
+			waiting[m.Key()] = true
+
+			continue
+		}
+
+		if err := m.MarkDelivered(ctx, r.DB); err != nil {
+			return nil, err
+		}
+	}
+
+	return messages[len(messages)-1], nil
+}
+
+// hold takes or renews the relay lock and reports whether the relay holds
+// it. The lock is taken over once its holder stopped renewing it.
+func (r *Relay) hold(ctx context.Context) (bool, error) {
+	if r.Lock <= 0 {
+		return true, nil
+	}
+
+	if r.Holder == "" {
+		r.Holder = uuid.NewString()
+	}
+
+	res, err := r.DB.ExecContext(ctx,
+		"INSERT INTO locks (name, holder, expires_at) VALUES ($1, $2, now() + $3::INT8 * INTERVAL '1 millisecond') "+
+			"ON CONFLICT (name) DO UPDATE SET holder = excluded.holder, expires_at = excluded.expires_at "+
+			"WHERE locks.holder = excluded.holder OR locks.expires_at < now()",
+		lockName, r.Holder, r.Lock.Milliseconds(),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := res.RowsAffected()
+
+	return n == 1, err
+}
+
+// release gives the relay lock up if the relay holds it
+func (r *Relay) release() {
+	if r.Lock <= 0 || r.Holder == "" {
+		return
+	}
+
+	if _, err := r.DB.Exec("DELETE FROM locks WHERE name = $1 AND holder = $2", lockName, r.Holder); err != nil {
+		r.Logger.Errorw("failed releasing the outbox relay lock", "error", err)
+	}
+}
@@ -0,0 +1,48 @@
+// Package memory is an in-memory sink, it backs the relay tests
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"go.hollow.sh/dnscontroller/pkg/api/v1/outbox"
+)
+
+// Sink keeps the messages published to it in memory
+type Sink struct {
+	mu       sync.Mutex
+	messages []*outbox.Message
+	// Fail is returned by the publishes of the messages it is called with
+	// when it returns an error, so tests can make a record's messages fail
+	Fail func(m *outbox.Message) error
+}
+
+// New returns an empty sink
+func New() *Sink {
+	return &Sink{}
+}
+
+// Publish keeps the message unless Fail returns an error for it
+func (s *Sink) Publish(ctx context.Context, m *outbox.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.Fail != nil {
+		if err := s.Fail(m); err != nil {
+			return err
+		}
+	}
+
+	s.messages = append(s.messages, m)
+
+	return nil
+}
+
+// Messages returns the messages published so far, in the order they were
+// published
+func (s *Sink) Messages() []*outbox.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*outbox.Message{}, s.messages...)
+}
@@ -0,0 +1,17 @@
+// Package sink defines the interface to the brokers outbox messages are
+// published to by the relay
+package sink
+
+import (
+	"context"
+
+	"go.hollow.sh/dnscontroller/pkg/api/v1/outbox"
+)
+
+// Sink publishes outbox messages
+type Sink interface {
+	// Publish sends a message. A message that fails is published again, as
+	// are the ones the relay published before it stopped, so a sink sees
+	// messages at least once and keys them by their uuid to drop duplicates.
+	Publish(ctx context.Context, m *outbox.Message) error
+}
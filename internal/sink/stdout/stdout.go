@@ -0,0 +1,36 @@
+// Package stdout is a sink writing each message as a line of JSON, to stdout
+// unless another writer is given
+package stdout
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	"go.hollow.sh/dnscontroller/pkg/api/v1/outbox"
+)
+
+// Sink writes messages to a writer
+type Sink struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// New returns a sink writing to w, or to stdout when w is nil
+func New(w io.Writer) *Sink {
+	if w == nil {
+		w = os.Stdout
+	}
+
+	return &Sink{enc: json.NewEncoder(w)}
+}
+
+// Publish writes the message as a line of JSON
+func (s *Sink) Publish(ctx context.Context, m *outbox.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.enc.Encode(m)
+}
@@ -0,0 +1,32 @@
+package stdout
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"go.hollow.sh/dnscontroller/pkg/api/v1/outbox"
+)
+
+func TestPublish(t *testing.T) {
+	var buf bytes.Buffer
+
+	s := New(&buf)
+
+	for _, event := range []string{"record.create", "answer.create"} {
+		if err := s.Publish(context.Background(), &outbox.Message{Event: event, Record: "www.example.com.", RecordType: "A"}); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 2 {
+		t.Fatalf("wrote %q, want 2 lines", buf.String())
+	}
+
+	m := &outbox.Message{}
+	if err := json.Unmarshal(lines[1], m); err != nil || m.Event != "answer.create" || m.Key() != "www.example.com./A" {
+		t.Errorf("second line = %s, %v, want the answer.create of www.example.com. A", lines[1], err)
+	}
+}
@@ -29,4 +29,8 @@ func RegisterOIDCFlags(cmd *cobra.Command) {
 	MustBindPFlag("oidc.claims.roles", cmd.Flags().Lookup("oidc-roles-claim"))
 	cmd.Flags().String("oidc-username-claim", "", "additional fields to output in logs from the JWT token, ex (email)")
 	MustBindPFlag("oidc.claims.username", cmd.Flags().Lookup("oidc-username-claim"))
+	cmd.Flags().String("oidc-owner-claim", "sub", "claim mapping callers to the owners they may change, sub or username")
+	MustBindPFlag("oidc.claims.owner", cmd.Flags().Lookup("oidc-owner-claim"))
+	cmd.Flags().Bool("oidc-claim-unowned", false, "let the first caller changing an owner without a principal claim it")
+	MustBindPFlag("oidc.claim-unowned", cmd.Flags().Lookup("oidc-claim-unowned"))
 }
@@ -0,0 +1,686 @@
+// Package answer wraps the CRUD operations for a models.Answer and its models.AnswerDetail
+package answer
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach-go/v2/crdb/crdbsqlx"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"go.hollow.sh/dnscontroller/internal/models"
+	"go.hollow.sh/dnscontroller/pkg/api/v1/audit"
+	"go.hollow.sh/dnscontroller/pkg/api/v1/outbox"
+	owner "go.hollow.sh/dnscontroller/pkg/api/v1/owners"
+	record "go.hollow.sh/dnscontroller/pkg/api/v1/records"
+)
+
+func qmAnswerIdentity(recordID, ownerID, target, atype string) qm.QueryMod {
+	mods := []qm.QueryMod{}
+
+	mods = append(mods, qm.Where("record_id=?", recordID))
+	mods = append(mods, qm.Where("owner_id=?", ownerID))
+	mods = append(mods, qm.Where("target=?", target))
+	mods = append(mods, qm.Where("type=?", atype))
+
+	return qm.Expr(mods...)
+}
+
+// QMServed matches the answers that are served, unhealthy answers and
+// answers whose lease expired are withdrawn
+func QMServed() qm.QueryMod {
+	return qm.Expr(
+		models.AnswerWhere.Healthy.EQ(true),
+		qm.Where("(answers.expires_at IS NULL OR answers.expires_at > ?)", time.Now()),
+	)
+}
+
+// List returns all the answers for a record
+func List(ctx context.Context, db *sqlx.DB, r *record.Record) ([]*Answer, error) {
+	dbAnswers, err := models.Answers(
+		qm.Where("record_id=?", r.UUID.String()),
+		qm.Load(models.AnswerRels.Owner),
+		qm.Load(models.AnswerRels.AnswerDetail),
+		qm.OrderBy(models.AnswerColumns.CreatedAt),
+	).All(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	answers := make([]*Answer, 0, len(dbAnswers))
+
+	for _, dbAnswer := range dbAnswers {
+		a := &Answer{}
+		if err := a.FromDBModel(dbAnswer); err != nil {
+			return nil, err
+		}
+
+		answers = append(answers, a)
+	}
+
+	return answers, nil
+}
+
+// Delete removes an answer from the DB, the details are removed by the cascade
+func (a *Answer) Delete(ctx context.Context, db *sqlx.DB) error {
+	return crdbsqlx.ExecuteTx(ctx, db, nil, func(tx *sqlx.Tx) error {
+		if err := a.checkPrecondition(ctx, tx); err != nil {
+			return err
+		}
+
+		return a.delete(ctx, tx)
+	})
+}
+
+// delete removes the answer with exec
+func (a *Answer) delete(ctx context.Context, exec boil.ContextExecutor) error {
+	dbAnswer, err := a.findOwned(ctx, exec)
+	if err != nil {
+		return err
+	}
+
+	before := &Answer{recordName: a.recordName}
+	if err := before.FromDBModel(dbAnswer); err != nil {
+		return err
+	}
+
+	if _, err := dbAnswer.Delete(ctx, exec); err != nil {
+		return err
+	}
+
+	if err := record.BumpSerial(ctx, exec, dbAnswer.RecordID); err != nil {
+		return err
+	}
+
+	if err := outbox.WriteChange(ctx, exec, before.outboxMessage(audit.AnswerDelete), before, nil); err != nil {
+		return err
+	}
+
+	return audit.Write(ctx, exec, before.auditEvent(audit.AnswerDelete), before, nil)
+}
+
+// DeleteAll deletes the answers matching the mods with exec, each deletion is
+// recorded in the audit log
+func DeleteAll(ctx context.Context, exec boil.ContextExecutor, mods ...qm.QueryMod) error {
+	mods = append(mods,
+		qm.Load(models.AnswerRels.Record),
+		qm.Load(models.AnswerRels.Owner),
+		qm.Load(models.AnswerRels.AnswerDetail),
+	)
+
+	dbAnswers, err := models.Answers(mods...).All(ctx, exec)
+	if err != nil || len(dbAnswers) == 0 {
+		return err
+	}
+
+	bumped := map[string]bool{}
+
+	for _, dbAnswer := range dbAnswers {
+		before := &Answer{recordName: dbAnswer.R.Record.Record}
+		if err := before.FromDBModel(dbAnswer); err != nil {
+			return err
+		}
+
+		if err := audit.Write(ctx, exec, before.auditEvent(audit.AnswerDelete), before, nil); err != nil {
+			return err
+		}
+
+		if err := outbox.WriteChange(ctx, exec, before.outboxMessage(audit.AnswerDelete), before, nil); err != nil {
+			return err
+		}
+
+		if !bumped[dbAnswer.RecordID] {
+			bumped[dbAnswer.RecordID] = true
+
+			if err := record.BumpSerial(ctx, exec, dbAnswer.RecordID); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = dbAnswers.DeleteAll(ctx, exec)
+
+	return err
+}
+
+// DeleteRecord removes a record from the DB along with its answers, each
+// answer gets its own event rather than going with the cascade
+func DeleteRecord(ctx context.Context, db *sqlx.DB, r *record.Record) error {
+	return crdbsqlx.ExecuteTx(ctx, db, nil, func(tx *sqlx.Tx) error {
+		if err := r.CheckPrecondition(ctx, tx); err != nil {
+			return err
+		}
+
+		if err := DeleteRecordAnswers(ctx, tx, r); err != nil {
+			return err
+		}
+
+		return r.Remove(ctx, tx)
+	})
+}
+
+// DeleteRecordAnswers deletes the answers of a record with exec, which lets
+// record.Remove delete it
+func DeleteRecordAnswers(ctx context.Context, exec boil.ContextExecutor, r *record.Record) error {
+	if err := r.Find(ctx, exec); err != nil {
+		return err
+	}
+
+	return DeleteAll(ctx, exec, models.AnswerWhere.RecordID.EQ(r.UUID.String()))
+}
+
+// DeleteOwner removes an owner from the DB along with its answers, the
+// records left without answers are kept
+func DeleteOwner(ctx context.Context, db *sqlx.DB, o *owner.Owner) error {
+	return crdbsqlx.ExecuteTx(ctx, db, nil, func(tx *sqlx.Tx) error {
+		if err := o.FindByUUID(ctx, tx); err != nil {
+			return err
+		}
+
+		if err := DeleteAll(ctx, tx, models.AnswerWhere.OwnerID.EQ(o.UUID.String())); err != nil {
+			return err
+		}
+
+		return o.Remove(ctx, tx)
+	})
+}
+
+// Renew extends the lease of every leased answer of the owner by its lease
+// from now and returns how many were renewed. Answers that already expired
+// are left for the reaper.
+func Renew(ctx context.Context, db *sqlx.DB, o *owner.Owner) (int64, error) {
+	var renewed int64
+
+	err := crdbsqlx.ExecuteTx(ctx, db, nil, func(tx *sqlx.Tx) error {
+		if err := o.FindByUUID(ctx, tx); err != nil {
+			return err
+		}
+
+		dbAnswers, err := models.Answers(
+			qm.Where("owner_id=?", o.UUID.String()),
+			qm.Where("expires_at > ?", time.Now()),
+			qm.Load(models.AnswerRels.Record),
+			qm.Load(models.AnswerRels.Owner),
+			qm.Load(models.AnswerRels.AnswerDetail),
+		).All(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		for _, dbAnswer := range dbAnswers {
+			if err := renew(ctx, tx, dbAnswer); err != nil {
+				return err
+			}
+		}
+
+		renewed = int64(len(dbAnswers))
+
+		return nil
+	})
+
+	return renewed, err
+}
+
+// renew extends the lease of a stored answer by its lease from now, the
+// answer must have its record, owner and details loaded
+func renew(ctx context.Context, exec boil.ContextExecutor, dbAnswer *models.Answer) error {
+	before := &Answer{recordName: dbAnswer.R.Record.Record}
+	if err := before.FromDBModel(dbAnswer); err != nil {
+		return err
+	}
+
+	dbAnswer.ExpiresAt = null.TimeFrom(time.Now().Add(time.Duration(dbAnswer.LeaseSeconds.Int64) * time.Second))
+
+	// A renewal doesn't change the answer itself
+	if _, err := dbAnswer.Update(boil.SkipTimestamps(ctx), exec, boil.Whitelist(models.AnswerColumns.ExpiresAt)); err != nil {
+		return err
+	}
+
+	after := &Answer{recordName: before.recordName}
+	if err := after.FromDBModel(dbAnswer); err != nil {
+		return err
+	}
+
+	return audit.Write(ctx, exec, after.auditEvent(audit.AnswerRenew), before, after)
+}
+
+// CreateOrUpdate is the upsert function, an existing answer has its ttl and
+// details replaced. The record, owner, answer and details are written in one
+// transaction, the record and owner are created if they don't exist yet.
+func (a *Answer) CreateOrUpdate(ctx context.Context, db *sqlx.DB) error {
+	if err := a.validate(); err != nil {
+		return err
+	}
+
+	// The owner is filled in from the DB, a retry must authorize against the
+	// principal the caller asked for
+	principal := a.Owner.Principal
+
+	return crdbsqlx.ExecuteTx(ctx, db, nil, func(tx *sqlx.Tx) error {
+		a.Owner.Principal = principal
+
+		if err := a.checkPrecondition(ctx, tx); err != nil {
+			return err
+		}
+
+		return a.upsert(ctx, tx)
+	})
+}
+
+// upsert is CreateOrUpdate with exec
+func (a *Answer) upsert(ctx context.Context, exec boil.ContextExecutor) error {
+	if a.record != nil {
+		if err := a.record.FindOrCreate(ctx, exec); err != nil {
+			return err
+		}
+
+		a.recordID = a.record.UUID.String()
+	}
+
+	// Both branches check the stored owner against the principal the write
+	// was authorized for
+	err := a.update(ctx, exec)
+	if errors.Is(err, sql.ErrNoRows) {
+		return a.create(ctx, exec)
+	}
+
+	return err
+}
+
+// Find looks the answer up by record, owner, target and type
+func (a *Answer) Find(ctx context.Context, db *sqlx.DB) error {
+	dbAnswer, err := a.findDBModel(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	return a.FromDBModel(dbAnswer)
+}
+
+// Create inserts an answer and its details, the owner is created if it
+// doesn't exist yet
+func (a *Answer) Create(ctx context.Context, db *sqlx.DB) error {
+	if err := a.validate(); err != nil {
+		return err
+	}
+
+	principal := a.Owner.Principal
+
+	return crdbsqlx.ExecuteTx(ctx, db, nil, func(tx *sqlx.Tx) error {
+		a.Owner.Principal = principal
+
+		if err := a.checkPrecondition(ctx, tx); err != nil {
+			return err
+		}
+
+		return a.create(ctx, tx)
+	})
+}
+
+// create inserts the answer with exec, the record must exist
+func (a *Answer) create(ctx context.Context, exec boil.ContextExecutor) error {
+	// An owner created concurrently may belong to another caller than the
+	// one it was authorized for
+	principal := a.Owner.Principal
+
+	if err := a.Owner.FindOrCreate(ctx, exec); err != nil {
+		return err
+	}
+
+	if principal != "" && a.Owner.Principal != principal {
+		return owner.ErrorNotOwner
+	}
+
+	if err := a.validateExclusive(ctx, exec); err != nil {
+		return err
+	}
+
+	dbAnswer, dbDetail := a.ToDBModel()
+	dbAnswer.OwnerID = a.Owner.UUID.String()
+
+	if err := dbAnswer.Insert(ctx, exec, boil.Infer()); err != nil {
+		return err
+	}
+
+	if dbDetail != nil {
+		if err := dbAnswer.SetAnswerDetail(ctx, exec, true, dbDetail); err != nil {
+			return err
+		}
+	}
+
+	// Set the values back
+	if err := a.FromDBModel(dbAnswer); err != nil {
+		return err
+	}
+
+	if err := record.BumpSerial(ctx, exec, dbAnswer.RecordID); err != nil {
+		return err
+	}
+
+	if err := outbox.WriteChange(ctx, exec, a.outboxMessage(audit.AnswerCreate), nil, a); err != nil {
+		return err
+	}
+
+	return audit.Write(ctx, exec, a.auditEvent(audit.AnswerCreate), nil, a)
+}
+
+// Update replaces the ttl and details of an existing answer
+func (a *Answer) Update(ctx context.Context, db *sqlx.DB) error {
+	if err := a.validate(); err != nil {
+		return err
+	}
+
+	return crdbsqlx.ExecuteTx(ctx, db, nil, func(tx *sqlx.Tx) error {
+		if err := a.checkPrecondition(ctx, tx); err != nil {
+			return err
+		}
+
+		return a.update(ctx, tx)
+	})
+}
+
+// checkPrecondition checks the precondition of the context against the
+// record of the answer, answers not made from a record have none to check
+func (a *Answer) checkPrecondition(ctx context.Context, exec boil.ContextExecutor) error {
+	if a.record == nil {
+		return nil
+	}
+
+	return a.record.CheckPrecondition(ctx, exec)
+}
+
+// update replaces the ttl and details of the answer with exec
+func (a *Answer) update(ctx context.Context, exec boil.ContextExecutor) error {
+	dbAnswer, err := a.findOwned(ctx, exec)
+	if err != nil {
+		return err
+	}
+
+	// The stored answer is changed in place below, so it's marshaled now
+	before, err := a.snapshot(dbAnswer)
+	if err != nil {
+		return err
+	}
+
+	changed, dbDetail := a.ToDBModel()
+	dbAnswer.TTL = changed.TTL
+	dbAnswer.HasDetails = changed.HasDetails
+	dbAnswer.LeaseSeconds = changed.LeaseSeconds
+	dbAnswer.ExpiresAt = changed.ExpiresAt
+
+	// The health columns belong to the checker
+	update := boil.Whitelist(
+		models.AnswerColumns.TTL,
+		models.AnswerColumns.HasDetails,
+		models.AnswerColumns.LeaseSeconds,
+		models.AnswerColumns.ExpiresAt,
+		models.AnswerColumns.UpdatedAt,
+	)
+
+	if _, err := dbAnswer.Update(ctx, exec, update); err != nil {
+		return err
+	}
+
+	current := dbAnswer.R.AnswerDetail
+
+	switch {
+	case dbDetail == nil && current != nil:
+		if _, err := current.Delete(ctx, exec); err != nil {
+			return err
+		}
+
+		dbAnswer.R.AnswerDetail = nil
+	case dbDetail != nil && current != nil:
+		current.Port = dbDetail.Port
+		current.Priority = dbDetail.Priority
+		current.Protocol = dbDetail.Protocol
+		current.Weight = dbDetail.Weight
+		current.Flags = dbDetail.Flags
+		current.Tag = dbDetail.Tag
+
+		if _, err := current.Update(ctx, exec, boil.Infer()); err != nil {
+			return err
+		}
+	case dbDetail != nil:
+		if err := dbAnswer.SetAnswerDetail(ctx, exec, true, dbDetail); err != nil {
+			return err
+		}
+	}
+
+	if err := a.FromDBModel(dbAnswer); err != nil {
+		return err
+	}
+
+	if err := record.BumpSerial(ctx, exec, dbAnswer.RecordID); err != nil {
+		return err
+	}
+
+	if err := outbox.WriteChange(ctx, exec, a.outboxMessage(audit.AnswerUpdate), before, a); err != nil {
+		return err
+	}
+
+	return audit.Write(ctx, exec, a.auditEvent(audit.AnswerUpdate), before, a)
+}
+
+// snapshot returns the stored answer as the API returns it
+func (a *Answer) snapshot(dbAnswer *models.Answer) (json.RawMessage, error) {
+	stored := &Answer{}
+	if err := stored.FromDBModel(dbAnswer); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(stored)
+}
+
+// auditEvent describes a mutation of the answer for the audit log
+func (a *Answer) auditEvent(operation string) *audit.Event {
+	e := &audit.Event{Operation: operation, Record: a.recordName, RecordType: a.Type}
+
+	if a.Owner != nil {
+		e.OwnerID = a.Owner.UUID.String()
+	}
+
+	return e
+}
+
+// outboxMessage describes a change to the answer for the relay
+func (a *Answer) outboxMessage(event string) *outbox.Message {
+	m := &outbox.Message{Event: event, Record: a.recordName, RecordType: a.Type}
+
+	if a.Owner != nil {
+		m.OwnerID = a.Owner.UUID.String()
+	}
+
+	return m
+}
+
+// findDBModel returns the stored answer with its owner and details loaded
+func (a *Answer) findDBModel(ctx context.Context, exec boil.ContextExecutor) (*models.Answer, error) {
+	if err := a.validateIdentity(); err != nil {
+		return nil, err
+	}
+
+	if err := a.Owner.Find(ctx, exec); err != nil {
+		return nil, err
+	}
+
+	return models.Answers(
+		qmAnswerIdentity(a.recordID, a.Owner.UUID.String(), a.Target, a.Type),
+		qm.Load(models.AnswerRels.Owner),
+		qm.Load(models.AnswerRels.AnswerDetail),
+	).One(ctx, exec)
+}
+
+// findOwned is findDBModel for a write, the owner it fills in from the DB
+// must still belong to the principal the write was authorized for. The
+// principal is put back when it fails, so a create that follows or a retried
+// transaction checks it again.
+func (a *Answer) findOwned(ctx context.Context, exec boil.ContextExecutor) (*models.Answer, error) {
+	if a.Owner == nil {
+		return nil, ErrorNoOwner
+	}
+
+	principal := a.Owner.Principal
+
+	dbAnswer, err := a.findDBModel(ctx, exec)
+	if err == nil && principal != "" && a.Owner.Principal != principal {
+		err = owner.ErrorNotOwner
+	}
+
+	if err != nil {
+		a.Owner.Principal = principal
+		return nil, err
+	}
+
+	return dbAnswer, nil
+}
+
+// FromDBModel converts a db type to an api type, the owner and details are
+// only set when they were loaded
+func (a *Answer) FromDBModel(dbT *models.Answer) error {
+	a.Target = dbT.Target
+	a.Type = dbT.Type
+	a.TTL = dbT.TTL
+	a.CreatedAt = dbT.CreatedAt
+	a.UpdatedAt = dbT.UpdatedAt
+	a.recordID = dbT.RecordID
+	a.Lease = dbT.LeaseSeconds.Ptr()
+	a.ExpiresAt = dbT.ExpiresAt.Ptr()
+	a.Health = &Health{
+		Healthy:   dbT.Healthy,
+		Successes: dbT.HealthSuccesses,
+		Failures:  dbT.HealthFailures,
+		CheckedAt: dbT.HealthCheckedAt.Ptr(),
+		Error:     dbT.HealthError.String,
+	}
+
+	var err error
+
+	a.UUID, err = uuid.Parse(dbT.ID)
+	if err != nil {
+		return err
+	}
+
+	if dbT.R == nil {
+		return nil
+	}
+
+	if dbOwner := dbT.R.Owner; dbOwner != nil {
+		a.Owner = &owner.Owner{}
+		if err := a.Owner.FromDBModel(dbOwner); err != nil {
+			return err
+		}
+	}
+
+	a.Port, a.Priority, a.Weight, a.Protocol = nil, nil, nil, ""
+	a.Flags, a.Tag = nil, ""
+
+	if d := dbT.R.AnswerDetail; d != nil {
+		a.Port = d.Port.Ptr()
+		a.Priority = d.Priority.Ptr()
+		a.Weight = d.Weight.Ptr()
+		a.Protocol = d.Protocol.String
+		a.Flags = d.Flags.Ptr()
+		a.Tag = d.Tag.String
+	}
+
+	return nil
+}
+
+// ToDBModel converts the api type to db types, the detail is nil when the
+// answer has no details. The owner id is left for the caller to resolve. A
+// leased answer expires one lease from now.
+func (a *Answer) ToDBModel() (*models.Answer, *models.AnswerDetail) {
+	dbModel := &models.Answer{
+		Target:       a.Target,
+		Type:         a.Type,
+		TTL:          a.TTL,
+		HasDetails:   a.hasDetails(),
+		LeaseSeconds: null.Int64FromPtr(a.Lease),
+		RecordID:     a.recordID,
+		CreatedAt:    a.CreatedAt,
+		UpdatedAt:    a.UpdatedAt,
+	}
+
+	if a.Lease != nil {
+		dbModel.ExpiresAt = null.TimeFrom(time.Now().Add(time.Duration(*a.Lease) * time.Second))
+	}
+
+	if a.UUID.String() != uuid.Nil.String() {
+		dbModel.ID = a.UUID.String()
+	}
+
+	if !dbModel.HasDetails {
+		return dbModel, nil
+	}
+
+	return dbModel, &models.AnswerDetail{
+		Port:     null.Int64FromPtr(a.Port),
+		Priority: null.Int64FromPtr(a.Priority),
+		Protocol: null.NewString(a.Protocol, a.Protocol != ""),
+		Weight:   null.Int64FromPtr(a.Weight),
+		Flags:    null.Int64FromPtr(a.Flags),
+		Tag:      null.NewString(a.Tag, a.Tag != ""),
+	}
+}
+
+// NewAnswer creates an answer for a record from the request body, the record
+// is created along with the answer by CreateOrUpdate if it isn't stored yet.
+// Only the owner and target are validated since deletes don't carry the
+// rest of the answer.
+func NewAnswer(c *gin.Context, r *record.Record) (*Answer, error) {
+	req := &Request{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		return nil, err
+	}
+
+	return newAnswer(req, r)
+}
+
+// newAnswer creates an answer for a record from a request
+func newAnswer(req *Request, r *record.Record) (*Answer, error) {
+	if req.Answer == nil {
+		return nil, ErrorNoAnswer
+	}
+
+	a := req.Answer
+	a.Owner = req.Owner
+	a.Health = nil
+	a.ExpiresAt = nil
+	a.Type = r.Type
+	a.record = r
+	a.recordID = r.UUID.String()
+	a.recordName = r.Name
+
+	// Sanitize input, host names are stored like record names and text
+	// values are case sensitive
+	switch {
+	case hostTargetTypes()[a.Type] && a.Target != "" && a.Target != ".":
+		target, err := record.CanonicalName(a.Target)
+		if err != nil {
+			return nil, err
+		}
+
+		a.Target = target
+	case !textTargetTypes()[a.Type]:
+		a.Target = strings.ToLower(a.Target)
+	}
+
+	a.Protocol = strings.ToLower(a.Protocol)
+	a.Tag = strings.ToLower(a.Tag)
+
+	if err := a.validateIdentity(); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
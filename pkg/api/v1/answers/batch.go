@@ -0,0 +1,136 @@
+package answer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+
+	"go.hollow.sh/dnscontroller/pkg/api/v1/batch"
+	owner "go.hollow.sh/dnscontroller/pkg/api/v1/owners"
+	record "go.hollow.sh/dnscontroller/pkg/api/v1/records"
+)
+
+// Batch is a list of answer operations applied in one transaction
+type Batch struct {
+	Mode       string       `json:"mode"`
+	Operations []*Operation `json:"operations"`
+}
+
+// Operation creates, upserts or deletes the answer of an owner. Creating and
+// upserting create the record and owner when they don't exist yet, like POST
+// does.
+type Operation struct {
+	Op         string       `json:"op"`
+	Record     string       `json:"record"`
+	RecordType string       `json:"record_type"`
+	Owner      *owner.Owner `json:"owner"`
+	Answer     *Answer      `json:"answer"`
+}
+
+// NewBatch creates a batch from the request body and validates its mode and
+// size, the mode defaults to atomic. The operations are validated when they
+// are applied, so a best effort batch still applies the valid ones.
+func NewBatch(c *gin.Context) (*Batch, error) {
+	b := &Batch{}
+	if err := c.ShouldBindJSON(b); err != nil {
+		return nil, err
+	}
+
+	if b.Mode == "" {
+		b.Mode = batch.ModeAtomic
+	}
+
+	for i, op := range b.Operations {
+		if op == nil {
+			b.Operations[i] = &Operation{}
+		}
+	}
+
+	return b, batch.Validate(b.Mode, len(b.Operations))
+}
+
+// Validate checks the operation along with the record, owner and target of
+// its answer
+func (op *Operation) Validate() error {
+	_, err := op.answer()
+
+	return err
+}
+
+// answer returns the answer the operation writes. It is made from the
+// operation on every call, so a retried transaction starts over from what
+// was requested.
+func (op *Operation) answer() (*Answer, error) {
+	switch op.Op {
+	case batch.OpCreate, batch.OpUpsert, batch.OpDelete:
+	default:
+		return nil, batch.ErrorInvalidOperation
+	}
+
+	r, err := record.NewRecordFromName(op.Record, op.RecordType)
+	if err != nil {
+		return nil, err
+	}
+
+	if op.Owner == nil {
+		return nil, ErrorNoOwner
+	}
+
+	if op.Answer == nil {
+		return nil, ErrorNoAnswer
+	}
+
+	o := *op.Owner
+	a := *op.Answer
+
+	return newAnswer(&Request{Owner: &o, Answer: &a}, r)
+}
+
+// Apply writes the operation with exec, it is a batch.Step
+func (op *Operation) Apply(ctx context.Context, exec boil.ContextExecutor) error {
+	a, err := op.answer()
+	if err != nil {
+		return err
+	}
+
+	switch op.Op {
+	case batch.OpCreate:
+		if err := a.validate(); err != nil {
+			return err
+		}
+
+		if err := a.record.FindOrCreate(ctx, exec); err != nil {
+			return err
+		}
+
+		a.recordID = a.record.UUID.String()
+
+		_, err := a.findOwned(ctx, exec)
+		if err == nil {
+			return ErrorAnswerExists
+		}
+
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		return a.create(ctx, exec)
+	case batch.OpUpsert:
+		if err := a.validate(); err != nil {
+			return err
+		}
+
+		return a.upsert(ctx, exec)
+	default:
+		if err := a.record.Find(ctx, exec); err != nil {
+			return err
+		}
+
+		a.recordID = a.record.UUID.String()
+
+		return a.delete(ctx, exec)
+	}
+}
@@ -0,0 +1,41 @@
+package answer
+
+import "errors"
+
+var (
+	// ErrorInvalidAnswer is a generic invalid response
+	ErrorInvalidAnswer = errors.New("invalid answer format")
+	// ErrorNoAnswer is when a request doesn't have an answer
+	ErrorNoAnswer = errors.New("no answer")
+	// ErrorNoOwner is when a request / answer doesn't have an owner
+	ErrorNoOwner = errors.New("no owner")
+	// ErrorAnswerExists is when a batch creates an answer the owner already
+	// has
+	ErrorAnswerExists = errors.New("answer already exists")
+	// ErrorNoSyncAnswers is when a sync request doesn't have an answers list
+	ErrorNoSyncAnswers = errors.New("sync has no answers list, an empty list deletes every answer")
+	// ErrorDuplicateAnswer is when a sync has the same answer twice
+	ErrorDuplicateAnswer = errors.New("sync has the same answer more than once")
+	// ErrorNoTarget is when a request / answer doesn't have a target
+	ErrorNoTarget = errors.New("no answer target")
+	// ErrorInvalidTarget is when a target doesn't match the record type
+	ErrorInvalidTarget = errors.New("invalid answer target for record type")
+	// ErrorInvalidTTL is when a ttl is negative
+	ErrorInvalidTTL = errors.New("invalid answer ttl")
+	// ErrorInvalidLease is when a lease isn't positive
+	ErrorInvalidLease = errors.New("answer lease must be positive")
+	// ErrorNoPort is when a SRV answer doesn't have a port
+	ErrorNoPort = errors.New("no answer port")
+	// ErrorInvalidDetails is when a port, priority or weight is out of range
+	ErrorInvalidDetails = errors.New("answer port, priority and weight must be between 0 and 65535")
+	// ErrorNoPriority is when a MX answer doesn't have a preference
+	ErrorNoPriority = errors.New("no answer priority")
+	// ErrorInvalidFlags is when CAA flags are out of range
+	ErrorInvalidFlags = errors.New("answer flags must be between 0 and 255")
+	// ErrorInvalidTag is when a CAA tag is missing or malformed
+	ErrorInvalidTag = errors.New("answer tag must be 1 to 15 letters and digits")
+	// ErrorInvalidText is when a TXT or CAA value is empty or too long
+	ErrorInvalidText = errors.New("invalid answer text")
+	// ErrorCNAMETarget is when a CNAME record would have more than one target
+	ErrorCNAMETarget = errors.New("a CNAME record can only have one target")
+)
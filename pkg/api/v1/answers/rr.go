@@ -0,0 +1,163 @@
+package answer
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/miekg/dns"
+
+	record "go.hollow.sh/dnscontroller/pkg/api/v1/records"
+)
+
+// maxCharacterString is the longest character-string in a TXT record
+// (RFC 1035 3.3)
+const maxCharacterString = 255
+
+// RR converts the answer to a resource record for the given record name
+func (a *Answer) RR(name string) (dns.RR, error) {
+	hdr := dns.RR_Header{
+		Name:   name,
+		Rrtype: dns.StringToType[a.Type],
+		Class:  dns.ClassINET,
+		Ttl:    uint32(a.TTL),
+	}
+
+	switch a.Type {
+	case "A":
+		ip := net.ParseIP(a.Target).To4()
+		if ip == nil {
+			return nil, ErrorInvalidTarget
+		}
+
+		return &dns.A{Hdr: hdr, A: ip}, nil
+	case "AAAA":
+		ip := net.ParseIP(a.Target)
+		if ip == nil {
+			return nil, ErrorInvalidTarget
+		}
+
+		return &dns.AAAA{Hdr: hdr, AAAA: ip}, nil
+	case "CAA":
+		return &dns.CAA{Hdr: hdr, Flag: uint8(detail(a.Flags)), Tag: a.Tag, Value: escapeText(a.Target)}, nil
+	case "CNAME":
+		return &dns.CNAME{Hdr: hdr, Target: a.Target}, nil
+	case "MX":
+		return &dns.MX{Hdr: hdr, Preference: uint16(detail(a.Priority)), Mx: a.Target}, nil
+	case "NS":
+		return &dns.NS{Hdr: hdr, Ns: a.Target}, nil
+	case "PTR":
+		return &dns.PTR{Hdr: hdr, Ptr: a.Target}, nil
+	case "SRV":
+		return &dns.SRV{
+			Hdr:      hdr,
+			Priority: uint16(detail(a.Priority)),
+			Weight:   uint16(detail(a.Weight)),
+			Port:     uint16(detail(a.Port)),
+			Target:   a.Target,
+		}, nil
+	case "TXT":
+		return &dns.TXT{Hdr: hdr, Txt: splitText(a.Target)}, nil
+	}
+
+	return nil, ErrorInvalidAnswer
+}
+
+// NewAnswerFromRR converts a resource record to an answer, the inverse of RR.
+// The record name is the header name of the resource record.
+func NewAnswerFromRR(rr dns.RR) (*Answer, error) {
+	hdr := rr.Header()
+	a := &Answer{Type: dns.TypeToString[hdr.Rrtype], TTL: int64(hdr.Ttl)}
+
+	switch rr := rr.(type) {
+	case *dns.A:
+		a.Target = rr.A.String()
+	case *dns.AAAA:
+		a.Target = rr.AAAA.String()
+	case *dns.CAA:
+		a.Target = unescapeText(rr.Value)
+		a.Flags = int64Ptr(int64(rr.Flag))
+		a.Tag = rr.Tag
+	case *dns.CNAME:
+		a.Target = rr.Target
+	case *dns.MX:
+		a.Target = rr.Mx
+		a.Priority = int64Ptr(int64(rr.Preference))
+	case *dns.NS:
+		a.Target = rr.Ns
+	case *dns.PTR:
+		a.Target = rr.Ptr
+	case *dns.SRV:
+		a.Target = rr.Target
+		a.Priority = int64Ptr(int64(rr.Priority))
+		a.Weight = int64Ptr(int64(rr.Weight))
+		a.Port = int64Ptr(int64(rr.Port))
+	case *dns.TXT:
+		for _, txt := range rr.Txt {
+			a.Target += unescapeText(txt)
+		}
+	default:
+		return nil, record.ErrorUnsupportedType
+	}
+
+	return a, nil
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func detail(v *int64) int64 {
+	if v == nil {
+		return 0
+	}
+
+	return *v
+}
+
+// splitText splits a TXT value into character-strings
+func splitText(s string) []string {
+	txt := []string{}
+
+	for len(s) > maxCharacterString {
+		txt = append(txt, escapeText(s[:maxCharacterString]))
+		s = s[maxCharacterString:]
+	}
+
+	return append(txt, escapeText(s))
+}
+
+// escapeText escapes a value the way miekg/dns expects text in its structs
+func escapeText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
+
+// unescapeText is the inverse of escapeText. It also decodes the \DDD
+// escapes miekg/dns keeps for bytes that aren't printable, and \X for any
+// other quoted character, as RFC 1035 5.1 has them.
+func unescapeText(s string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		switch {
+		case i+3 < len(s) && isDigit(s[i+1]) && isDigit(s[i+2]) && isDigit(s[i+3]):
+			n, _ := strconv.Atoi(s[i+1 : i+4])
+			b.WriteByte(byte(n))
+			i += 3
+		case i+1 < len(s):
+			b.WriteByte(s[i+1])
+			i++
+		}
+	}
+
+	return b.String()
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,74 @@
+package answer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/miekg/dns"
+)
+
+func TestNewAnswerFromRR(t *testing.T) {
+	tests := []string{
+		"www.example.com.\t300\tIN\tA\t192.0.2.1",
+		"www.example.com.\t300\tIN\tAAAA\t2001:db8::1",
+		"example.com.\t3600\tIN\tCAA\t0 issue \"letsencrypt.org\"",
+		"alias.example.com.\t300\tIN\tCNAME\twww.example.com.",
+		"example.com.\t300\tIN\tMX\t10 mail.example.com.",
+		"example.com.\t300\tIN\tNS\tns1.example.com.",
+		"1.2.0.192.in-addr.arpa.\t300\tIN\tPTR\twww.example.com.",
+		"_web._tcp.example.com.\t60\tIN\tSRV\t10 20 443 web1.example.com.",
+		"example.com.\t300\tIN\tTXT\t\"v=spf1 \\\"quoted\\\" -all\"",
+		"example.com.\t300\tIN\tTXT\t\"caf\\195\\169\\009tab\"",
+		"long.example.com.\t300\tIN\tTXT\t\"" + strings.Repeat("a", maxCharacterString) + "\" \"bc\"",
+	}
+
+	for _, zone := range tests {
+		rr, err := dns.NewRR(zone)
+		if err != nil {
+			t.Fatalf("dns.NewRR(%q) error = %v", zone, err)
+		}
+
+		a, err := NewAnswerFromRR(rr)
+		if err != nil {
+			t.Fatalf("NewAnswerFromRR(%q) error = %v", zone, err)
+		}
+
+		got, err := a.RR(rr.Header().Name)
+		if err != nil {
+			t.Fatalf("RR() of %q error = %v", zone, err)
+		}
+
+		if got.String() != rr.String() {
+			t.Errorf("RR() = %q, want %q", got.String(), rr.String())
+		}
+	}
+
+	soa, _ := dns.NewRR("example.com. 300 IN SOA ns.example.com. hostmaster.example.com. 1 3600 600 604800 300")
+	if _, err := NewAnswerFromRR(soa); err == nil {
+		t.Errorf("NewAnswerFromRR() of a SOA didn't fail")
+	}
+}
+
+func TestUnescapeText(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "plain", in: "v=spf1 -all", want: "v=spf1 -all"},
+		{name: "quote and backslash", in: `say \"hi\" \\o/`, want: `say "hi" \o/`},
+		{name: "decimal", in: `caf\195\169\009tab`, want: "caf\u00e9\ttab"},
+		{name: "quoted character", in: `a\;b`, want: "a;b"},
+		{name: "short digits", in: `a\12`, want: "a12"},
+		{name: "dangling backslash", in: `a\`, want: "a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := unescapeText(tt.in)
+			if got != tt.want {
+				t.Errorf("unescapeText(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
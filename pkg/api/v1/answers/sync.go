@@ -0,0 +1,256 @@
+package answer
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach-go/v2/crdb/crdbsqlx"
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"go.hollow.sh/dnscontroller/internal/models"
+	owner "go.hollow.sh/dnscontroller/pkg/api/v1/owners"
+	record "go.hollow.sh/dnscontroller/pkg/api/v1/records"
+)
+
+// RecordAnswer is an answer along with the name and type of its record
+type RecordAnswer struct {
+	Record     string  `json:"record"`
+	RecordType string  `json:"record_type"`
+	Answer     *Answer `json:"answer"`
+}
+
+// SyncRequest is the whole desired set of answers of an owner, an empty list
+// deletes every answer of the owner
+type SyncRequest struct {
+	Answers []*RecordAnswer `json:"answers"`
+}
+
+// Diff is what a sync changed
+type Diff struct {
+	Added   []*RecordAnswer `json:"added"`
+	Updated []*RecordAnswer `json:"updated"`
+	Removed []*RecordAnswer `json:"removed"`
+}
+
+// NewSyncRequest creates a sync request from the request body, the answers
+// are validated by Sync
+func NewSyncRequest(c *gin.Context) (*SyncRequest, error) {
+	req := &SyncRequest{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		return nil, err
+	}
+
+	// A missing list is more likely a mistake than a request to delete
+	// every answer
+	if req.Answers == nil {
+		return nil, ErrorNoSyncAnswers
+	}
+
+	return req, nil
+}
+
+// ValidateSync ensures the desired answers of an owner are valid and each
+// is given once
+func ValidateSync(o *owner.Owner, desired []*RecordAnswer) error {
+	_, _, err := syncAnswers(o, desired)
+
+	return err
+}
+
+// Sync makes the desired answers the answers of the owner in one
+// transaction. Stored answers that aren't desired are deleted, stored
+// answers whose ttl, lease or details differ are updated, and the rest are
+// created along with their record. Leased answers that are kept as they are
+// have their lease renewed, so a sync works as a heartbeat. The owner must
+// exist.
+func Sync(ctx context.Context, db *sqlx.DB, o *owner.Owner, desired []*RecordAnswer) (*Diff, error) {
+	if err := ValidateSync(o, desired); err != nil {
+		return nil, err
+	}
+
+	var diff *Diff
+
+	err := crdbsqlx.ExecuteTx(ctx, db, nil, func(tx *sqlx.Tx) error {
+		var err error
+
+		diff, err = sync(ctx, tx, o, desired)
+
+		return err
+	})
+
+	return diff, err
+}
+
+func sync(ctx context.Context, exec boil.ContextExecutor, o *owner.Owner, desired []*RecordAnswer) (*Diff, error) {
+	if err := o.FindByUUID(ctx, exec); err != nil {
+		return nil, err
+	}
+
+	wanted, keys, err := syncAnswers(o, desired)
+	if err != nil {
+		return nil, err
+	}
+
+	dbAnswers, err := models.Answers(
+		qm.Where("owner_id=?", o.UUID.String()),
+		qm.Load(models.AnswerRels.Record),
+		qm.Load(models.AnswerRels.Owner),
+		qm.Load(models.AnswerRels.AnswerDetail),
+	).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &Diff{Added: []*RecordAnswer{}, Updated: []*RecordAnswer{}, Removed: []*RecordAnswer{}}
+
+	// Removing first frees names for CNAMEs added in the same sync
+	for _, dbAnswer := range dbAnswers {
+		stored := &Answer{recordName: dbAnswer.R.Record.Record}
+		if err := stored.FromDBModel(dbAnswer); err != nil {
+			return nil, err
+		}
+
+		key := syncKey(stored.recordName, stored.Type, stored.Target)
+
+		a, ok := wanted[key]
+		if !ok {
+			if err := stored.delete(ctx, exec); err != nil {
+				return nil, err
+			}
+
+			diff.Removed = append(diff.Removed, stored.recordAnswer())
+
+			continue
+		}
+
+		delete(wanted, key)
+
+		if a.sameAs(stored) {
+			if stored.Lease == nil {
+				continue
+			}
+
+			if err := renew(ctx, exec, dbAnswer); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		a.recordID = stored.recordID
+
+		if err := a.update(ctx, exec); err != nil {
+			return nil, err
+		}
+
+		diff.Updated = append(diff.Updated, a.recordAnswer())
+	}
+
+	for _, key := range keys {
+		a, ok := wanted[key]
+		if !ok {
+			continue
+		}
+
+		if err := a.record.FindOrCreate(ctx, exec); err != nil {
+			return nil, err
+		}
+
+		a.recordID = a.record.UUID.String()
+
+		if err := a.create(ctx, exec); err != nil {
+			return nil, err
+		}
+
+		diff.Added = append(diff.Added, a.recordAnswer())
+	}
+
+	sort.Slice(diff.Removed, func(i, j int) bool {
+		return syncKey(diff.Removed[i].Record, diff.Removed[i].RecordType, diff.Removed[i].Answer.Target) <
+			syncKey(diff.Removed[j].Record, diff.Removed[j].RecordType, diff.Removed[j].Answer.Target)
+	})
+
+	return diff, nil
+}
+
+// syncAnswers returns the desired answers by their identity and the
+// identities in the order they were given. The answers are made from the
+// request on every call, so a retried transaction starts over from it.
+func syncAnswers(o *owner.Owner, desired []*RecordAnswer) (map[string]*Answer, []string, error) {
+	answers := make(map[string]*Answer, len(desired))
+	keys := make([]string, 0, len(desired))
+
+	for _, d := range desired {
+		if d == nil || d.Answer == nil {
+			return nil, nil, ErrorNoAnswer
+		}
+
+		r, err := record.NewRecordFromName(d.Record, d.RecordType)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		ow := *o
+		ac := *d.Answer
+
+		a, err := newAnswer(&Request{Owner: &ow, Answer: &ac}, r)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if err := a.validate(); err != nil {
+			return nil, nil, err
+		}
+
+		key := syncKey(r.Name, a.Type, a.Target)
+		if _, ok := answers[key]; ok {
+			return nil, nil, ErrorDuplicateAnswer
+		}
+
+		answers[key] = a
+		keys = append(keys, key)
+	}
+
+	return answers, keys, nil
+}
+
+func syncKey(name, rtype, target string) string {
+	return name + " " + rtype + " " + target
+}
+
+// sameAs reports whether the stored answer already is the answer, an
+// expired answer is updated so its lease starts over
+func (a *Answer) sameAs(stored *Answer) bool {
+	if stored.ExpiresAt != nil && stored.ExpiresAt.Before(time.Now()) {
+		return false
+	}
+
+	return a.TTL == stored.TTL &&
+		a.Protocol == stored.Protocol &&
+		a.Tag == stored.Tag &&
+		sameDetail(a.Lease, stored.Lease) &&
+		sameDetail(a.Port, stored.Port) &&
+		sameDetail(a.Priority, stored.Priority) &&
+		sameDetail(a.Weight, stored.Weight) &&
+		sameDetail(a.Flags, stored.Flags)
+}
+
+func sameDetail(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+// recordAnswer returns the answer along with its record for a diff, the
+// owner is left out since every answer of a diff has the same
+func (a *Answer) recordAnswer() *RecordAnswer {
+	a.Owner = nil
+
+	return &RecordAnswer{Record: a.recordName, RecordType: a.Type, Answer: a}
+}
@@ -0,0 +1,67 @@
+package answer
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	owner "go.hollow.sh/dnscontroller/pkg/api/v1/owners"
+	record "go.hollow.sh/dnscontroller/pkg/api/v1/records"
+)
+
+// DefaultTTL is used when an answer is submitted without a ttl, an explicit
+// ttl of 0 is kept
+const DefaultTTL int64 = 3600
+
+// Request is the payload used to create, update or delete an answer
+type Request struct {
+	Owner  *owner.Owner `json:"owner"`
+	Answer *Answer      `json:"answer"`
+}
+
+// Answer is the API model for an answer. The target holds the address, host
+// name or text of the answer depending on the record type, MX answers use the
+// priority as their preference and CAA answers use the flags and tag.
+type Answer struct {
+	Owner     *owner.Owner `json:"owner,omitempty"`
+	Target    string       `json:"target"`
+	Type      string       `json:"type"`
+	TTL       int64        `json:"ttl"`
+	Port      *int64       `json:"port,omitempty"`
+	Priority  *int64       `json:"priority,omitempty"`
+	Protocol  string       `json:"protocol,omitempty"`
+	Weight    *int64       `json:"weight,omitempty"`
+	Flags     *int64       `json:"flags,omitempty"`
+	Tag       string       `json:"tag,omitempty"`
+	Lease     *int64       `json:"lease,omitempty"`
+	ExpiresAt *time.Time   `json:"expires_at,omitempty"`
+	Health    *Health      `json:"health,omitempty"`
+	UUID      uuid.UUID    `json:"uuid"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+	record    *record.Record
+	recordID  string
+	// recordName is kept for the audit log
+	recordName string
+}
+
+// UnmarshalJSON decodes an answer, one without a ttl gets DefaultTTL
+func (a *Answer) UnmarshalJSON(b []byte) error {
+	// plain has the fields of Answer without its methods
+	type plain Answer
+
+	a.TTL = DefaultTTL
+
+	return json.Unmarshal(b, (*plain)(a))
+}
+
+// Health is the state of an answer's health checks, unhealthy answers aren't
+// served or pushed upstream. It is set by the checker only.
+type Health struct {
+	Healthy   bool       `json:"healthy"`
+	Successes int64      `json:"successes"`
+	Failures  int64      `json:"failures"`
+	CheckedAt *time.Time `json:"checked_at,omitempty"`
+	Error     string     `json:"error,omitempty"`
+}
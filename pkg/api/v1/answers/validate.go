@@ -0,0 +1,209 @@
+package answer
+
+import (
+	"context"
+	"net"
+	"unicode/utf8"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"go.hollow.sh/dnscontroller/internal/models"
+	record "go.hollow.sh/dnscontroller/pkg/api/v1/records"
+)
+
+const (
+	// maxDetailValue is the largest port, priority or weight an answer can
+	// have, they are all 16 bit fields (RFC 1035 3.3.9, RFC 2782)
+	maxDetailValue = 65535
+	// maxFlagsValue is the largest CAA flags value, an 8 bit field (RFC 8659 4.1)
+	maxFlagsValue = 255
+	// maxTagLength is the longest CAA tag (RFC 8659 4.1)
+	maxTagLength = 15
+	// maxTextLength caps a TXT or CAA value, TXT values are split into 255
+	// octet character-strings when served
+	maxTextLength = 4096
+)
+
+// typeValidators checks the type specific fields of an answer
+func typeValidators() map[string]func(a *Answer) error {
+	return map[string]func(a *Answer) error{
+		"A":     validateA,
+		"AAAA":  validateAAAA,
+		"CAA":   validateCAA,
+		"CNAME": validateHostTarget,
+		"MX":    validateMX,
+		"NS":    validateHostTarget,
+		"PTR":   validateHostTarget,
+		"SRV":   validateSRV,
+		"TXT":   validateTXT,
+	}
+}
+
+// hostTargetTypes are the record types whose target is a host name, they are
+// stored in the same canonical form as record names
+func hostTargetTypes() map[string]bool {
+	return map[string]bool{
+		"CNAME": true,
+		"MX":    true,
+		"NS":    true,
+		"PTR":   true,
+		"SRV":   true,
+	}
+}
+
+// textTargetTypes are the record types whose target isn't a host name or
+// address and so keeps its case
+func textTargetTypes() map[string]bool {
+	return map[string]bool{
+		"CAA": true,
+		"TXT": true,
+	}
+}
+
+func (a *Answer) hasDetails() bool {
+	return a.Port != nil || a.Priority != nil || a.Weight != nil || a.Protocol != "" || a.Flags != nil || a.Tag != ""
+}
+
+func (a *Answer) validateIdentity() error {
+	if a.Owner == nil {
+		return ErrorNoOwner
+	}
+
+	if err := a.Owner.Validate(); err != nil {
+		return err
+	}
+
+	if a.Target == "" {
+		return ErrorNoTarget
+	}
+
+	return nil
+}
+
+// Validate checks the whole answer, a write checks it again in its
+// transaction
+func (a *Answer) Validate() error {
+	return a.validate()
+}
+
+func (a *Answer) validate() error {
+	if err := a.validateIdentity(); err != nil {
+		return err
+	}
+
+	if a.TTL < 0 {
+		return ErrorInvalidTTL
+	}
+
+	if a.Lease != nil && *a.Lease <= 0 {
+		return ErrorInvalidLease
+	}
+
+	for _, v := range []*int64{a.Port, a.Priority, a.Weight} {
+		if v != nil && (*v < 0 || *v > maxDetailValue) {
+			return ErrorInvalidDetails
+		}
+	}
+
+	if v, ok := typeValidators()[a.Type]; ok {
+		return v(a)
+	}
+
+	return nil
+}
+
+// validateExclusive ensures a CNAME record keeps a single target (RFC 1034 3.6.2)
+func (a *Answer) validateExclusive(ctx context.Context, exec boil.ContextExecutor) error {
+	if a.Type != "CNAME" {
+		return nil
+	}
+
+	exists, err := models.Answers(
+		qm.Where("record_id=?", a.recordID),
+		qm.Where("target!=?", a.Target),
+	).Exists(ctx, exec)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return ErrorCNAMETarget
+	}
+
+	return nil
+}
+
+func validateA(a *Answer) error {
+	if ip := net.ParseIP(a.Target); ip == nil || ip.To4() == nil {
+		return ErrorInvalidTarget
+	}
+
+	return nil
+}
+
+func validateAAAA(a *Answer) error {
+	if ip := net.ParseIP(a.Target); ip == nil || ip.To4() != nil {
+		return ErrorInvalidTarget
+	}
+
+	return nil
+}
+
+func validateCAA(a *Answer) error {
+	if a.Flags == nil || *a.Flags < 0 || *a.Flags > maxFlagsValue {
+		return ErrorInvalidFlags
+	}
+
+	if a.Tag == "" || len(a.Tag) > maxTagLength {
+		return ErrorInvalidTag
+	}
+
+	for _, r := range a.Tag {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			return ErrorInvalidTag
+		}
+	}
+
+	return validateTXT(a)
+}
+
+func validateHostTarget(a *Answer) error {
+	return record.ValidateName(a.Target)
+}
+
+// validateMX requires a preference (RFC 1035 3.3.9), "." is allowed as the
+// target of a null MX (RFC 7505)
+func validateMX(a *Answer) error {
+	if a.Priority == nil {
+		return ErrorNoPriority
+	}
+
+	if a.Target == "." {
+		return nil
+	}
+
+	return validateHostTarget(a)
+}
+
+// validateSRV requires a port, "." is allowed as the target when the service
+// isn't available (RFC 2782)
+func validateSRV(a *Answer) error {
+	if a.Port == nil {
+		return ErrorNoPort
+	}
+
+	if a.Target == "." {
+		return nil
+	}
+
+	return validateHostTarget(a)
+}
+
+func validateTXT(a *Answer) error {
+	if len(a.Target) > maxTextLength || !utf8.ValidString(a.Target) {
+		return ErrorInvalidText
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package answer
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	owner "go.hollow.sh/dnscontroller/pkg/api/v1/owners"
+	record "go.hollow.sh/dnscontroller/pkg/api/v1/records"
+)
+
+func TestValidate(t *testing.T) {
+	o := &owner.Owner{Name: "validate-test", Origin: "test", Service: "web"}
+	n := func(v int64) *int64 { return &v }
+
+	tests := []struct {
+		name    string
+		answer  *Answer
+		wantErr error
+	}{
+		{name: "no owner", answer: &Answer{Type: "A", Target: "192.0.2.1"}, wantErr: ErrorNoOwner},
+		{name: "incomplete owner", answer: &Answer{Type: "A", Target: "192.0.2.1", Owner: &owner.Owner{Name: "web"}}, wantErr: owner.ErrorNoOwnerOrigin},
+		{name: "no target", answer: &Answer{Type: "A", Owner: o}, wantErr: ErrorNoTarget},
+		{name: "negative ttl", answer: &Answer{Type: "A", Target: "192.0.2.1", TTL: -1, Owner: o}, wantErr: ErrorInvalidTTL},
+		{name: "zero lease", answer: &Answer{Type: "A", Target: "192.0.2.1", Lease: n(0), Owner: o}, wantErr: ErrorInvalidLease},
+		{name: "port out of range", answer: &Answer{Type: "SRV", Target: "web1.example.com.", Port: n(65536), Owner: o}, wantErr: ErrorInvalidDetails},
+
+		{name: "A", answer: &Answer{Type: "A", Target: "192.0.2.1", Owner: o}},
+		{name: "A with an IPv6 target", answer: &Answer{Type: "A", Target: "2001:db8::1", Owner: o}, wantErr: ErrorInvalidTarget},
+		{name: "A with a host target", answer: &Answer{Type: "A", Target: "www.example.com.", Owner: o}, wantErr: ErrorInvalidTarget},
+
+		{name: "AAAA", answer: &Answer{Type: "AAAA", Target: "2001:db8::1", Owner: o}},
+		{name: "AAAA with an IPv4 target", answer: &Answer{Type: "AAAA", Target: "192.0.2.1", Owner: o}, wantErr: ErrorInvalidTarget},
+
+		{name: "CAA", answer: &Answer{Type: "CAA", Target: "letsencrypt.org", Flags: n(0), Tag: "issue", Owner: o}},
+		{name: "CAA critical", answer: &Answer{Type: "CAA", Target: "letsencrypt.org", Flags: n(128), Tag: "issue", Owner: o}},
+		{name: "CAA without flags", answer: &Answer{Type: "CAA", Target: "letsencrypt.org", Tag: "issue", Owner: o}, wantErr: ErrorInvalidFlags},
+		{name: "CAA flags out of range", answer: &Answer{Type: "CAA", Target: "letsencrypt.org", Flags: n(256), Tag: "issue", Owner: o}, wantErr: ErrorInvalidFlags},
+		{name: "CAA without a tag", answer: &Answer{Type: "CAA", Target: "letsencrypt.org", Flags: n(0), Owner: o}, wantErr: ErrorInvalidTag},
+		{name: "CAA tag too long", answer: &Answer{Type: "CAA", Target: "letsencrypt.org", Flags: n(0), Tag: strings.Repeat("a", 16), Owner: o}, wantErr: ErrorInvalidTag},
+		{name: "CAA tag not alphanumeric", answer: &Answer{Type: "CAA", Target: "letsencrypt.org", Flags: n(0), Tag: "is-sue", Owner: o}, wantErr: ErrorInvalidTag},
+		{name: "CAA value too long", answer: &Answer{Type: "CAA", Target: strings.Repeat("a", 4097), Flags: n(0), Tag: "issue", Owner: o}, wantErr: ErrorInvalidText},
+
+		{name: "CNAME", answer: &Answer{Type: "CNAME", Target: "www.example.com.", Owner: o}},
+		{name: "CNAME with an invalid target", answer: &Answer{Type: "CNAME", Target: "-www.example.com.", Owner: o}, wantErr: record.ErrorInvalidHyphen},
+
+		{name: "MX", answer: &Answer{Type: "MX", Target: "mail.example.com.", Priority: n(10), Owner: o}},
+		{name: "null MX", answer: &Answer{Type: "MX", Target: ".", Priority: n(0), Owner: o}},
+		{name: "MX without a preference", answer: &Answer{Type: "MX", Target: "mail.example.com.", Owner: o}, wantErr: ErrorNoPriority},
+		{name: "MX with an invalid target", answer: &Answer{Type: "MX", Target: "mail..example.com.", Priority: n(10), Owner: o}, wantErr: record.ErrorEmptyLabel},
+
+		{name: "NS", answer: &Answer{Type: "NS", Target: "ns1.example.com.", Owner: o}},
+		{name: "NS with a space in its target", answer: &Answer{Type: "NS", Target: "ns 1.example.com.", Owner: o}, wantErr: record.ErrorInvalidCharacter},
+
+		{name: "PTR", answer: &Answer{Type: "PTR", Target: "www.example.com.", Owner: o}},
+		{name: "PTR with an invalid target", answer: &Answer{Type: "PTR", Target: strings.Repeat("a", 64) + ".example.com.", Owner: o}, wantErr: record.ErrorLabelTooLong},
+
+		{name: "SRV", answer: &Answer{Type: "SRV", Target: "web1.example.com.", Port: n(443), Priority: n(10), Weight: n(20), Owner: o}},
+		{name: "SRV service unavailable", answer: &Answer{Type: "SRV", Target: ".", Port: n(0), Owner: o}},
+		{name: "SRV without a port", answer: &Answer{Type: "SRV", Target: "web1.example.com.", Owner: o}, wantErr: ErrorNoPort},
+		{name: "SRV with an invalid target", answer: &Answer{Type: "SRV", Target: "web_1.example.com.", Port: n(443), Owner: o}, wantErr: record.ErrorInvalidCharacter},
+
+		{name: "TXT", answer: &Answer{Type: "TXT", Target: `v=spf1 "quoted" -all`, Owner: o}},
+		{name: "TXT longest", answer: &Answer{Type: "TXT", Target: strings.Repeat("a", 4096), Owner: o}},
+		{name: "TXT too long", answer: &Answer{Type: "TXT", Target: strings.Repeat("a", 4097), Owner: o}, wantErr: ErrorInvalidText},
+		{name: "TXT not utf-8", answer: &Answer{Type: "TXT", Target: "caf\xe9", Owner: o}, wantErr: ErrorInvalidText},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.answer.validate(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTypeValidators(t *testing.T) {
+	for _, rtype := range []string{"A", "AAAA", "CAA", "CNAME", "MX", "NS", "PTR", "SRV", "TXT"} {
+		if _, ok := typeValidators()[rtype]; !ok {
+			t.Errorf("typeValidators() has no validator for %s", rtype)
+		}
+	}
+}
+
+func TestUnmarshalTTL(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want int64
+	}{
+		{name: "no ttl", body: `{"target":"192.0.2.1"}`, want: DefaultTTL},
+		{name: "null ttl", body: `{"target":"192.0.2.1","ttl":null}`, want: DefaultTTL},
+		{name: "zero ttl", body: `{"target":"192.0.2.1","ttl":0}`, want: 0},
+		{name: "ttl", body: `{"target":"192.0.2.1","ttl":300}`, want: 300},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &Request{}
+			if err := json.Unmarshal([]byte(`{"answer":`+tt.body+`}`), req); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+
+			if req.Answer.TTL != tt.want {
+				t.Errorf("ttl = %d, want %d", req.Answer.TTL, tt.want)
+			}
+		})
+	}
+}
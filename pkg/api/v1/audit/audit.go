@@ -0,0 +1,260 @@
+// Package audit records who changed which record, answer or owner. Events are
+// written in the transaction of the mutation they describe, so a mutation
+// without its event is never committed.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"go.hollow.sh/dnscontroller/internal/models"
+)
+
+// Operations recorded in the audit log
+const (
+	RecordCreate = "record.create"
+	RecordDelete = "record.delete"
+	AnswerCreate = "answer.create"
+	AnswerUpdate = "answer.update"
+	AnswerDelete = "answer.delete"
+	// AnswerExpire is an answer removed by the reaper once its lease expired
+	AnswerExpire = "answer.expire"
+	// AnswerRenew is the lease of an answer extended by a renewal, it doesn't
+	// change what is served so it isn't published
+	AnswerRenew = "answer.renew"
+	OwnerCreate = "owner.create"
+	OwnerUpdate = "owner.update"
+	OwnerDelete = "owner.delete"
+)
+
+const (
+	// DefaultPageSize is used when a list request doesn't specify a page size
+	DefaultPageSize = 100
+	// MaxPageSize is the largest page a list request can ask for
+	MaxPageSize = 1000
+)
+
+type actorKey struct{}
+
+// WithActor returns a context carrying who the mutations made with it are
+// made by
+func WithActor(ctx context.Context, a Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, a)
+}
+
+// ActorFrom returns the actor of the context, mutations without one are
+// recorded with an empty actor
+func ActorFrom(ctx context.Context) Actor {
+	a, _ := ctx.Value(actorKey{}).(Actor)
+
+	return a
+}
+
+// Write stores an event for a mutation made with exec, before and after are
+// stored as JSON when they are set. The actor comes from the context.
+//
+// The event's version is the commit timestamp of the transaction, which is what
+// watchers resume from. Fixing the timestamp this early means a transaction
+// that is pushed later restarts instead, the callers already retry those.
+func Write(ctx context.Context, exec boil.ContextExecutor, e *Event, before, after interface{}) error {
+	actor := ActorFrom(ctx)
+
+	dbEvent := &models.AuditEvent{
+		Actor:      actor.Subject,
+		Operation:  e.Operation,
+		Record:     null.NewString(e.Record, e.Record != ""),
+		RecordType: null.NewString(e.RecordType, e.RecordType != ""),
+		OwnerID:    null.NewString(e.OwnerID, e.OwnerID != ""),
+		RequestID:  null.NewString(actor.RequestID, actor.RequestID != ""),
+	}
+
+	var err error
+
+	if dbEvent.Before, err = marshal(before); err != nil {
+		return err
+	}
+
+	if dbEvent.After, err = marshal(after); err != nil {
+		return err
+	}
+
+	if err := dbEvent.Insert(ctx, exec, boil.Infer()); err != nil {
+		return err
+	}
+
+	_, err = exec.ExecContext(ctx, "UPDATE audit_events SET version = cluster_logical_timestamp()::INT8 WHERE id = $1", dbEvent.ID)
+
+	return err
+}
+
+func marshal(v interface{}) (null.JSON, error) {
+	if v == nil {
+		return null.JSON{}, nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return null.JSON{}, err
+	}
+
+	return null.JSONFrom(b), nil
+}
+
+// ListParams filter and paginate the audit log, events are listed newest
+// first
+type ListParams struct {
+	Record   string `form:"record"`
+	Owner    string `form:"owner"`
+	Actor    string `form:"actor"`
+	Since    string `form:"since"`
+	Until    string `form:"until"`
+	Page     int    `form:"page"`
+	PageSize int    `form:"page_size"`
+	since    time.Time
+	until    time.Time
+}
+
+// NewListParams creates list params from the URL query and validates them
+func NewListParams(c *gin.Context) (*ListParams, error) {
+	p := &ListParams{}
+	if err := c.ShouldBindQuery(p); err != nil {
+		return nil, err
+	}
+
+	// Names are stored fully qualified and lowercase
+	if p.Record != "" {
+		p.Record = strings.ToLower(p.Record)
+
+		if !strings.HasSuffix(p.Record, ".") {
+			p.Record += "."
+		}
+	}
+
+	if p.Owner != "" {
+		if _, err := uuid.Parse(p.Owner); err != nil {
+			return nil, ErrorInvalidOwnerID
+		}
+	}
+
+	var err error
+
+	if p.Since != "" {
+		if p.since, err = time.Parse(time.RFC3339, p.Since); err != nil {
+			return nil, ErrorInvalidTimeRange
+		}
+	}
+
+	if p.Until != "" {
+		if p.until, err = time.Parse(time.RFC3339, p.Until); err != nil {
+			return nil, ErrorInvalidTimeRange
+		}
+	}
+
+	if p.Page < 1 {
+		p.Page = 1
+	}
+
+	if p.PageSize < 1 {
+		p.PageSize = DefaultPageSize
+	}
+
+	if p.PageSize > MaxPageSize {
+		p.PageSize = MaxPageSize
+	}
+
+	return p, nil
+}
+
+// TotalPages returns the number of pages needed for count events
+func (p *ListParams) TotalPages(count int64) int {
+	return int((count + int64(p.PageSize) - 1) / int64(p.PageSize))
+}
+
+func (p *ListParams) queryMods() []qm.QueryMod {
+	mods := []qm.QueryMod{}
+
+	if p.Record != "" {
+		mods = append(mods, qm.Where("record=?", p.Record))
+	}
+
+	if p.Owner != "" {
+		mods = append(mods, qm.Where("owner_id=?", p.Owner))
+	}
+
+	if p.Actor != "" {
+		mods = append(mods, qm.Where("actor=?", p.Actor))
+	}
+
+	if !p.since.IsZero() {
+		mods = append(mods, qm.Where("created_at>=?", p.since))
+	}
+
+	if !p.until.IsZero() {
+		mods = append(mods, qm.Where("created_at<?", p.until))
+	}
+
+	return mods
+}
+
+// List returns a page of events matching the params, along with the total
+// number of matching events
+func List(ctx context.Context, db *sqlx.DB, p *ListParams) ([]*Event, int64, error) {
+	mods := p.queryMods()
+
+	count, err := models.AuditEvents(mods...).Count(ctx, db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	mods = append(mods,
+		qm.OrderBy("created_at DESC, id"),
+		qm.Limit(p.PageSize),
+		qm.Offset((p.Page-1)*p.PageSize),
+	)
+
+	dbEvents, err := models.AuditEvents(mods...).All(ctx, db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	events := make([]*Event, 0, len(dbEvents))
+
+	for _, dbEvent := range dbEvents {
+		e := &Event{}
+		if err := e.FromDBModel(dbEvent); err != nil {
+			return nil, 0, err
+		}
+
+		events = append(events, e)
+	}
+
+	return events, count, nil
+}
+
+// FromDBModel converts a db type to an api type
+func (e *Event) FromDBModel(dbT *models.AuditEvent) error {
+	e.Actor = dbT.Actor
+	e.Operation = dbT.Operation
+	e.Record = dbT.Record.String
+	e.RecordType = dbT.RecordType.String
+	e.OwnerID = dbT.OwnerID.String
+	e.Before = json.RawMessage(dbT.Before.JSON)
+	e.After = json.RawMessage(dbT.After.JSON)
+	e.RequestID = dbT.RequestID.String
+	e.CreatedAt = dbT.CreatedAt
+
+	var err error
+
+	e.UUID, err = uuid.Parse(dbT.ID)
+
+	return err
+}
@@ -0,0 +1,148 @@
+package audit
+
+import (
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+
+	"go.hollow.sh/dnscontroller/internal/models"
+)
+
+func newTestContext(query string) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/api/v1/audit?"+query, nil)
+
+	return c
+}
+
+func TestNewListParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    *ListParams
+		wantErr error
+	}{
+		{
+			name:  "defaults",
+			query: "",
+			want:  &ListParams{Page: 1, PageSize: DefaultPageSize},
+		},
+		{
+			name:  "record",
+			query: "record=WWW.Example.com",
+			want:  &ListParams{Record: "www.example.com.", Page: 1, PageSize: DefaultPageSize},
+		},
+		{
+			name:  "fully qualified record",
+			query: "record=www.example.com.",
+			want:  &ListParams{Record: "www.example.com.", Page: 1, PageSize: DefaultPageSize},
+		},
+		{
+			name:  "owner",
+			query: "owner=0f6b5d9e-3a4c-4b9f-8a1e-2c7d6e5f4a3b",
+			want:  &ListParams{Owner: "0f6b5d9e-3a4c-4b9f-8a1e-2c7d6e5f4a3b", Page: 1, PageSize: DefaultPageSize},
+		},
+		{
+			name:    "bad owner",
+			query:   "owner=cluster-a",
+			wantErr: ErrorInvalidOwnerID,
+		},
+		{
+			name:  "time range",
+			query: "since=2022-06-01T03:00:00Z&until=2022-06-01T04:00:00Z",
+			want: &ListParams{
+				Since:    "2022-06-01T03:00:00Z",
+				Until:    "2022-06-01T04:00:00Z",
+				Page:     1,
+				PageSize: DefaultPageSize,
+				since:    time.Date(2022, 6, 1, 3, 0, 0, 0, time.UTC),
+				until:    time.Date(2022, 6, 1, 4, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:    "bad since",
+			query:   "since=2022-06-01",
+			wantErr: ErrorInvalidTimeRange,
+		},
+		{
+			name:    "bad until",
+			query:   "until=yesterday",
+			wantErr: ErrorInvalidTimeRange,
+		},
+		{
+			name:  "page",
+			query: "page=3&page_size=20",
+			want:  &ListParams{Page: 3, PageSize: 20},
+		},
+		{
+			name:  "page size clamped",
+			query: "page=0&page_size=5000",
+			want:  &ListParams{Page: 1, PageSize: MaxPageSize},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewListParams(newTestContext(tt.query))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewListParams() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewListParams() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryMods(t *testing.T) {
+	since := time.Date(2022, 6, 1, 3, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		params    *ListParams
+		wantWhere string
+		wantArgs  []interface{}
+	}{
+		{
+			name:   "no filters",
+			params: &ListParams{},
+		},
+		{
+			name:      "record",
+			params:    &ListParams{Record: "www.example.com."},
+			wantWhere: `WHERE (record=$1)`,
+			wantArgs:  []interface{}{"www.example.com."},
+		},
+		{
+			name:      "every filter",
+			params:    &ListParams{Record: "www.example.com.", Owner: "0f6b5d9e-3a4c-4b9f-8a1e-2c7d6e5f4a3b", Actor: "system:reaper", since: since, until: since.Add(time.Hour)},
+			wantWhere: `WHERE (record=$1) AND (owner_id=$2) AND (actor=$3) AND (created_at>=$4) AND (created_at<$5)`,
+			wantArgs:  []interface{}{"www.example.com.", "0f6b5d9e-3a4c-4b9f-8a1e-2c7d6e5f4a3b", "system:reaper", since, since.Add(time.Hour)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := queries.BuildQuery(models.AuditEvents(tt.params.queryMods()...).Query)
+
+			want := `SELECT "audit_events".* FROM "audit_events"`
+			if tt.wantWhere != "" {
+				want += " " + tt.wantWhere
+			}
+
+			if sql != want+";" {
+				t.Errorf("query = %s, want %s;", sql, want)
+			}
+
+			if len(args) != len(tt.wantArgs) || (len(args) > 0 && !reflect.DeepEqual(args, tt.wantArgs)) {
+				t.Errorf("args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
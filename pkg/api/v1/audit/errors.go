@@ -0,0 +1,10 @@
+package audit
+
+import "errors"
+
+var (
+	// ErrorInvalidTimeRange is when since or until isn't a RFC 3339 time
+	ErrorInvalidTimeRange = errors.New("since and until must be RFC 3339 times")
+	// ErrorInvalidOwnerID is when the owner filter isn't a uuid
+	ErrorInvalidOwnerID = errors.New("invalid owner id")
+)
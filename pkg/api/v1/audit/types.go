@@ -0,0 +1,31 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event is the API model for an audit event, before and after hold the
+// resource as the API returns it
+type Event struct {
+	UUID       uuid.UUID       `json:"uuid"`
+	Actor      string          `json:"actor"`
+	Operation  string          `json:"operation"`
+	Record     string          `json:"record,omitempty"`
+	RecordType string          `json:"record_type,omitempty"`
+	OwnerID    string          `json:"owner_id,omitempty"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	RequestID  string          `json:"request_id,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// Actor is who a mutation is made by
+type Actor struct {
+	// Subject is the JWT subject of the caller, or the name of the
+	// background job
+	Subject   string
+	RequestID string
+}
@@ -0,0 +1,147 @@
+// Package batch runs the operations of a batch request in one transaction
+package batch
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cockroachdb/cockroach-go/v2/crdb/crdbsqlx"
+	"github.com/jmoiron/sqlx"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// Modes of a batch
+const (
+	// ModeAtomic applies every operation or none of them
+	ModeAtomic = "atomic"
+	// ModeBestEffort applies the operations that succeed, a failed one is
+	// rolled back on its own
+	ModeBestEffort = "best_effort"
+)
+
+// Operations of a batch
+const (
+	// OpCreate creates what doesn't exist yet and fails otherwise
+	OpCreate = "create"
+	// OpUpsert creates or updates
+	OpUpsert = "upsert"
+	// OpDelete deletes
+	OpDelete = "delete"
+)
+
+// MaxOperations is the most operations a batch can have
+const MaxOperations = 1000
+
+// Step applies one operation with the executor of the batch transaction
+type Step func(ctx context.Context, exec boil.ContextExecutor) error
+
+// Result is the outcome of an operation, Status is the HTTP status the
+// operation would have had as a request of its own
+type Result struct {
+	Index  int    `json:"index"`
+	Status int    `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Validate checks the mode and size of a batch
+func Validate(mode string, operations int) error {
+	switch mode {
+	case ModeAtomic, ModeBestEffort:
+	default:
+		return ErrorInvalidMode
+	}
+
+	switch {
+	case operations == 0:
+		return ErrorNoOperations
+	case operations > MaxOperations:
+		return ErrorTooManyOperations
+	}
+
+	return nil
+}
+
+// Run applies the steps in one transaction and returns the error of each,
+// nil when it was applied. An atomic batch stops at the first failed step and
+// every other step gets ErrorAborted. A best effort batch rolls a failed step
+// back to a savepoint and goes on with the rest. The error is set when the
+// transaction itself failed.
+func Run(ctx context.Context, db *sqlx.DB, mode string, steps []Step) ([]error, error) {
+	var errs []error
+
+	err := crdbsqlx.ExecuteTx(ctx, db, nil, func(tx *sqlx.Tx) error {
+		errs = make([]error, len(steps))
+
+		if mode == ModeBestEffort {
+			return runBestEffort(ctx, tx, steps, errs)
+		}
+
+		return runAtomic(ctx, tx, steps, errs)
+	})
+
+	var abort *abortError
+	if errors.As(err, &abort) {
+		return errs, nil
+	}
+
+	return errs, err
+}
+
+// abortError rolls an atomic batch back, it unwraps to the error of the
+// failed step so retryable errors restart the transaction
+type abortError struct {
+	err error
+}
+
+func (e *abortError) Error() string {
+	return e.err.Error()
+}
+
+func (e *abortError) Unwrap() error {
+	return e.err
+}
+
+func runAtomic(ctx context.Context, tx *sqlx.Tx, steps []Step, errs []error) error {
+	for i, step := range steps {
+		err := step(ctx, tx)
+		if err == nil {
+			continue
+		}
+
+		for j := range errs {
+			errs[j] = ErrorAborted
+		}
+
+		errs[i] = err
+
+		return &abortError{err: err}
+	}
+
+	return nil
+}
+
+func runBestEffort(ctx context.Context, tx *sqlx.Tx, steps []Step, errs []error) error {
+	for i, step := range steps {
+		if _, err := tx.ExecContext(ctx, "SAVEPOINT batch_step"); err != nil {
+			return err
+		}
+
+		if err := step(ctx, tx); err != nil {
+			// Retryable errors can't be rolled back to a savepoint, the
+			// whole transaction is restarted instead
+			if _, rbErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT batch_step"); rbErr != nil {
+				return err
+			}
+
+			errs[i] = err
+
+			continue
+		}
+
+		if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT batch_step"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
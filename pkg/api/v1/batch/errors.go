@@ -0,0 +1,20 @@
+package batch
+
+import "errors"
+
+var (
+	// ErrorInvalidBatch is a generic invalid response
+	ErrorInvalidBatch = errors.New("invalid batch format")
+	// ErrorInvalidMode is when the mode isn't atomic or best_effort
+	ErrorInvalidMode = errors.New("batch mode must be atomic or best_effort")
+	// ErrorInvalidOperation is when an operation isn't create, upsert or
+	// delete
+	ErrorInvalidOperation = errors.New("batch operation must be create, upsert or delete")
+	// ErrorNoOperations is when a batch is empty
+	ErrorNoOperations = errors.New("batch has no operations")
+	// ErrorTooManyOperations is when a batch has more than MaxOperations
+	ErrorTooManyOperations = errors.New("batch has more than 1000 operations")
+	// ErrorAborted is the error of the operations of an atomic batch that
+	// were rolled back, or never run, because another one failed
+	ErrorAborted = errors.New("operation not applied, the batch was rolled back")
+)
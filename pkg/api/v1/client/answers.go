@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	answer "go.hollow.sh/dnscontroller/pkg/api/v1/answers"
+	owner "go.hollow.sh/dnscontroller/pkg/api/v1/owners"
+)
+
+// ListAnswers returns the answers of the record with the name and type
+func (c *Client) ListAnswers(ctx context.Context, name, rtype string) ([]*answer.Answer, error) {
+	answers := []*answer.Answer{}
+	if err := c.do(ctx, http.MethodGet, answersPath(name, rtype), nil, nil, &answers); err != nil {
+		return nil, err
+	}
+
+	return answers, nil
+}
+
+// CreateAnswer creates the answer of the owner, or replaces its ttl and
+// details when it exists. The record and owner are created if needed.
+func (c *Client) CreateAnswer(ctx context.Context, name, rtype string, o *owner.Owner, a *answer.Answer) error {
+	return c.do(ctx, http.MethodPost, answersPath(name, rtype), nil, &answer.Request{Owner: o, Answer: a}, nil)
+}
+
+// UpdateAnswer replaces the ttl and details of an existing answer of the owner
+func (c *Client) UpdateAnswer(ctx context.Context, name, rtype string, o *owner.Owner, a *answer.Answer) error {
+	return c.do(ctx, http.MethodPut, answersPath(name, rtype), nil, &answer.Request{Owner: o, Answer: a}, nil)
+}
+
+// DeleteAnswer deletes the answer of the owner with the target of a
+func (c *Client) DeleteAnswer(ctx context.Context, name, rtype string, o *owner.Owner, a *answer.Answer) error {
+	return c.do(ctx, http.MethodDelete, answersPath(name, rtype), nil, &answer.Request{Owner: o, Answer: a}, nil)
+}
+
+func answersPath(name, rtype string) string {
+	return recordPath(name, rtype) + "/answers"
+}
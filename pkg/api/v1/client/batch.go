@@ -0,0 +1,32 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	answer "go.hollow.sh/dnscontroller/pkg/api/v1/answers"
+	"go.hollow.sh/dnscontroller/pkg/api/v1/batch"
+	record "go.hollow.sh/dnscontroller/pkg/api/v1/records"
+)
+
+// BatchRecords applies the record operations in one request and returns the
+// result of each. A best effort batch returns the results of its failed
+// operations without an error, an atomic batch that was rolled back returns
+// an *Error for the operation that failed.
+func (c *Client) BatchRecords(ctx context.Context, b *record.Batch) ([]batch.Result, error) {
+	return c.batch(ctx, "/records:batch", b)
+}
+
+// BatchAnswers is BatchRecords for answer operations
+func (c *Client) BatchAnswers(ctx context.Context, b *answer.Batch) ([]batch.Result, error) {
+	return c.batch(ctx, "/answers:batch", b)
+}
+
+func (c *Client) batch(ctx context.Context, path string, b interface{}) ([]batch.Result, error) {
+	resp := &response{}
+	if err := c.do(ctx, http.MethodPost, path, nil, b, resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Results, nil
+}
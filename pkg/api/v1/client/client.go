@@ -0,0 +1,205 @@
+// Package client is a typed client for the dnscontroller v1 API
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"go.hollow.sh/dnscontroller/pkg/api/v1/batch"
+)
+
+const (
+	// DefaultRetries is how many times a failed request is retried by default
+	DefaultRetries = 3
+	// DefaultBackoff is the wait before the first retry by default
+	DefaultBackoff = 200 * time.Millisecond
+
+	// maxBackoff caps the wait between retries
+	maxBackoff = 5 * time.Second
+
+	v1Path = "/api/v1"
+)
+
+// Client calls the dnscontroller v1 API over http
+type Client struct {
+	// URL is the address of the API server, without the /api/v1 prefix
+	URL string
+	// Token is sent as a bearer token when set
+	Token      string
+	HTTPClient *http.Client
+	// Retries is how many times a request is retried after a 429, or after
+	// a connection error or a 5xx when it is a GET, PUT or DELETE. A POST
+	// may have been applied before it failed, so it isn't sent again.
+	Retries int
+	// Backoff is the wait before the first retry, it doubles for each retry
+	// after that
+	Backoff time.Duration
+}
+
+// New returns a client for the API server at the address with the default
+// retries
+func New(address, token string) *Client {
+	return &Client{
+		URL:     address,
+		Token:   token,
+		Retries: DefaultRetries,
+		Backoff: DefaultBackoff,
+	}
+}
+
+// response is the body the API returns for everything but single resources
+// and answer and owner lists
+type response struct {
+	PageSize         int             `json:"page_size"`
+	Page             int             `json:"page"`
+	TotalPages       int             `json:"total_pages"`
+	TotalRecordCount int64           `json:"total_record_count"`
+	Message          string          `json:"message"`
+	Error            string          `json:"error"`
+	Code             string          `json:"code"`
+	Slug             string          `json:"slug"`
+	Renewed          *int64          `json:"renewed"`
+	Version          string          `json:"version"`
+	Record           json.RawMessage `json:"record"`
+	Records          json.RawMessage `json:"records"`
+	Results          []batch.Result  `json:"results"`
+}
+
+// do sends the request, retrying it as configured, and decodes a 2xx
+// response into out when it is set. A *[]byte out gets the raw body.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	var payload []byte
+
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+
+		payload = b
+	}
+
+	u := c.URL + v1Path + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	backoff := c.Backoff
+
+	for attempt := 0; ; attempt++ {
+		err := c.send(ctx, method, u, payload, out)
+		if err == nil || attempt >= c.Retries || !retryable(method, err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, method, u string, payload []byte, out interface{}) error {
+	var reader io.Reader
+
+	if payload != nil {
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		r := &response{}
+
+		// Errors from proxies in front of the API aren't JSON
+		b, _ := io.ReadAll(resp.Body)
+		if err := json.Unmarshal(b, r); err != nil {
+			r.Error = string(b)
+		}
+
+		return newError(resp.StatusCode, r)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if raw, ok := out.(*[]byte); ok {
+		*raw, err = io.ReadAll(resp.Body)
+
+		return err
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// retryable reports whether the request may succeed when sent again without
+// being applied twice. A 429 was turned away before it was handled, other
+// failures are only retried for the idempotent methods. Connection errors are
+// retried unless the context is done.
+func retryable(method string, err error) bool {
+	var apiErr *Error
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	switch method {
+	case http.MethodGet, http.MethodPut, http.MethodDelete:
+	default:
+		return false
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	if apiErr == nil {
+		return false
+	}
+
+	switch apiErr.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
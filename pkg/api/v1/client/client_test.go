@@ -0,0 +1,289 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	answer "go.hollow.sh/dnscontroller/pkg/api/v1/answers"
+	"go.hollow.sh/dnscontroller/pkg/api/v1/batch"
+	owner "go.hollow.sh/dnscontroller/pkg/api/v1/owners"
+	record "go.hollow.sh/dnscontroller/pkg/api/v1/records"
+	"go.hollow.sh/dnscontroller/pkg/api/v1/watch"
+)
+
+func newTestClient(t *testing.T, h http.HandlerFunc) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	c := New(srv.URL, "token")
+	c.Backoff = time.Millisecond
+
+	return c
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   []error
+	}{
+		{
+			name:   "name error",
+			status: http.StatusBadRequest,
+			body:   `{"message":"invalid record name","error":"invalid name \"a..b.\": empty label"}`,
+			want:   []error{record.ErrorEmptyLabel},
+		},
+		{
+			name:   "name error code",
+			status: http.StatusBadRequest,
+			body:   `{"message":"invalid record name","error":"a reworded message","code":"invalid_hyphen"}`,
+			want:   []error{record.ErrorInvalidHyphen},
+		},
+		{
+			name:   "generic message",
+			status: http.StatusBadRequest,
+			body:   `{"message":"invalid record format","error":"json: cannot unmarshal"}`,
+			want:   []error{record.ErrorInvalidRecord},
+		},
+		{
+			name:   "not owner",
+			status: http.StatusForbidden,
+			body:   `{"message":"forbidden","error":"owner belongs to another caller"}`,
+			want:   []error{ErrorForbidden, owner.ErrorNotOwner},
+		},
+		{
+			name:   "not found",
+			status: http.StatusNotFound,
+			body:   `{"message":"resource not found","error":"sql: no rows in result set"}`,
+			want:   []error{ErrorNotFound},
+		},
+		{
+			name:   "not json",
+			status: http.StatusBadGateway,
+			body:   "bad gateway",
+			want:   []error{ErrorServer},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = io.WriteString(w, tt.body)
+			})
+			c.Retries = 0
+
+			err := c.CreateRecord(context.Background(), "a..b.", "A")
+
+			var apiErr *Error
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+				t.Fatalf("CreateRecord() error = %v, want status %d", err, tt.status)
+			}
+
+			for _, want := range tt.want {
+				if !errors.Is(err, want) {
+					t.Errorf("CreateRecord() error = %v, want %v", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		wantErr      error
+		wantAttempts int32
+	}{
+		{name: "recovers", statuses: []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK}, wantAttempts: 3},
+		{name: "gives up", statuses: []int{http.StatusInternalServerError}, wantErr: ErrorServer, wantAttempts: 4},
+		{name: "client error", statuses: []int{http.StatusBadRequest}, wantErr: owner.ErrorNoOwnerName, wantAttempts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt32(&attempts, 1)
+
+				body, _ := io.ReadAll(r.Body)
+				if len(body) == 0 {
+					t.Errorf("attempt %d has no body", n)
+				}
+
+				if got := r.Header.Get("Authorization"); got != "Bearer token" {
+					t.Errorf("Authorization = %q, want %q", got, "Bearer token")
+				}
+
+				status := tt.statuses[len(tt.statuses)-1]
+				if int(n) <= len(tt.statuses) {
+					status = tt.statuses[n-1]
+				}
+
+				w.WriteHeader(status)
+				_, _ = io.WriteString(w, `{"error":"no owner name"}`)
+			})
+
+			err := c.UpdateOwner(context.Background(), &owner.Owner{Origin: "test", Service: "web"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateOwner() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got := atomic.LoadInt32(&attempts); got != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestRetriesOnlyIdempotent(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		wantAttempts int32
+	}{
+		{name: "server error", status: http.StatusServiceUnavailable, wantAttempts: 1},
+		{name: "too many requests", status: http.StatusTooManyRequests, wantAttempts: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&attempts, 1)
+				w.WriteHeader(tt.status)
+			})
+
+			// The answer may have been created before the error
+			if err := c.CreateAnswer(context.Background(), "www.example.com.", "A", &owner.Owner{Name: "test"}, &answer.Answer{Target: "192.0.2.1"}); err == nil {
+				t.Fatal("CreateAnswer() error = nil, want an error")
+			}
+
+			if got := atomic.LoadInt32(&attempts); got != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestRetriesStopWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	c.Backoff = time.Hour
+
+	if err := c.DeleteRecord(ctx, "www.example.com.", "A"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("DeleteRecord() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestAllRecords(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+
+		if r.URL.Path != "/api/v1/records" || q.Get("type") != "SRV" || q.Get("page_size") != "1" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+
+		page, _ := strconv.Atoi(q.Get("page"))
+
+		_, _ = io.WriteString(w, `{"page":`+strconv.Itoa(page)+`,"page_size":1,"total_pages":3,"total_record_count":3,`+
+			`"records":[{"record":"_web`+strconv.Itoa(page)+`._tcp.example.com.","record_type":"SRV","uuid":"00000000-0000-0000-0000-000000000000"}]}`)
+	})
+
+	records, err := c.AllRecords(context.Background(), &record.ListParams{Type: "SRV", PageSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(records) != 3 || records[2].Name != "_web3._tcp.example.com." {
+		t.Fatalf("AllRecords() = %v, want 3 records", records)
+	}
+}
+
+func TestBatchRecords(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    []batch.Result
+		wantErr error
+	}{
+		{
+			name:   "best effort",
+			status: http.StatusMultiStatus,
+			body:   `{"message":"batch partially applied","results":[{"index":0,"status":200},{"index":1,"status":409,"error":"record already exists"}]}`,
+			want:   []batch.Result{{Index: 0, Status: http.StatusOK}, {Index: 1, Status: http.StatusConflict, Error: "record already exists"}},
+		},
+		{
+			name:    "rolled back",
+			status:  http.StatusConflict,
+			body:    `{"message":"batch rolled back","error":"operation 1: record already exists","results":[{"index":0,"status":424},{"index":1,"status":409}]}`,
+			wantErr: record.ErrorRecordExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/api/v1/records:batch" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				}
+
+				w.WriteHeader(tt.status)
+				_, _ = io.WriteString(w, tt.body)
+			})
+
+			results, err := c.BatchRecords(context.Background(), &record.Batch{
+				Mode:       batch.ModeBestEffort,
+				Operations: []*record.Operation{{Op: batch.OpCreate, Name: "www.example.com.", Type: "A"}},
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("BatchRecords() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(results, tt.want) {
+				t.Errorf("BatchRecords() = %v, want %v", results, tt.want)
+			}
+		})
+	}
+}
+
+func TestWatch(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+
+		if r.URL.Path != "/api/v1/watch" || q.Get("version") != "41" || q.Get("zone") != "example.com" || q.Get("timeout") != "5" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+
+		_, _ = io.WriteString(w, `{"version":"42","records":[{"version":"42","kind":"answer","type":"create",`+
+			`"record":"www.example.com.","record_type":"A","uuid":"00000000-0000-0000-0000-000000000000"}]}`)
+	})
+
+	events, version, err := c.Watch(context.Background(), &watch.Params{Version: "41", Zone: "example.com", Timeout: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if version != "42" || len(events) != 1 || events[0].Name() != "answer.create" || events[0].Record != "www.example.com." {
+		t.Fatalf("Watch() = %v, %q, want one answer.create at 42", events, version)
+	}
+}
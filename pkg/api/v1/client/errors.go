@@ -0,0 +1,172 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	answer "go.hollow.sh/dnscontroller/pkg/api/v1/answers"
+	"go.hollow.sh/dnscontroller/pkg/api/v1/batch"
+	owner "go.hollow.sh/dnscontroller/pkg/api/v1/owners"
+	record "go.hollow.sh/dnscontroller/pkg/api/v1/records"
+	"go.hollow.sh/dnscontroller/pkg/api/v1/watch"
+	webhook "go.hollow.sh/dnscontroller/pkg/api/v1/webhooks"
+	zone "go.hollow.sh/dnscontroller/pkg/api/v1/zones"
+)
+
+var (
+	// ErrorNotFound is when the resource doesn't exist
+	ErrorNotFound = errors.New("resource not found")
+	// ErrorUnauthorized is when the token is missing or invalid
+	ErrorUnauthorized = errors.New("unauthorized")
+	// ErrorForbidden is when the token lacks a scope or the caller isn't the
+	// principal of the owner
+	ErrorForbidden = errors.New("forbidden")
+	// ErrorServer is when the server failed the request, after retries
+	ErrorServer = errors.New("server error")
+)
+
+// Error is returned for responses outside of 2xx. It unwraps to the error of
+// its status code and, when the server reported one, to the matching error of
+// the records, answers, owners, zones, batch, watch or webhooks packages, so
+// errors.Is works with either.
+type Error struct {
+	StatusCode int
+	Message    string
+	// Detail is the error reported by the server
+	Detail string
+	// Code is the stable code of a name error, see record.NameError
+	Code string
+	errs []error
+}
+
+func (e *Error) Error() string {
+	if e.Detail != "" {
+		return fmt.Sprintf("dnscontroller api returned %d: %s: %s", e.StatusCode, e.Message, e.Detail)
+	}
+
+	return fmt.Sprintf("dnscontroller api returned %d: %s", e.StatusCode, e.Message)
+}
+
+// Unwrap returns the errors the response maps to
+func (e *Error) Unwrap() []error {
+	return e.errs
+}
+
+func newError(statusCode int, resp *response) *Error {
+	e := &Error{StatusCode: statusCode, Message: resp.Message, Detail: resp.Error, Code: resp.Code}
+
+	if e.Message == "" {
+		e.Message = http.StatusText(statusCode)
+	}
+
+	if err := statusError(statusCode); err != nil {
+		e.errs = append(e.errs, err)
+	}
+
+	if err := record.ErrorFromCode(resp.Code); err != nil {
+		e.errs = append(e.errs, err)
+		return e
+	}
+
+	// The detail is the most specific, the message is often the generic
+	// invalid format error of the resource
+	for _, s := range []string{resp.Error, resp.Message} {
+		if err := sentinel(s); err != nil {
+			e.errs = append(e.errs, err)
+			break
+		}
+	}
+
+	return e
+}
+
+func statusError(statusCode int) error {
+	switch {
+	case statusCode == http.StatusNotFound:
+		return ErrorNotFound
+	case statusCode == http.StatusUnauthorized:
+		return ErrorUnauthorized
+	case statusCode == http.StatusForbidden:
+		return ErrorForbidden
+	case statusCode >= http.StatusInternalServerError:
+		return ErrorServer
+	}
+
+	return nil
+}
+
+// sentinel returns the error the message was made from. Name errors carry
+// the name and label before the error, so suffixes match too.
+func sentinel(msg string) error {
+	if msg == "" {
+		return nil
+	}
+
+	for _, err := range sentinels() {
+		if msg == err.Error() || strings.HasSuffix(msg, ": "+err.Error()) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sentinels() []error {
+	return []error{
+		record.ErrorInvalidRecord,
+		record.ErrorNoRecordName,
+		record.ErrorNoRecordType,
+		record.ErrorUnsupportedType,
+		record.ErrorCNAMEConflict,
+		record.ErrorInvalidPTRName,
+		record.ErrorNameTooLong,
+		record.ErrorLabelTooLong,
+		record.ErrorEmptyLabel,
+		record.ErrorInvalidCharacter,
+		record.ErrorInvalidHyphen,
+		record.ErrorInvalidIDN,
+		record.ErrorInvalidSRVName,
+		record.ErrorPreconditionFailed,
+		record.ErrorRecordExists,
+		answer.ErrorInvalidAnswer,
+		answer.ErrorNoAnswer,
+		answer.ErrorNoOwner,
+		answer.ErrorNoTarget,
+		answer.ErrorInvalidTarget,
+		answer.ErrorInvalidTTL,
+		answer.ErrorInvalidLease,
+		answer.ErrorNoPort,
+		answer.ErrorInvalidDetails,
+		answer.ErrorNoPriority,
+		answer.ErrorInvalidFlags,
+		answer.ErrorInvalidTag,
+		answer.ErrorInvalidText,
+		answer.ErrorCNAMETarget,
+		answer.ErrorAnswerExists,
+		answer.ErrorNoSyncAnswers,
+		answer.ErrorDuplicateAnswer,
+		owner.ErrorInvalidOwner,
+		owner.ErrorInvalidOwnerID,
+		owner.ErrorNoOwnerName,
+		owner.ErrorNoOwnerOrigin,
+		owner.ErrorNotOwner,
+		owner.ErrorNoOwnerService,
+		zone.ErrorInvalidZone,
+		zone.ErrorNoZoneName,
+		batch.ErrorInvalidBatch,
+		batch.ErrorInvalidMode,
+		batch.ErrorInvalidOperation,
+		batch.ErrorNoOperations,
+		batch.ErrorTooManyOperations,
+		watch.ErrorInvalidVersion,
+		watch.ErrorInvalidTimeout,
+		webhook.ErrorInvalidWebhook,
+		webhook.ErrorInvalidWebhookID,
+		webhook.ErrorInvalidURL,
+		webhook.ErrorNoSecret,
+		webhook.ErrorInvalidEvent,
+		webhook.ErrorInvalidStatus,
+	}
+}
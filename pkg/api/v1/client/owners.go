@@ -0,0 +1,98 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	answer "go.hollow.sh/dnscontroller/pkg/api/v1/answers"
+	owner "go.hollow.sh/dnscontroller/pkg/api/v1/owners"
+)
+
+// ListOwners returns every owner
+func (c *Client) ListOwners(ctx context.Context) ([]*owner.Owner, error) {
+	owners := []*owner.Owner{}
+	if err := c.do(ctx, http.MethodGet, "/owners", nil, nil, &owners); err != nil {
+		return nil, err
+	}
+
+	return owners, nil
+}
+
+// GetOwner returns the owner with the id
+func (c *Client) GetOwner(ctx context.Context, id uuid.UUID) (*owner.Owner, error) {
+	o := &owner.Owner{}
+	if err := c.do(ctx, http.MethodGet, ownerPath(id), nil, nil, o); err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+// CreateOwner creates the owner, or finds it when one with its name, origin
+// and service exists, and fills it in with what is stored
+func (c *Client) CreateOwner(ctx context.Context, o *owner.Owner) error {
+	if err := c.do(ctx, http.MethodPost, "/owners", nil, o, nil); err != nil {
+		return err
+	}
+
+	owners, err := c.ListOwners(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, stored := range owners {
+		if stored.Name == o.Name && stored.Origin == o.Origin && stored.Service == o.Service {
+			*o = *stored
+			return nil
+		}
+	}
+
+	return ErrorNotFound
+}
+
+// UpdateOwner replaces the name, origin, service and principal of the owner
+// with the id of o
+func (c *Client) UpdateOwner(ctx context.Context, o *owner.Owner) error {
+	return c.do(ctx, http.MethodPut, ownerPath(o.UUID), nil, o, nil)
+}
+
+// DeleteOwner deletes the owner with the id, along with its answers
+func (c *Client) DeleteOwner(ctx context.Context, id uuid.UUID) error {
+	return c.do(ctx, http.MethodDelete, ownerPath(id), nil, nil, nil)
+}
+
+// RenewOwner renews the leases of the answers of the owner with the id and
+// returns how many were renewed
+func (c *Client) RenewOwner(ctx context.Context, id uuid.UUID) (int64, error) {
+	resp := &response{}
+	if err := c.do(ctx, http.MethodPost, ownerPath(id)+"/renew", nil, nil, resp); err != nil {
+		return 0, err
+	}
+
+	if resp.Renewed == nil {
+		return 0, nil
+	}
+
+	return *resp.Renewed, nil
+}
+
+func ownerPath(id uuid.UUID) string {
+	return "/owners/" + id.String()
+}
+
+// SyncOwnerAnswers makes the answers the whole set of answers of the owner
+// with the id, and returns what was added, updated and removed
+func (c *Client) SyncOwnerAnswers(ctx context.Context, id uuid.UUID, answers []*answer.RecordAnswer) (*answer.Diff, error) {
+	if answers == nil {
+		answers = []*answer.RecordAnswer{}
+	}
+
+	diff := &answer.Diff{}
+	if err := c.do(ctx, http.MethodPut, ownerPath(id)+"/answers", nil, &answer.SyncRequest{Answers: answers}, diff); err != nil {
+		return nil, err
+	}
+
+	return diff, nil
+}
@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	record "go.hollow.sh/dnscontroller/pkg/api/v1/records"
+)
+
+// RecordPage is a page of a record list
+type RecordPage struct {
+	Records          []*record.Record
+	Page             int
+	PageSize         int
+	TotalPages       int
+	TotalRecordCount int64
+}
+
+// ListRecords returns the page of records matching the params, the server
+// defaults are used for an empty page and page size
+func (c *Client) ListRecords(ctx context.Context, p *record.ListParams) (*RecordPage, error) {
+	resp := &response{}
+	if err := c.do(ctx, http.MethodGet, "/records", listQuery(p), nil, resp); err != nil {
+		return nil, err
+	}
+
+	page := &RecordPage{
+		Records:          []*record.Record{},
+		Page:             resp.Page,
+		PageSize:         resp.PageSize,
+		TotalPages:       resp.TotalPages,
+		TotalRecordCount: resp.TotalRecordCount,
+	}
+
+	if len(resp.Records) > 0 {
+		if err := json.Unmarshal(resp.Records, &page.Records); err != nil {
+			return nil, err
+		}
+	}
+
+	return page, nil
+}
+
+// AllRecords returns the records matching the params across every page, the
+// page of the params is ignored
+func (c *Client) AllRecords(ctx context.Context, p *record.ListParams) ([]*record.Record, error) {
+	params := record.ListParams{}
+	if p != nil {
+		params = *p
+	}
+
+	if params.PageSize == 0 {
+		params.PageSize = record.MaxPageSize
+	}
+
+	records := []*record.Record{}
+
+	for params.Page = 1; ; params.Page++ {
+		page, err := c.ListRecords(ctx, &params)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, page.Records...)
+
+		if params.Page >= page.TotalPages {
+			return records, nil
+		}
+	}
+}
+
+// GetRecord returns the record with the name and type
+func (c *Client) GetRecord(ctx context.Context, name, rtype string) (*record.Record, error) {
+	r := &record.Record{}
+	if err := c.do(ctx, http.MethodGet, recordPath(name, rtype), nil, nil, r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// CreateRecord creates the record with the name and type, creating a record
+// that exists isn't an error
+func (c *Client) CreateRecord(ctx context.Context, name, rtype string) error {
+	return c.do(ctx, http.MethodPost, recordPath(name, rtype), nil, nil, nil)
+}
+
+// DeleteRecord deletes the record with the name and type, along with its
+// answers
+func (c *Client) DeleteRecord(ctx context.Context, name, rtype string) error {
+	return c.do(ctx, http.MethodDelete, recordPath(name, rtype), nil, nil, nil)
+}
+
+func recordPath(name, rtype string) string {
+	return "/records/" + url.PathEscape(name) + "/" + url.PathEscape(rtype)
+}
+
+func listQuery(p *record.ListParams) url.Values {
+	q := url.Values{}
+
+	if p == nil {
+		return q
+	}
+
+	for k, v := range map[string]string{
+		"type":   p.Type,
+		"prefix": p.Prefix,
+		"suffix": p.Suffix,
+		"owner":  p.Owner,
+		"origin": p.Origin,
+	} {
+		if v != "" {
+			q.Set(k, v)
+		}
+	}
+
+	if p.Page > 0 {
+		q.Set("page", strconv.Itoa(p.Page))
+	}
+
+	if p.PageSize > 0 {
+		q.Set("page_size", strconv.Itoa(p.PageSize))
+	}
+
+	return q
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"go.hollow.sh/dnscontroller/pkg/api/v1/watch"
+)
+
+// Watch long-polls for the changes to records and answers after the version of
+// the params, waiting up to their timeout. The changes are returned with the
+// version the next call resumes from, an empty version watches from now.
+func (c *Client) Watch(ctx context.Context, p *watch.Params) ([]*watch.Event, string, error) {
+	q := url.Values{}
+
+	if p != nil {
+		for k, v := range map[string]string{
+			"version": p.Version,
+			"zone":    p.Zone,
+			"owner":   p.Owner,
+		} {
+			if v != "" {
+				q.Set(k, v)
+			}
+		}
+
+		if p.Timeout > 0 {
+			q.Set("timeout", strconv.Itoa(p.Timeout))
+		}
+	}
+
+	resp := &response{}
+	if err := c.do(ctx, http.MethodGet, "/watch", q, nil, resp); err != nil {
+		return nil, "", err
+	}
+
+	events := []*watch.Event{}
+
+	if len(resp.Records) > 0 {
+		if err := json.Unmarshal(resp.Records, &events); err != nil {
+			return nil, "", err
+		}
+	}
+
+	return events, resp.Version, nil
+}
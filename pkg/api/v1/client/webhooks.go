@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	webhook "go.hollow.sh/dnscontroller/pkg/api/v1/webhooks"
+)
+
+// ListWebhooks returns every webhook
+func (c *Client) ListWebhooks(ctx context.Context) ([]*webhook.Webhook, error) {
+	webhooks := []*webhook.Webhook{}
+	if err := c.do(ctx, http.MethodGet, "/webhooks", nil, nil, &webhooks); err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+// GetWebhook returns the webhook with the id
+func (c *Client) GetWebhook(ctx context.Context, id uuid.UUID) (*webhook.Webhook, error) {
+	w := &webhook.Webhook{}
+	if err := c.do(ctx, http.MethodGet, webhookPath(id), nil, nil, w); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// CreateWebhook creates the webhook and fills it in with what is stored, the
+// secret is cleared
+func (c *Client) CreateWebhook(ctx context.Context, w *webhook.Webhook) error {
+	resp := &response{}
+	if err := c.do(ctx, http.MethodPost, "/webhooks", nil, w, resp); err != nil {
+		return err
+	}
+
+	return json.Unmarshal(resp.Record, w)
+}
+
+// UpdateWebhook replaces the url and filters of the webhook with the id of w,
+// and its secret when one is set
+func (c *Client) UpdateWebhook(ctx context.Context, w *webhook.Webhook) error {
+	return c.do(ctx, http.MethodPut, webhookPath(w.UUID), nil, w, nil)
+}
+
+// DeleteWebhook deletes the webhook with the id, along with its deliveries
+func (c *Client) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	return c.do(ctx, http.MethodDelete, webhookPath(id), nil, nil, nil)
+}
+
+// WebhookDeliveries returns the page of the delivery history of the webhook
+// with the id matching the params, newest first
+func (c *Client) WebhookDeliveries(ctx context.Context, id uuid.UUID, p *webhook.ListParams) ([]*webhook.Delivery, error) {
+	return c.deliveries(ctx, webhookPath(id)+"/deliveries", p)
+}
+
+// WebhookDeadLetters returns the page of the deliveries of the webhook with the
+// id that failed every attempt, newest first
+func (c *Client) WebhookDeadLetters(ctx context.Context, id uuid.UUID, p *webhook.ListParams) ([]*webhook.Delivery, error) {
+	return c.deliveries(ctx, webhookPath(id)+"/dead-letters", p)
+}
+
+func (c *Client) deliveries(ctx context.Context, path string, p *webhook.ListParams) ([]*webhook.Delivery, error) {
+	q := url.Values{}
+
+	if p != nil {
+		if p.Status != "" {
+			q.Set("status", p.Status)
+		}
+
+		if p.Page > 0 {
+			q.Set("page", strconv.Itoa(p.Page))
+		}
+
+		if p.PageSize > 0 {
+			q.Set("page_size", strconv.Itoa(p.PageSize))
+		}
+	}
+
+	resp := &response{}
+	if err := c.do(ctx, http.MethodGet, path, q, nil, resp); err != nil {
+		return nil, err
+	}
+
+	deliveries := []*webhook.Delivery{}
+
+	if len(resp.Records) > 0 {
+		if err := json.Unmarshal(resp.Records, &deliveries); err != nil {
+			return nil, err
+		}
+	}
+
+	return deliveries, nil
+}
+
+func webhookPath(id uuid.UUID) string {
+	return "/webhooks/" + id.String()
+}
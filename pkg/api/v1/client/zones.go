@@ -0,0 +1,17 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// ExportZone returns the RFC 1035 master file of the zone
+func (c *Client) ExportZone(ctx context.Context, zone string) ([]byte, error) {
+	var b []byte
+	if err := c.do(ctx, http.MethodGet, "/zones/"+url.PathEscape(zone)+"/export", nil, nil, &b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
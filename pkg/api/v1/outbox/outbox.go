@@ -0,0 +1,150 @@
+// Package outbox stores the changes to records and answers for the relay to
+// publish. A message is written in the transaction of the change it
+// describes, so a change is published once it commits even when the process
+// stops before publishing it, and a change that is rolled back never is.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"go.hollow.sh/dnscontroller/internal/models"
+)
+
+// Message is the API model for an outbox message, before and after hold the
+// resource as the API returns it. Messages of a transaction share its
+// version, the commit timestamp in nanoseconds.
+type Message struct {
+	UUID       uuid.UUID       `json:"uuid"`
+	Event      string          `json:"event"`
+	Record     string          `json:"record"`
+	RecordType string          `json:"record_type"`
+	OwnerID    string          `json:"owner_id,omitempty"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	Version    string          `json:"version"`
+	CreatedAt  time.Time       `json:"created_at"`
+	version    int64
+	sequence   int64
+}
+
+// Key returns the record of the message, the messages of a record are
+// published in order
+func (m *Message) Key() string {
+	return m.Record + "/" + m.RecordType
+}
+
+// Write stores a message for a change made with exec
+func Write(ctx context.Context, exec boil.ContextExecutor, m *Message) error {
+	_, err := exec.ExecContext(ctx,
+		"INSERT INTO outbox (event, record, record_type, owner_id, before, after, version) "+
+			"VALUES ($1, $2, $3, $4, $5, $6, cluster_logical_timestamp()::INT8)",
+		m.Event, m.Record, m.RecordType,
+		null.NewString(m.OwnerID, m.OwnerID != ""),
+		null.NewJSON(m.Before, len(m.Before) > 0),
+		null.NewJSON(m.After, len(m.After) > 0),
+	)
+
+	return err
+}
+
+// WriteChange stores a message for a change made with exec, before and after
+// are stored as JSON when they are set. Every write of a record or answer
+// calls it next to its audit event.
+func WriteChange(ctx context.Context, exec boil.ContextExecutor, m *Message, before, after interface{}) error {
+	var err error
+
+	if m.Before, err = marshal(before); err != nil {
+		return err
+	}
+
+	if m.After, err = marshal(after); err != nil {
+		return err
+	}
+
+	return Write(ctx, exec, m)
+}
+
+func marshal(v interface{}) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(v)
+}
+
+// Pending returns up to limit messages that weren't delivered yet, in commit
+// order. When after is set only the messages committed after it are
+// returned, so the pending messages can be read page by page.
+func Pending(ctx context.Context, db *sqlx.DB, after *Message, limit int) ([]*Message, error) {
+	mods := []qm.QueryMod{models.OutboxWhere.DeliveredAt.IsNull()}
+
+	if after != nil {
+		mods = append(mods, qm.Where("(version, sequence) > (?, ?)", after.version, after.sequence))
+	}
+
+	mods = append(mods,
+		qm.OrderBy("version, sequence"),
+		qm.Limit(limit),
+	)
+
+	dbMessages, err := models.Outboxes(mods...).All(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]*Message, 0, len(dbMessages))
+
+	for _, dbMessage := range dbMessages {
+		m := &Message{}
+		if err := m.FromDBModel(dbMessage); err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, m)
+	}
+
+	return messages, nil
+}
+
+// MarkDelivered records that the message was published, so the relay doesn't
+// publish it again
+func (m *Message) MarkDelivered(ctx context.Context, db *sqlx.DB) error {
+	_, err := db.ExecContext(ctx, "UPDATE outbox SET delivered_at = now() WHERE id = $1", m.UUID.String())
+
+	return err
+}
+
+// Prune deletes the messages delivered before the time and returns how many
+// were deleted
+func Prune(ctx context.Context, db *sqlx.DB, before time.Time) (int64, error) {
+	return models.Outboxes(models.OutboxWhere.DeliveredAt.LT(null.TimeFrom(before))).DeleteAll(ctx, db)
+}
+
+// FromDBModel converts a db type to an api type
+func (m *Message) FromDBModel(dbT *models.Outbox) error {
+	m.Event = dbT.Event
+	m.Record = dbT.Record
+	m.RecordType = dbT.RecordType
+	m.OwnerID = dbT.OwnerID.String
+	m.Before = json.RawMessage(dbT.Before.JSON)
+	m.After = json.RawMessage(dbT.After.JSON)
+	m.Version = strconv.FormatInt(dbT.Version, 10)
+	m.version = dbT.Version
+	m.sequence = dbT.Sequence
+	m.CreatedAt = dbT.CreatedAt
+
+	var err error
+
+	m.UUID, err = uuid.Parse(dbT.ID)
+
+	return err
+}
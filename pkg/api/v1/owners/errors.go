@@ -0,0 +1,20 @@
+package owner
+
+import "errors"
+
+var (
+	// ErrorInvalidOwner is a generic invalid response
+	ErrorInvalidOwner = errors.New("invalid owner format")
+	// ErrorInvalidOwnerID is when the owner in the URL isn't a uuid
+	ErrorInvalidOwnerID = errors.New("invalid owner id")
+	// ErrorNoOwnerName is when a request / owner doesn't have a name
+	ErrorNoOwnerName = errors.New("no owner name")
+	// ErrorNoOwnerOrigin is when a request / owner doesn't have an origin
+	ErrorNoOwnerOrigin = errors.New("no owner origin")
+	// ErrorNotOwner is when the caller isn't the principal of the owner
+	ErrorNotOwner = errors.New("owner belongs to another caller")
+	// ErrorNoOwnerService is when a request / owner doesn't have a service
+	ErrorNoOwnerService = errors.New("no owner service")
+	// ErrorOwnerHasAnswers when an owner is removed before its answers
+	ErrorOwnerHasAnswers = errors.New("owner still has answers")
+)
@@ -0,0 +1,310 @@
+// Package owner wraps the CRUD operations for a models.Owner
+package owner
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/cockroachdb/cockroach-go/v2/crdb/crdbsqlx"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"go.hollow.sh/dnscontroller/internal/models"
+	"go.hollow.sh/dnscontroller/pkg/api/v1/audit"
+)
+
+// identityColumns is the unique index an owner is resolved by
+var identityColumns = []string{
+	models.OwnerColumns.Name,
+	models.OwnerColumns.Origin,
+	models.OwnerColumns.Service,
+}
+
+func qmOwnerIdentity(name, origin, service string) qm.QueryMod {
+	mods := []qm.QueryMod{}
+
+	mods = append(mods, qm.Where("name=?", name))
+	mods = append(mods, qm.Where("origin=?", origin))
+	mods = append(mods, qm.Where("service=?", service))
+
+	return qm.Expr(mods...)
+}
+
+// List returns all the owners
+func List(ctx context.Context, db *sqlx.DB) ([]*Owner, error) {
+	dbOwners, err := models.Owners(
+		qm.OrderBy(models.OwnerColumns.Name),
+		qm.OrderBy(models.OwnerColumns.Origin),
+		qm.OrderBy(models.OwnerColumns.Service),
+	).All(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	owners := make([]*Owner, 0, len(dbOwners))
+
+	for _, dbOwner := range dbOwners {
+		o := &Owner{}
+		if err := o.FromDBModel(dbOwner); err != nil {
+			return nil, err
+		}
+
+		owners = append(owners, o)
+	}
+
+	return owners, nil
+}
+
+// Remove deletes the owner with exec. Its answers must be deleted first,
+// each with an event of its own, which answer.DeleteOwner does, so the
+// cascade never removes them unseen.
+func (o *Owner) Remove(ctx context.Context, exec boil.ContextExecutor) error {
+	if err := o.FindByUUID(ctx, exec); err != nil {
+		return err
+	}
+
+	hasAnswers, err := models.Answers(models.AnswerWhere.OwnerID.EQ(o.UUID.String())).Exists(ctx, exec)
+	if err != nil {
+		return err
+	}
+
+	if hasAnswers {
+		return ErrorOwnerHasAnswers
+	}
+
+	if _, err := o.ToDBModel().Delete(ctx, exec); err != nil {
+		return err
+	}
+
+	return audit.Write(ctx, exec, &audit.Event{Operation: audit.OwnerDelete, OwnerID: o.UUID.String()}, o, nil)
+}
+
+// Create is FindOrCreate in a transaction of its own
+func (o *Owner) Create(ctx context.Context, db *sqlx.DB) error {
+	return crdbsqlx.ExecuteTx(ctx, db, nil, func(tx *sqlx.Tx) error {
+		return o.FindOrCreate(ctx, tx)
+	})
+}
+
+// FindOrCreate resolves the owner by name, origin and service, creating it if
+// needed. Concurrent calls for the same identity resolve to the same owner.
+func (o *Owner) FindOrCreate(ctx context.Context, exec boil.ContextExecutor) error {
+	err := o.Find(ctx, exec)
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	dbOwner := o.ToDBModel()
+
+	// An owner created concurrently makes this a no-op
+	if err := dbOwner.Upsert(ctx, exec, false, identityColumns, boil.None(), boil.Infer()); err != nil {
+		return err
+	}
+
+	if err := o.Find(ctx, exec); err != nil {
+		return err
+	}
+
+	return audit.Write(ctx, exec, &audit.Event{Operation: audit.OwnerCreate, OwnerID: o.UUID.String()}, nil, o)
+}
+
+// Find looks the owner up by name, origin and service
+func (o *Owner) Find(ctx context.Context, exec boil.ContextExecutor) error {
+	if err := o.Validate(); err != nil {
+		return err
+	}
+
+	dbOwner, err := models.Owners(qmOwnerIdentity(o.Name, o.Origin, o.Service)).One(ctx, exec)
+	if err != nil {
+		return err
+	}
+
+	return o.FromDBModel(dbOwner)
+}
+
+// FindByUUID looks the owner up by its id
+func (o *Owner) FindByUUID(ctx context.Context, exec boil.ContextExecutor) error {
+	dbOwner, err := models.FindOwner(ctx, exec, o.UUID.String())
+	if err != nil {
+		return err
+	}
+
+	return o.FromDBModel(dbOwner)
+}
+
+// Update replaces the name, origin, service and principal of the owner with
+// the given id
+func (o *Owner) Update(ctx context.Context, db *sqlx.DB) error {
+	if err := o.Validate(); err != nil {
+		return err
+	}
+
+	return crdbsqlx.ExecuteTx(ctx, db, nil, func(tx *sqlx.Tx) error {
+		dbOwner, err := models.FindOwner(ctx, tx, o.UUID.String())
+		if err != nil {
+			return err
+		}
+
+		before := &Owner{}
+		if err := before.FromDBModel(dbOwner); err != nil {
+			return err
+		}
+
+		dbOwner.Name = o.Name
+		dbOwner.Origin = o.Origin
+		dbOwner.Service = o.Service
+		dbOwner.Principal = null.NewString(o.Principal, o.Principal != "")
+
+		if _, err := dbOwner.Update(ctx, tx, boil.Infer()); err != nil {
+			return err
+		}
+
+		if err := o.FromDBModel(dbOwner); err != nil {
+			return err
+		}
+
+		return audit.Write(ctx, tx, &audit.Event{Operation: audit.OwnerUpdate, OwnerID: o.UUID.String()}, before, o)
+	})
+}
+
+// Claim is ClaimWith in a transaction of its own
+func (o *Owner) Claim(ctx context.Context, db *sqlx.DB, principal string) error {
+	return crdbsqlx.ExecuteTx(ctx, db, nil, func(tx *sqlx.Tx) error {
+		return o.ClaimWith(ctx, tx, principal)
+	})
+}
+
+// ClaimWith gives the owner with the given id to the principal when it has
+// none, like the owners stored before owners had principals. An owner claimed
+// in the meantime is only kept when it went to the same principal.
+func (o *Owner) ClaimWith(ctx context.Context, exec boil.ContextExecutor, principal string) error {
+	dbOwner, err := models.FindOwner(ctx, exec, o.UUID.String())
+	if err != nil {
+		return err
+	}
+
+	before := &Owner{}
+	if err := before.FromDBModel(dbOwner); err != nil {
+		return err
+	}
+
+	if before.Principal != "" {
+		*o = *before
+
+		return o.Authorize(principal)
+	}
+
+	dbOwner.Principal = null.StringFrom(principal)
+
+	if _, err := dbOwner.Update(ctx, exec, boil.Whitelist(models.OwnerColumns.Principal, models.OwnerColumns.UpdatedAt)); err != nil {
+		return err
+	}
+
+	if err := o.FromDBModel(dbOwner); err != nil {
+		return err
+	}
+
+	return audit.Write(ctx, exec, &audit.Event{Operation: audit.OwnerUpdate, OwnerID: o.UUID.String()}, before, o)
+}
+
+// FromDBModel converts a db type to an api type
+func (o *Owner) FromDBModel(dbT *models.Owner) error {
+	o.Name = dbT.Name
+	o.Origin = dbT.Origin
+	o.Service = dbT.Service
+	o.Principal = dbT.Principal.String
+	o.CreatedAt = dbT.CreatedAt
+	o.UpdatedAt = dbT.UpdatedAt
+
+	var err error
+
+	o.UUID, err = uuid.Parse(dbT.ID)
+
+	return err
+}
+
+// ToDBModel converts the api type to db type
+func (o *Owner) ToDBModel() *models.Owner {
+	dbModel := &models.Owner{
+		Name:      o.Name,
+		Origin:    o.Origin,
+		Service:   o.Service,
+		Principal: null.NewString(o.Principal, o.Principal != ""),
+		CreatedAt: o.CreatedAt,
+		UpdatedAt: o.UpdatedAt,
+	}
+
+	if o.UUID.String() != uuid.Nil.String() {
+		dbModel.ID = o.UUID.String()
+	}
+
+	return dbModel
+}
+
+// NewOwner creates an owner from the request body and validates it, the
+// owner id is taken from the URL params when present
+func NewOwner(c *gin.Context) (*Owner, error) {
+	o := &Owner{}
+	if err := c.ShouldBindJSON(o); err != nil {
+		return nil, err
+	}
+
+	o.UUID = uuid.Nil
+
+	if c.Param("owner") != "" {
+		id, err := ParseUUID(c)
+		if err != nil {
+			return nil, err
+		}
+
+		o.UUID = id
+	}
+
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+// ParseUUID returns the owner id from the URL params
+func ParseUUID(c *gin.Context) (uuid.UUID, error) {
+	id, err := uuid.Parse(c.Param("owner"))
+	if err != nil {
+		return uuid.Nil, ErrorInvalidOwnerID
+	}
+
+	return id, nil
+}
+
+// Authorize ensures the owner belongs to the principal, an owner without a
+// principal belongs to nobody but admins
+func (o *Owner) Authorize(principal string) error {
+	if principal == "" || o.Principal != principal {
+		return ErrorNotOwner
+	}
+
+	return nil
+}
+
+// Validate ensures the owner identity is complete
+func (o *Owner) Validate() error {
+	if o.Name == "" {
+		return ErrorNoOwnerName
+	}
+
+	if o.Origin == "" {
+		return ErrorNoOwnerOrigin
+	}
+
+	if o.Service == "" {
+		return ErrorNoOwnerService
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package owner
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Owner is the API model for an owner, the name, origin and service are the
+// identity a client registers its answers under. The principal is the caller
+// allowed to change the owner's answers.
+type Owner struct {
+	Name      string    `json:"name"`
+	Origin    string    `json:"origin"`
+	Service   string    `json:"service"`
+	Principal string    `json:"principal,omitempty"`
+	UUID      uuid.UUID `json:"uuid"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,92 @@
+package record
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+
+	"go.hollow.sh/dnscontroller/pkg/api/v1/batch"
+)
+
+// Batch is a list of record operations applied in one transaction
+type Batch struct {
+	Mode       string       `json:"mode"`
+	Operations []*Operation `json:"operations"`
+}
+
+// Operation creates, upserts or deletes a record. Upserting leaves an
+// existing record as is, like POST does.
+type Operation struct {
+	Op   string `json:"op"`
+	Name string `json:"record"`
+	Type string `json:"record_type"`
+}
+
+// NewBatch creates a batch from the request body and validates its mode and
+// size, the mode defaults to atomic. The operations are validated when they
+// are applied, so a best effort batch still applies the valid ones.
+func NewBatch(c *gin.Context) (*Batch, error) {
+	b := &Batch{}
+	if err := c.ShouldBindJSON(b); err != nil {
+		return nil, err
+	}
+
+	if b.Mode == "" {
+		b.Mode = batch.ModeAtomic
+	}
+
+	for i, op := range b.Operations {
+		if op == nil {
+			b.Operations[i] = &Operation{}
+		}
+	}
+
+	return b, batch.Validate(b.Mode, len(b.Operations))
+}
+
+// Record returns the record the operation writes, validated
+func (op *Operation) Record() (*Record, error) {
+	switch op.Op {
+	case batch.OpCreate, batch.OpUpsert, batch.OpDelete:
+	default:
+		return nil, batch.ErrorInvalidOperation
+	}
+
+	// Deletes look a stored record up, which may predate the name rules
+	if op.Op == batch.OpDelete {
+		return NewStoredRecordFromName(op.Name, op.Type)
+	}
+
+	return NewRecordFromName(op.Name, op.Type)
+}
+
+// Apply writes the operation with exec, it is a batch.Step. A delete fails
+// with ErrorRecordHasAnswers unless the answers were deleted first, see
+// answer.DeleteRecordAnswers.
+func (op *Operation) Apply(ctx context.Context, exec boil.ContextExecutor) error {
+	r, err := op.Record()
+	if err != nil {
+		return err
+	}
+
+	switch op.Op {
+	case batch.OpCreate:
+		err := r.Find(ctx, exec)
+		if err == nil {
+			return ErrorRecordExists
+		}
+
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		return r.FindOrCreate(ctx, exec)
+	case batch.OpUpsert:
+		return r.FindOrCreate(ctx, exec)
+	default:
+		return r.Remove(ctx, exec)
+	}
+}
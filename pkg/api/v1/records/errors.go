@@ -1,6 +1,9 @@
 package record
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	// ErrorInvalidRecord is a generic invalid response
@@ -11,4 +14,85 @@ var (
 	ErrorNoRecordType = errors.New("no record type")
 	// ErrorUnsupportedType when a request for an unsupported record type occurs
 	ErrorUnsupportedType = errors.New("unsupported record type")
+	// ErrorCNAMEConflict when a CNAME would share its name with another record
+	ErrorCNAMEConflict = errors.New("a CNAME record can't share its name with other records")
+	// ErrorInvalidPTRName when a PTR record isn't under in-addr.arpa or ip6.arpa
+	ErrorInvalidPTRName = errors.New("PTR records must be under in-addr.arpa or ip6.arpa")
+	// ErrorNameTooLong when a name is longer than 253 characters
+	ErrorNameTooLong = errors.New("name is longer than 253 characters")
+	// ErrorLabelTooLong when a label is longer than 63 characters
+	ErrorLabelTooLong = errors.New("label is longer than 63 characters")
+	// ErrorEmptyLabel when a name has an empty label, like "a..b"
+	ErrorEmptyLabel = errors.New("empty label")
+	// ErrorInvalidCharacter when a label has something other than letters,
+	// digits, hyphens or a leading underscore
+	ErrorInvalidCharacter = errors.New("label has invalid characters")
+	// ErrorInvalidHyphen when a label starts or ends with a hyphen
+	ErrorInvalidHyphen = errors.New("label starts or ends with a hyphen")
+	// ErrorInvalidIDN when a unicode name can't be converted to punycode
+	ErrorInvalidIDN = errors.New("name can't be converted to punycode")
+	// ErrorInvalidSRVName when a SRV record doesn't follow _service._proto.name
+	ErrorInvalidSRVName = errors.New("SRV records must be named _service._proto.name")
+	// ErrorRecordHasAnswers when a record is removed before its answers
+	ErrorRecordHasAnswers = errors.New("record still has answers")
+	// ErrorRecordExists when a batch creates a record that is already stored
+	ErrorRecordExists = errors.New("record already exists")
+	// ErrorPreconditionFailed when the If-Match or If-None-Match of a write
+	// doesn't hold for the record
+	ErrorPreconditionFailed = errors.New("record doesn't match the precondition")
 )
+
+// NameError is returned when a record name is invalid, Err is one of the
+// name errors above and Label is the offending label when there is one
+type NameError struct {
+	Name  string
+	Label string
+	Err   error
+}
+
+func (e *NameError) Error() string {
+	if e.Label != "" {
+		return fmt.Sprintf("invalid name %q, label %q: %s", e.Name, e.Label, e.Err)
+	}
+
+	return fmt.Sprintf("invalid name %q: %s", e.Name, e.Err)
+}
+
+// Unwrap allows errors.Is to match the name errors
+func (e *NameError) Unwrap() error {
+	return e.Err
+}
+
+// nameErrorCodes are the codes the API reports name errors with, unlike the
+// messages they don't change
+var nameErrorCodes = map[error]string{
+	ErrorNameTooLong:      "name_too_long",
+	ErrorLabelTooLong:     "label_too_long",
+	ErrorEmptyLabel:       "empty_label",
+	ErrorInvalidCharacter: "invalid_character",
+	ErrorInvalidHyphen:    "invalid_hyphen",
+	ErrorInvalidIDN:       "invalid_idn",
+	ErrorInvalidSRVName:   "invalid_srv_name",
+}
+
+// Code returns the stable code of the name error, invalid_name when Err
+// isn't one of the name errors
+func (e *NameError) Code() string {
+	if code, ok := nameErrorCodes[e.Err]; ok {
+		return code
+	}
+
+	return "invalid_name"
+}
+
+// ErrorFromCode returns the name error a code was made from, or nil when the
+// code isn't one of them
+func ErrorFromCode(code string) error {
+	for err, c := range nameErrorCodes {
+		if c == code {
+			return err
+		}
+	}
+
+	return nil
+}
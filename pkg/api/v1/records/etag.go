@@ -0,0 +1,106 @@
+package record
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"go.hollow.sh/dnscontroller/internal/models"
+)
+
+// Precondition is the If-Match and If-None-Match headers of a write, as
+// lists of entity tags or *
+type Precondition struct {
+	IfMatch     string
+	IfNoneMatch string
+}
+
+type preconditionKey struct{}
+
+// WithPrecondition returns a context whose writes to a record only happen
+// when the precondition holds
+func WithPrecondition(ctx context.Context, p *Precondition) context.Context {
+	return context.WithValue(ctx, preconditionKey{}, p)
+}
+
+// ETag returns the entity tag of the record and its answers, every write to
+// either changes it. The record must have been found.
+func (r *Record) ETag(ctx context.Context, exec boil.ContextExecutor) (string, error) {
+	dbAnswers, err := models.Answers(
+		qm.Select(models.AnswerColumns.ID, models.AnswerColumns.UpdatedAt),
+		qm.Where("record_id=?", r.UUID.String()),
+		qm.OrderBy(models.AnswerColumns.ID),
+	).All(ctx, exec)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s %s\n", r.UUID, r.UpdatedAt.Format(time.RFC3339Nano))
+
+	for _, dbAnswer := range dbAnswers {
+		fmt.Fprintf(h, "%s %s\n", dbAnswer.ID, dbAnswer.UpdatedAt.Format(time.RFC3339Nano))
+	}
+
+	return `"` + hex.EncodeToString(h.Sum(nil)) + `"`, nil
+}
+
+// CheckPrecondition returns ErrorPreconditionFailed when the precondition of
+// the context doesn't hold for the stored record, it is called with the
+// executor of the write so nothing changes the record in between
+func (r *Record) CheckPrecondition(ctx context.Context, exec boil.ContextExecutor) error {
+	p, _ := ctx.Value(preconditionKey{}).(*Precondition)
+	if p == nil || (p.IfMatch == "" && p.IfNoneMatch == "") {
+		return nil
+	}
+
+	etag := ""
+
+	err := r.Find(ctx, exec)
+
+	switch {
+	case err == nil:
+		if etag, err = r.ETag(ctx, exec); err != nil {
+			return err
+		}
+	case !errors.Is(err, sql.ErrNoRows):
+		return err
+	}
+
+	// A missing record matches neither a tag nor *
+	if p.IfMatch != "" && (etag == "" || !MatchETag(p.IfMatch, etag, false)) {
+		return ErrorPreconditionFailed
+	}
+
+	if p.IfNoneMatch != "" && etag != "" && MatchETag(p.IfNoneMatch, etag, true) {
+		return ErrorPreconditionFailed
+	}
+
+	return nil
+}
+
+// MatchETag reports whether the header, a list of entity tags or *, matches
+// the tag. Weak comparison ignores the W/ prefix (RFC 9110 8.8.3.2).
+func MatchETag(header, etag string, weak bool) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+
+		if weak {
+			tag = strings.TrimPrefix(tag, "W/")
+		}
+
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,156 @@
+package record
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"go.hollow.sh/dnscontroller/internal/models"
+)
+
+const (
+	// DefaultPageSize is used when a list request doesn't specify a page size
+	DefaultPageSize = 100
+	// MaxPageSize is the largest page a list request can ask for
+	MaxPageSize = 1000
+)
+
+// likeEscaper escapes the wildcards of a LIKE pattern
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// ListParams allow you to filter and paginate the records
+type ListParams struct {
+	Type     string `form:"type"`
+	Prefix   string `form:"prefix"`
+	Suffix   string `form:"suffix"`
+	Owner    string `form:"owner"`
+	Origin   string `form:"origin"`
+	Page     int    `form:"page"`
+	PageSize int    `form:"page_size"`
+}
+
+// NewListParams creates list params from the URL query and validates them
+func NewListParams(c *gin.Context) (*ListParams, error) {
+	p := &ListParams{}
+	if err := c.ShouldBindQuery(p); err != nil {
+		return nil, err
+	}
+
+	// Sanitize input
+	p.Type = strings.ToUpper(p.Type)
+	p.Prefix = strings.ToLower(p.Prefix)
+	p.Suffix = strings.ToLower(p.Suffix)
+
+	// Names are stored fully qualified
+	if p.Suffix != "" && !strings.HasSuffix(p.Suffix, ".") {
+		p.Suffix += "."
+	}
+
+	if p.Page < 1 {
+		p.Page = 1
+	}
+
+	if p.PageSize < 1 {
+		p.PageSize = DefaultPageSize
+	}
+
+	if p.PageSize > MaxPageSize {
+		p.PageSize = MaxPageSize
+	}
+
+	if p.Type != "" {
+		if err := isSupportedRecordType(p.Type); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+// TotalPages returns the number of pages needed for count records
+func (p *ListParams) TotalPages(count int64) int {
+	return int((count + int64(p.PageSize) - 1) / int64(p.PageSize))
+}
+
+// queryMods returns the filters, the pagination is added separately so the
+// filters can be reused to count the records
+func (p *ListParams) queryMods() []qm.QueryMod {
+	mods := []qm.QueryMod{}
+
+	if p.Type != "" {
+		mods = append(mods, qm.Where("record_type=?", p.Type))
+	}
+
+	if p.Prefix != "" {
+		mods = append(mods, qm.Where("record LIKE ?", likeEscaper.Replace(p.Prefix)+"%"))
+	}
+
+	if p.Suffix != "" {
+		mods = append(mods, qm.Where("record LIKE ?", "%"+likeEscaper.Replace(p.Suffix)))
+	}
+
+	if p.Owner != "" || p.Origin != "" {
+		mods = append(mods, qmHasAnswerFrom(p.Owner, p.Origin))
+	}
+
+	return mods
+}
+
+// qmHasAnswerFrom matches records with at least one answer from an owner
+// with the given name and / or origin
+func qmHasAnswerFrom(name, origin string) qm.QueryMod {
+	clause := "EXISTS (SELECT 1 FROM answers JOIN owners ON owners.id = answers.owner_id WHERE answers.record_id = records.id"
+	args := []interface{}{}
+
+	if name != "" {
+		clause += " AND owners.name = ?"
+		args = append(args, name)
+	}
+
+	if origin != "" {
+		clause += " AND owners.origin = ?"
+		args = append(args, origin)
+	}
+
+	return qm.Where(clause+")", args...)
+}
+
+// List returns a page of records matching the params, along with the total
+// number of matching records
+func List(ctx context.Context, db *sqlx.DB, p *ListParams) ([]*Record, int64, error) {
+	mods := p.queryMods()
+
+	count, err := models.Records(mods...).Count(ctx, db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Sort on the unique index and the id so pages are stable
+	mods = append(mods,
+		qm.OrderBy("record, record_type, id"),
+		qm.Limit(p.PageSize),
+		qm.Offset((p.Page-1)*p.PageSize),
+	)
+
+	dbRecords, err := models.Records(mods...).All(ctx, db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	records := make([]*Record, 0, len(dbRecords))
+
+	for _, dbRecord := range dbRecords {
+		r := &Record{}
+		if err := r.FromDBModel(dbRecord); err != nil {
+			return nil, 0, err
+		}
+
+		r.path = r.Name + "/" + r.Type
+		records = append(records, r)
+	}
+
+	return records, count, nil
+}
@@ -0,0 +1,163 @@
+package record
+
+import (
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+
+	"go.hollow.sh/dnscontroller/internal/models"
+)
+
+func newTestContext(query string) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/api/v1/records?"+query, nil)
+
+	return c
+}
+
+func TestNewListParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    *ListParams
+		wantErr error
+	}{
+		{
+			name:  "defaults",
+			query: "",
+			want:  &ListParams{Page: 1, PageSize: DefaultPageSize},
+		},
+		{
+			name:  "type",
+			query: "type=srv",
+			want:  &ListParams{Type: "SRV", Page: 1, PageSize: DefaultPageSize},
+		},
+		{
+			name:    "unsupported type",
+			query:   "type=SOA",
+			wantErr: ErrorUnsupportedType,
+		},
+		{
+			name:  "prefix and suffix",
+			query: "prefix=WWW&suffix=Example.com",
+			want:  &ListParams{Prefix: "www", Suffix: "example.com.", Page: 1, PageSize: DefaultPageSize},
+		},
+		{
+			name:  "fully qualified suffix",
+			query: "suffix=example.com.",
+			want:  &ListParams{Suffix: "example.com.", Page: 1, PageSize: DefaultPageSize},
+		},
+		{
+			name:  "owner and origin",
+			query: "owner=cluster-a&origin=kubernetes",
+			want:  &ListParams{Owner: "cluster-a", Origin: "kubernetes", Page: 1, PageSize: DefaultPageSize},
+		},
+		{
+			name:  "page",
+			query: "page=3&page_size=20",
+			want:  &ListParams{Page: 3, PageSize: 20},
+		},
+		{
+			name:  "page size clamped",
+			query: "page=0&page_size=5000",
+			want:  &ListParams{Page: 1, PageSize: MaxPageSize},
+		},
+		{
+			name:  "negative page",
+			query: "page=-2&page_size=-1",
+			want:  &ListParams{Page: 1, PageSize: DefaultPageSize},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewListParams(newTestContext(tt.query))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewListParams() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewListParams() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryMods(t *testing.T) {
+	const hasAnswer = "EXISTS (SELECT 1 FROM answers JOIN owners ON owners.id = answers.owner_id WHERE answers.record_id = records.id"
+
+	tests := []struct {
+		name      string
+		params    *ListParams
+		wantWhere string
+		wantArgs  []interface{}
+	}{
+		{
+			name:   "no filters",
+			params: &ListParams{},
+		},
+		{
+			name:      "type",
+			params:    &ListParams{Type: "A"},
+			wantWhere: `WHERE (record_type=$1)`,
+			wantArgs:  []interface{}{"A"},
+		},
+		{
+			name:      "wildcards escaped",
+			params:    &ListParams{Prefix: `_http%`, Suffix: `a\b.example.com.`},
+			wantWhere: `WHERE (record LIKE $1) AND (record LIKE $2)`,
+			wantArgs:  []interface{}{`\_http\%%`, `%a\\b.example.com.`},
+		},
+		{
+			name:      "owner",
+			params:    &ListParams{Owner: "cluster-a"},
+			wantWhere: `WHERE (` + hasAnswer + ` AND owners.name = $1))`,
+			wantArgs:  []interface{}{"cluster-a"},
+		},
+		{
+			name:      "origin",
+			params:    &ListParams{Origin: "kubernetes"},
+			wantWhere: `WHERE (` + hasAnswer + ` AND owners.origin = $1))`,
+			wantArgs:  []interface{}{"kubernetes"},
+		},
+		{
+			name:      "every filter",
+			params:    &ListParams{Type: "SRV", Prefix: "_http", Suffix: "example.com.", Owner: "cluster-a", Origin: "kubernetes"},
+			wantWhere: `WHERE (record_type=$1) AND (record LIKE $2) AND (record LIKE $3) AND (` + hasAnswer + ` AND owners.name = $4 AND owners.origin = $5))`,
+			wantArgs:  []interface{}{"SRV", `\_http%`, "%example.com.", "cluster-a", "kubernetes"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := queries.BuildQuery(models.Records(tt.params.queryMods()...).Query)
+
+			want := `SELECT "records".* FROM "records"`
+			if tt.wantWhere != "" {
+				want += " " + tt.wantWhere
+			}
+
+			if sql != want+";" {
+				t.Errorf("query = %s, want %s;", sql, want)
+			}
+
+			if len(args) != len(tt.wantArgs) || (len(args) > 0 && !reflect.DeepEqual(args, tt.wantArgs)) {
+				t.Errorf("args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestTotalPages(t *testing.T) {
+	p := &ListParams{PageSize: 10}
+
+	for count, want := range map[int64]int{0: 0, 1: 1, 10: 1, 11: 2} {
+		if got := p.TotalPages(count); got != want {
+			t.Errorf("TotalPages(%d) = %d, want %d", count, got, want)
+		}
+	}
+}
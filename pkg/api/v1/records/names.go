@@ -0,0 +1,123 @@
+package record
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+const (
+	// maxLabelLength is the longest label of a name (RFC 1035 2.3.4)
+	maxLabelLength = 63
+	// maxNameLength is the longest name in its text form without the
+	// trailing dot (RFC 1035 2.3.4)
+	maxNameLength = 253
+	// minSRVLabels is the service, protocol and at least one label of the
+	// name (RFC 2782)
+	minSRVLabels = 3
+)
+
+// CanonicalName converts a name to the form it is stored in: lower case,
+// punycode encoded (RFC 5891) and fully qualified with a trailing dot
+func CanonicalName(name string) (string, error) {
+	if name == "" {
+		return "", ErrorNoRecordName
+	}
+
+	canonical := strings.ToLower(name)
+
+	if !isASCII(canonical) {
+		var err error
+
+		canonical, err = idna.Lookup.ToASCII(canonical)
+		if err != nil {
+			return "", &NameError{Name: name, Err: ErrorInvalidIDN}
+		}
+	}
+
+	if !strings.HasSuffix(canonical, ".") {
+		canonical += "."
+	}
+
+	if err := ValidateName(canonical); err != nil {
+		return "", err
+	}
+
+	return canonical, nil
+}
+
+// ValidateName checks a canonical name: at most 253 characters, labels of 1
+// to 63 letters, digits and hyphens that don't start or end with a hyphen
+// (RFC 1035 2.3.1, RFC 1123 2.1). Labels may start with an underscore for
+// service names (RFC 8552) and the first label may be a wildcard.
+func ValidateName(name string) error {
+	trimmed := strings.TrimSuffix(name, ".")
+
+	if trimmed == "" {
+		return &NameError{Name: name, Err: ErrorEmptyLabel}
+	}
+
+	if len(trimmed) > maxNameLength {
+		return &NameError{Name: name, Err: ErrorNameTooLong}
+	}
+
+	for i, label := range strings.Split(trimmed, ".") {
+		if err := validateLabel(label, i == 0); err != nil {
+			return &NameError{Name: name, Label: label, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// validateSRVName checks the name follows _service._proto.name (RFC 2782)
+func validateSRVName(name string) error {
+	labels := strings.Split(strings.TrimSuffix(name, "."), ".")
+
+	if len(labels) < minSRVLabels {
+		return &NameError{Name: name, Err: ErrorInvalidSRVName}
+	}
+
+	for _, label := range labels[:2] {
+		if len(label) < 2 || label[0] != '_' {
+			return &NameError{Name: name, Label: label, Err: ErrorInvalidSRVName}
+		}
+	}
+
+	return nil
+}
+
+func validateLabel(label string, first bool) error {
+	switch {
+	case label == "":
+		return ErrorEmptyLabel
+	case len(label) > maxLabelLength:
+		return ErrorLabelTooLong
+	case label == "*" && first:
+		return nil
+	case label[0] == '-' || label[len(label)-1] == '-':
+		return ErrorInvalidHyphen
+	}
+
+	for i, r := range label {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-':
+		case r == '_' && i == 0:
+		default:
+			return ErrorInvalidCharacter
+		}
+	}
+
+	return nil
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,150 @@
+package record
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCanonicalName(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr error
+	}{
+		{name: "fully qualified", in: "www.example.com.", want: "www.example.com."},
+		{name: "trailing dot added", in: "www.example.com", want: "www.example.com."},
+		{name: "lower cased", in: "WWW.Example.COM", want: "www.example.com."},
+		{name: "punycode", in: "bücher.example.com", want: "xn--bcher-kva.example.com."},
+		{name: "upper case unicode", in: "BÜCHER.example.com.", want: "xn--bcher-kva.example.com."},
+		{name: "service", in: "_http._tcp.example.com", want: "_http._tcp.example.com."},
+		{name: "wildcard", in: "*.example.com", want: "*.example.com."},
+		{name: "empty", in: "", wantErr: ErrorNoRecordName},
+		{name: "root", in: ".", wantErr: ErrorEmptyLabel},
+		{name: "empty label", in: "a..example.com", wantErr: ErrorEmptyLabel},
+		{name: "invalid character", in: "a b.example.com", wantErr: ErrorInvalidCharacter},
+		{name: "invalid idn", in: "xn--aä.example.com", wantErr: ErrorInvalidIDN},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CanonicalName(tt.in)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CanonicalName(%q) error = %v, want %v", tt.in, err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("CanonicalName(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	label63 := strings.Repeat("a", maxLabelLength)
+	// 3 labels of 63 and one of 61 make 253 characters with their dots
+	name253 := strings.Repeat(label63+".", 3) + strings.Repeat("a", 61) + "."
+
+	tests := []struct {
+		name      string
+		in        string
+		wantErr   error
+		wantLabel string
+	}{
+		{name: "host", in: "www.example.com."},
+		{name: "digits and hyphens", in: "web-01.example.com."},
+		{name: "service labels", in: "_http._tcp.example.com."},
+		{name: "wildcard", in: "*.example.com."},
+		{name: "longest label", in: label63 + ".example.com."},
+		{name: "longest name", in: name253},
+		{name: "empty", in: ".", wantErr: ErrorEmptyLabel},
+		{name: "empty label", in: "a..example.com.", wantErr: ErrorEmptyLabel},
+		{name: "name too long", in: "b" + name253, wantErr: ErrorNameTooLong},
+		{name: "label too long", in: label63 + "a.example.com.", wantErr: ErrorLabelTooLong, wantLabel: label63 + "a"},
+		{name: "leading hyphen", in: "-web.example.com.", wantErr: ErrorInvalidHyphen, wantLabel: "-web"},
+		{name: "trailing hyphen", in: "web-.example.com.", wantErr: ErrorInvalidHyphen, wantLabel: "web-"},
+		{name: "upper case", in: "WWW.example.com.", wantErr: ErrorInvalidCharacter, wantLabel: "WWW"},
+		{name: "underscore inside a label", in: "web_01.example.com.", wantErr: ErrorInvalidCharacter, wantLabel: "web_01"},
+		{name: "wildcard below the first label", in: "www.*.example.com.", wantErr: ErrorInvalidCharacter, wantLabel: "*"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateName(tt.in)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ValidateName(%q) error = %v, want %v", tt.in, err, tt.wantErr)
+			}
+
+			if err == nil {
+				return
+			}
+
+			var nameErr *NameError
+			if !errors.As(err, &nameErr) || nameErr.Name != tt.in || nameErr.Label != tt.wantLabel {
+				t.Errorf("ValidateName(%q) error = %#v, want a NameError with label %q", tt.in, err, tt.wantLabel)
+			}
+		})
+	}
+}
+
+func TestValidateSRVName(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        string
+		wantErr   bool
+		wantLabel string
+	}{
+		{name: "service", in: "_http._tcp.example.com."},
+		{name: "shortest", in: "_h._u.example."},
+		{name: "too few labels", in: "_http._tcp.", wantErr: true},
+		{name: "no service", in: "www._tcp.example.com.", wantErr: true, wantLabel: "www"},
+		{name: "no protocol", in: "_http.tcp.example.com.", wantErr: true, wantLabel: "tcp"},
+		{name: "bare underscore", in: "_._tcp.example.com.", wantErr: true, wantLabel: "_"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSRVName(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateSRVName(%q) error = %v, want error %v", tt.in, err, tt.wantErr)
+			}
+
+			if err == nil {
+				return
+			}
+
+			var nameErr *NameError
+			if !errors.As(err, &nameErr) || !errors.Is(err, ErrorInvalidSRVName) || nameErr.Label != tt.wantLabel {
+				t.Errorf("validateSRVName(%q) error = %#v, want ErrorInvalidSRVName with label %q", tt.in, err, tt.wantLabel)
+			}
+		})
+	}
+}
+
+func TestNameErrorCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{err: ErrorEmptyLabel, want: "empty_label"},
+		{err: ErrorNameTooLong, want: "name_too_long"},
+		{err: ErrorLabelTooLong, want: "label_too_long"},
+		{err: ErrorInvalidCharacter, want: "invalid_character"},
+		{err: ErrorInvalidHyphen, want: "invalid_hyphen"},
+		{err: ErrorInvalidIDN, want: "invalid_idn"},
+		{err: ErrorInvalidSRVName, want: "invalid_srv_name"},
+		{err: ErrorInvalidRecord, want: "invalid_name"},
+	}
+
+	for _, tt := range tests {
+		got := (&NameError{Name: "a.", Err: tt.err}).Code()
+		if got != tt.want {
+			t.Errorf("Code() of %v = %q, want %q", tt.err, got, tt.want)
+		}
+
+		if tt.want != "invalid_name" && ErrorFromCode(got) != tt.err {
+			t.Errorf("ErrorFromCode(%q) = %v, want %v", got, ErrorFromCode(got), tt.err)
+		}
+	}
+}
@@ -7,12 +7,17 @@ import (
 	"errors"
 	"strings"
 
+	"github.com/cockroachdb/cockroach-go/v2/crdb/crdbsqlx"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/miekg/dns"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
 	"go.hollow.sh/dnscontroller/internal/models"
+	"go.hollow.sh/dnscontroller/pkg/api/v1/audit"
+	"go.hollow.sh/dnscontroller/pkg/api/v1/outbox"
 )
 
 func qmRecordNameAndType(rname, rtype string) qm.QueryMod {
@@ -24,52 +29,104 @@ func qmRecordNameAndType(rname, rtype string) qm.QueryMod {
 	return qm.Expr(mods...)
 }
 
-// Delete removes a record from the DB
-func (r *Record) Delete(ctx context.Context, db *sqlx.DB) error {
-	err := r.validate()
+// Remove deletes the record with exec. Its answers must be deleted first,
+// each with an event of its own, which answer.DeleteRecord does, so the
+// cascade never removes them unseen.
+func (r *Record) Remove(ctx context.Context, exec boil.ContextExecutor) error {
+	dbRecord, err := models.Records(qmRecordNameAndType(r.Name, r.Type)).One(ctx, exec)
 	if err != nil {
 		return err
 	}
 
-	err = r.Find(ctx, db)
+	if err := r.FromDBModel(dbRecord); err != nil {
+		return err
+	}
+
+	hasAnswers, err := models.Answers(models.AnswerWhere.RecordID.EQ(dbRecord.ID)).Exists(ctx, exec)
 	if err != nil {
 		return err
 	}
 
+	if hasAnswers {
+		return ErrorRecordHasAnswers
+	}
+
+	if err := BumpSerial(ctx, exec, dbRecord.ID); err != nil {
+		return err
+	}
+
+	if _, err := dbRecord.Delete(ctx, exec); err != nil {
+		return err
+	}
+
+	if err := outbox.WriteChange(ctx, exec, &outbox.Message{Event: audit.RecordDelete, Record: r.Name, RecordType: r.Type}, r, nil); err != nil {
+		return err
+	}
+
+	return audit.Write(ctx, exec, &audit.Event{Operation: audit.RecordDelete, Record: r.Name, RecordType: r.Type}, r, nil)
+}
+
+// Create is FindOrCreate in a transaction of its own
+func (r *Record) Create(ctx context.Context, db *sqlx.DB) error {
+	return crdbsqlx.ExecuteTx(ctx, db, nil, func(tx *sqlx.Tx) error {
+		if err := r.CheckPrecondition(ctx, tx); err != nil {
+			return err
+		}
+
+		return r.FindOrCreate(ctx, tx)
+	})
+}
+
+// FindOrCreate is the upsert function, the record is inserted and linked to
+// its zone if it doesn't exist yet. The zone is created if none matches.
+func (r *Record) FindOrCreate(ctx context.Context, exec boil.ContextExecutor) error {
+	err := r.Find(ctx, exec)
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
 	dbRecord, err := r.ToDBModel()
 	if err != nil {
 		return err
 	}
 
-	_, err = dbRecord.Delete(ctx, db)
-	if err != nil {
+	if err := r.validateExclusive(ctx, exec); err != nil {
 		return err
 	}
 
-	return nil
-}
+	if err := linkZone(ctx, exec, dbRecord); err != nil {
+		return err
+	}
 
-// FindOrCreate is the upsert function
-func (r *Record) FindOrCreate(ctx context.Context, db *sqlx.DB) error {
-	err := r.Find(ctx, db)
-	if errors.Is(err, sql.ErrNoRows) {
-		return r.Create(ctx, db)
-	} else if err != nil {
+	if err := dbRecord.Insert(ctx, exec, boil.Infer()); err != nil {
 		return err
 	}
 
-	return nil
+	// Set the values back
+	if err := r.FromDBModel(dbRecord); err != nil {
+		return err
+	}
+
+	if err := BumpSerial(ctx, exec, dbRecord.ID); err != nil {
+		return err
+	}
+
+	if err := outbox.WriteChange(ctx, exec, &outbox.Message{Event: audit.RecordCreate, Record: r.Name, RecordType: r.Type}, nil, r); err != nil {
+		return err
+	}
+
+	return audit.Write(ctx, exec, &audit.Event{Operation: audit.RecordCreate, Record: r.Name, RecordType: r.Type}, nil, r)
 }
 
 // Find looks the record up by name,type
-func (r *Record) Find(ctx context.Context, db *sqlx.DB) error {
+func (r *Record) Find(ctx context.Context, exec boil.ContextExecutor) error {
 	if err := r.validate(); err != nil {
 		return err
 	}
 
 	qm := qmRecordNameAndType(r.Name, r.Type)
 
-	dbRecord, err := models.Records(qm).One(ctx, db)
+	dbRecord, err := models.Records(qm).One(ctx, exec)
 	if err != nil {
 		return err
 	}
@@ -77,19 +134,29 @@ func (r *Record) Find(ctx context.Context, db *sqlx.DB) error {
 	return r.FromDBModel(dbRecord)
 }
 
-// Create inserts a record
-func (r *Record) Create(ctx context.Context, db *sqlx.DB) error {
-	dbRecord, err := r.ToDBModel()
-	if err != nil {
-		return err
-	}
+func qmBelow(name string) qm.QueryMod {
+	return qm.Where("record LIKE ?", "%."+likeEscaper.Replace(name))
+}
 
-	if err := dbRecord.Insert(ctx, db, boil.Infer()); err != nil {
-		return err
-	}
+// QMInZone matches the records at or below a zone apex
+func QMInZone(zone string) qm.QueryMod {
+	return qm.Expr(qm.Where("record=?", zone), qm.Or2(qmBelow(zone)))
+}
 
-	// Set the values back
-	return r.FromDBModel(dbRecord)
+// HasDescendants reports whether any record is stored below the name, a name
+// without records of its own still exists when it does (RFC 4592 2.2.2)
+func HasDescendants(ctx context.Context, exec boil.ContextExecutor, name string) (bool, error) {
+	return models.Records(qmBelow(name)).Exists(ctx, exec)
+}
+
+// HasAnswersNotFrom reports whether the record has answers of owners that
+// don't belong to the principal
+func (r *Record) HasAnswersNotFrom(ctx context.Context, exec boil.ContextExecutor, principal string) (bool, error) {
+	return models.Answers(
+		qm.InnerJoin("owners ON owners.id = answers.owner_id"),
+		qm.Where("answers.record_id=?", r.UUID.String()),
+		qm.Where("owners.principal IS DISTINCT FROM ?", principal),
+	).Exists(ctx, exec)
 }
 
 // FromDBModel converts a db type to an api type
@@ -115,6 +182,10 @@ func (r *Record) ToDBModel() (*models.Record, error) {
 		return nil, err
 	}
 
+	if err := r.validateName(); err != nil {
+		return nil, err
+	}
+
 	dbModel := &models.Record{
 		Record:    r.Name,
 		CreatedAt: r.CreatedAt,
@@ -141,25 +212,67 @@ func (r *Record) GetPath() string {
 
 // NewRecord creates a record from the URL params and validates it
 func NewRecord(c *gin.Context) (*Record, error) {
-	var record *Record
-
-	// Try to get record info from URL params
-	rname := c.Param("record")
-	rtype := c.Param("recordtype")
+	return NewRecordFromName(c.Param("record"), c.Param("recordtype"))
+}
 
-	record = &Record{
+// NewRecordFromName creates a record from its name and type and validates it
+func NewRecordFromName(rname, rtype string) (*Record, error) {
+	record := &Record{
 		Name: rname,
 		Type: rtype,
 	}
 
+	// Sanitize input
+	record.Type = strings.ToUpper(record.Type)
+
+	name, err := CanonicalName(record.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	record.Name = name
+
 	// Try to validate
 	if err := record.validate(); err != nil {
 		return nil, err
 	}
 
-	// Sanitize input
-	record.Name = strings.ToLower(record.Name)
-	record.Type = strings.ToUpper(record.Type)
+	if err := record.validateName(); err != nil {
+		return nil, err
+	}
+
+	record.path = record.Name + "/" + record.Type
+
+	return record, nil
+}
+
+// NewStoredRecord creates a record from the URL params to look a stored one
+// up. The name is only made canonical, not held to the name rules, so the
+// records stored before them can still be read and deleted.
+func NewStoredRecord(c *gin.Context) (*Record, error) {
+	return NewStoredRecordFromName(c.Param("record"), c.Param("recordtype"))
+}
+
+// NewStoredRecordFromName creates a record from its name and type to look a
+// stored one up
+func NewStoredRecordFromName(rname, rtype string) (*Record, error) {
+	if rname == "" {
+		return nil, ErrorNoRecordName
+	}
+
+	record := &Record{
+		Name: strings.ToLower(dns.Fqdn(rname)),
+		Type: strings.ToUpper(rtype),
+	}
+
+	if _, ok := dns.IsDomainName(record.Name); !ok {
+		return nil, &NameError{Name: rname, Err: ErrorInvalidRecord}
+	}
+
+	if err := record.validate(); err != nil {
+		return nil, err
+	}
+
 	record.path = record.Name + "/" + record.Type
 
 	return record, nil
@@ -174,18 +287,65 @@ func (r *Record) validate() error {
 		return ErrorNoRecordType
 	}
 
-	if err := isSupportedRecordType(r.Type); err != nil {
+	return isSupportedRecordType(r.Type)
+}
+
+// validateName holds the name to the rules of its type. Records stored
+// before the rules may break them, so they are only checked on writes.
+func (r *Record) validateName() error {
+	if err := ValidateName(r.Name); err != nil {
+		return err
+	}
+
+	if r.Type == "SRV" {
+		if err := validateSRVName(r.Name); err != nil {
+			return err
+		}
+	}
+
+	// Reverse lookups live under the arpa zones (RFC 1035 3.5, RFC 3596 2.5)
+	if r.Type == "PTR" && !strings.HasSuffix(r.Name, ".in-addr.arpa.") && !strings.HasSuffix(r.Name, ".ip6.arpa.") {
+		return ErrorInvalidPTRName
+	}
+
+	return nil
+}
+
+// validateExclusive ensures a name with a CNAME has no other records
+// (RFC 1034 3.6.2)
+func (r *Record) validateExclusive(ctx context.Context, exec boil.ContextExecutor) error {
+	mods := []qm.QueryMod{qm.Where("record=?", r.Name)}
+
+	if r.Type == "CNAME" {
+		mods = append(mods, qm.Where("record_type!=?", r.Type))
+	} else {
+		mods = append(mods, qm.Where("record_type=?", "CNAME"))
+	}
+
+	exists, err := models.Records(mods...).Exists(ctx, exec)
+	if err != nil {
 		return err
 	}
 
+	if exists {
+		return ErrorCNAMEConflict
+	}
+
 	return nil
 }
 
 // Supported dns record types that are supported.
 func supportedRecordTypes() map[string]bool {
 	return map[string]bool{
-		"A":   true,
-		"SRV": true,
+		"A":     true,
+		"AAAA":  true,
+		"CAA":   true,
+		"CNAME": true,
+		"MX":    true,
+		"NS":    true,
+		"PTR":   true,
+		"SRV":   true,
+		"TXT":   true,
 	}
 }
 
@@ -0,0 +1,59 @@
+package record
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"go.hollow.sh/dnscontroller/internal/models"
+)
+
+func TestNewStoredRecordFromName(t *testing.T) {
+	tests := []struct {
+		name     string
+		rname    string
+		rtype    string
+		wantName string
+		wantErr  error
+	}{
+		{name: "canonical", rname: "www.example.com.", rtype: "A", wantName: "www.example.com."},
+		{name: "made canonical", rname: "WWW.Example.com", rtype: "a", wantName: "www.example.com."},
+		{name: "srv without a service", rname: "foo.example.com.", rtype: "SRV", wantName: "foo.example.com."},
+		{name: "ptr outside arpa", rname: "host.example.com.", rtype: "PTR", wantName: "host.example.com."},
+		{name: "underscore inside a label", rname: "web_01.example.com.", rtype: "A", wantName: "web_01.example.com."},
+		{name: "empty", rname: "", rtype: "A", wantErr: ErrorNoRecordName},
+		{name: "empty label", rname: "a..example.com.", rtype: "A", wantErr: ErrorInvalidRecord},
+		{name: "unsupported type", rname: "www.example.com.", rtype: "HINFO", wantErr: ErrorUnsupportedType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewStoredRecordFromName(tt.rname, tt.rtype)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewStoredRecordFromName(%q, %q) error = %v, want %v", tt.rname, tt.rtype, err, tt.wantErr)
+			}
+
+			if err == nil && r.Name != tt.wantName {
+				t.Errorf("NewStoredRecordFromName(%q, %q) name = %q, want %q", tt.rname, tt.rtype, r.Name, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestFromDBModelBeforeNameRules(t *testing.T) {
+	dbRecord := &models.Record{ID: uuid.NewString(), Record: "foo.example.com.", RecordType: "SRV"}
+
+	r := &Record{}
+	if err := r.FromDBModel(dbRecord); err != nil {
+		t.Fatalf("FromDBModel() of a record stored before the name rules error = %v", err)
+	}
+
+	if _, err := r.ToDBModel(); !errors.Is(err, ErrorInvalidSRVName) {
+		t.Errorf("ToDBModel() error = %v, want %v", err, ErrorInvalidSRVName)
+	}
+
+	if _, err := NewRecordFromName("foo.example.com.", "SRV"); !errors.Is(err, ErrorInvalidSRVName) {
+		t.Errorf("NewRecordFromName() error = %v, want %v", err, ErrorInvalidSRVName)
+	}
+}
@@ -0,0 +1,142 @@
+package record
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"go.hollow.sh/dnscontroller/internal/models"
+)
+
+// minZoneLabels is the fewest labels an automatically created zone has, so a
+// record is never put in a top level domain of its own
+const minZoneLabels = 2
+
+// AutoZoneName returns the zone created for a record when no zone matches it.
+// Service labels are dropped along with the host label, so
+// _http._tcp.www.example.com. and www.example.com. are both in example.com.
+func AutoZoneName(name string) string {
+	labels := strings.Split(strings.TrimSuffix(name, "."), ".")
+
+	for len(labels) > minZoneLabels && (strings.HasPrefix(labels[0], "_") || labels[0] == "*") {
+		labels = labels[1:]
+	}
+
+	if len(labels) > minZoneLabels {
+		labels = labels[1:]
+	}
+
+	return strings.Join(labels, ".") + "."
+}
+
+// ancestors returns the name and each of its parents, most specific first
+func ancestors(name string) []interface{} {
+	names := []interface{}{}
+
+	for n := name; n != "" && n != "."; {
+		names = append(names, n)
+		n = n[strings.Index(n, ".")+1:]
+	}
+
+	return names
+}
+
+// findZone returns the longest zone the name is in
+func findZone(ctx context.Context, exec boil.ContextExecutor, name string) (*models.Zone, error) {
+	return models.Zones(
+		qm.WhereIn("name IN ?", ancestors(name)...),
+		qm.OrderBy("length(name) DESC"),
+	).One(ctx, exec)
+}
+
+// linkZone links the record to its longest matching zone, a zone is created
+// when none matches
+func linkZone(ctx context.Context, exec boil.ContextExecutor, dbRecord *models.Record) error {
+	zone, err := findZone(ctx, exec, dbRecord.Record)
+	if err == nil {
+		dbRecord.ZoneID = null.StringFrom(zone.ID)
+		return nil
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	zone = &models.Zone{Name: AutoZoneName(dbRecord.Record), AutoCreated: true}
+
+	// A zone created concurrently makes this a no-op
+	if err := zone.Upsert(ctx, exec, false, []string{models.ZoneColumns.Name}, boil.None(), boil.Infer()); err != nil {
+		return err
+	}
+
+	// Records stored before the zone existed belong to it as well
+	if err := RelinkZone(ctx, exec, zone.Name); err != nil {
+		return err
+	}
+
+	zone, err = models.Zones(qm.Where("name=?", zone.Name)).One(ctx, exec)
+	if err != nil {
+		return err
+	}
+
+	dbRecord.ZoneID = null.StringFrom(zone.ID)
+
+	return nil
+}
+
+// RelinkZone links every record at or below the zone apex to its longest
+// matching zone, it runs whenever a zone is created or deleted. The serial of
+// the zone and of the zones above it is incremented, since records moved
+// between them.
+func RelinkZone(ctx context.Context, exec boil.ContextExecutor, zone string) error {
+	if _, err := exec.ExecContext(ctx,
+		"UPDATE zones SET serial = serial + 1 WHERE name = $1 OR right($1, length(name) + 1) = '.' || name",
+		zone,
+	); err != nil {
+		return err
+	}
+
+	dbRecords, err := models.Records(QMInZone(zone)).All(ctx, exec)
+	if err != nil {
+		return err
+	}
+
+	for _, dbRecord := range dbRecords {
+		zoneID := null.String{}
+
+		z, err := findZone(ctx, exec, dbRecord.Record)
+
+		switch {
+		case err == nil:
+			zoneID = null.StringFrom(z.ID)
+		case !errors.Is(err, sql.ErrNoRows):
+			return err
+		}
+
+		if dbRecord.ZoneID == zoneID {
+			continue
+		}
+
+		dbRecord.ZoneID = zoneID
+
+		if _, err := dbRecord.Update(ctx, exec, boil.Whitelist(models.RecordColumns.ZoneID, models.RecordColumns.UpdatedAt)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// BumpSerial increments the serial of the zone the record is linked to. A
+// write of a record or of its answers calls it in its transaction, so only
+// the row of that zone is locked, never its parents.
+func BumpSerial(ctx context.Context, exec boil.ContextExecutor, recordID string) error {
+	_, err := exec.ExecContext(ctx, "UPDATE zones SET serial = serial + 1 WHERE id = (SELECT zone_id FROM records WHERE id = $1)", recordID)
+
+	return err
+}
@@ -0,0 +1,54 @@
+package record
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAutoZoneName(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "host", in: "www.example.com.", want: "example.com."},
+		{name: "deeper host", in: "web.team-a.example.com.", want: "team-a.example.com."},
+		{name: "apex", in: "example.com.", want: "example.com."},
+		{name: "top level domain", in: "com.", want: "com."},
+		{name: "service", in: "_http._tcp.www.example.com.", want: "example.com."},
+		{name: "service at the apex", in: "_http._tcp.example.com.", want: "example.com."},
+		{name: "service of a team", in: "_artifacts._tcp.team-a.example.com.", want: "example.com."},
+		{name: "wildcard", in: "*.example.com.", want: "example.com."},
+		{name: "wildcard of a host", in: "*.www.example.com.", want: "example.com."},
+		{name: "reverse", in: "1.2.0.192.in-addr.arpa.", want: "2.0.192.in-addr.arpa."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AutoZoneName(tt.in); got != tt.want {
+				t.Errorf("AutoZoneName(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAncestors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []interface{}
+	}{
+		{name: "host", in: "www.example.com.", want: []interface{}{"www.example.com.", "example.com.", "com."}},
+		{name: "top level domain", in: "com.", want: []interface{}{"com."}},
+		{name: "root", in: ".", want: []interface{}{}},
+		{name: "service", in: "_http._tcp.example.com.", want: []interface{}{"_http._tcp.example.com.", "_tcp.example.com.", "example.com.", "com."}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ancestors(tt.in); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ancestors(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	ax "go.hollow.sh/dnscontroller/pkg/api/v1/answers"
+	ox "go.hollow.sh/dnscontroller/pkg/api/v1/owners"
+	rx "go.hollow.sh/dnscontroller/pkg/api/v1/records"
+)
+
+func (r *Router) getAnswers(c *gin.Context) {
+	record, err := rx.NewStoredRecord(c)
+	if err != nil {
+		invalidRecordResponse(c, err)
+		return
+	}
+
+	if err := record.Find(c.Request.Context(), r.db); err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	if r.notModified(c, record) {
+		return
+	}
+
+	answers, err := ax.List(c.Request.Context(), r.db, record)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, answers)
+}
+
+func (r *Router) createAnswer(c *gin.Context) {
+	record, err := rx.NewRecord(c)
+	if err != nil {
+		invalidRecordResponse(c, err)
+		return
+	}
+
+	answer, err := ax.NewAnswer(c, record)
+	if err != nil {
+		badRequestResponse(c, ax.ErrorInvalidAnswer.Error(), err)
+		return
+	}
+
+	if !r.authorizeOwner(c, answer.Owner) {
+		return
+	}
+
+	if err := answer.Validate(); err != nil {
+		badRequestResponse(c, ax.ErrorInvalidAnswer.Error(), err)
+		return
+	}
+
+	if err := answer.CreateOrUpdate(r.recordWriteContext(c), r.db); err != nil {
+		answerWriteErrorResponse(c, err)
+		return
+	}
+
+	createdResponse(c)
+}
+
+func (r *Router) updateAnswer(c *gin.Context) {
+	record, err := rx.NewStoredRecord(c)
+	if err != nil {
+		invalidRecordResponse(c, err)
+		return
+	}
+
+	if err := record.Find(c.Request.Context(), r.db); err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	answer, err := ax.NewAnswer(c, record)
+	if err != nil {
+		badRequestResponse(c, ax.ErrorInvalidAnswer.Error(), err)
+		return
+	}
+
+	if !r.authorizeOwner(c, answer.Owner) {
+		return
+	}
+
+	if err := answer.Validate(); err != nil {
+		badRequestResponse(c, ax.ErrorInvalidAnswer.Error(), err)
+		return
+	}
+
+	if err := answer.Update(r.recordWriteContext(c), r.db); err != nil {
+		answerWriteErrorResponse(c, err)
+		return
+	}
+
+	updatedResponse(c, answer.UUID.String())
+}
+
+func (r *Router) deleteAnswer(c *gin.Context) {
+	record, err := rx.NewStoredRecord(c)
+	if err != nil {
+		invalidRecordResponse(c, err)
+		return
+	}
+
+	if err := record.Find(c.Request.Context(), r.db); err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	answer, err := ax.NewAnswer(c, record)
+	if err != nil {
+		badRequestResponse(c, ax.ErrorInvalidAnswer.Error(), err)
+		return
+	}
+
+	if !r.authorizeOwner(c, answer.Owner) {
+		return
+	}
+
+	if err := answer.Delete(r.recordWriteContext(c), r.db); err != nil {
+		answerWriteErrorResponse(c, err)
+		return
+	}
+
+	deletedResponse(c)
+}
+
+// answerWriteErrorResponse writes the response of an answer write that
+// failed in its transaction. The answer was validated before, so what is
+// left is the owner being claimed by someone else, a conflict with the
+// stored answers, or the datastore.
+func answerWriteErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ox.ErrorNotOwner):
+		forbiddenResponse(c, err)
+	case errors.Is(err, rx.ErrorCNAMEConflict), errors.Is(err, ax.ErrorCNAMETarget):
+		// The answer is valid on its own but not with the stored ones
+		badRequestResponse(c, ax.ErrorInvalidAnswer.Error(), err)
+	default:
+		dbErrorResponse(c, err)
+	}
+}
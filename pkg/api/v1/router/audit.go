@@ -0,0 +1,64 @@
+package router
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.hollow.sh/toolbox/ginjwt"
+
+	"go.hollow.sh/dnscontroller/pkg/api/v1/audit"
+)
+
+const (
+	// requestIDHeader carries the id a request is correlated by, it is
+	// generated when the caller doesn't send one
+	requestIDHeader = "X-Request-Id"
+
+	requestIDKey = "dnscontroller.request_id"
+)
+
+// requestID tags every request with an id, which is echoed in the response and
+// recorded with the audit events of the request
+func requestID(c *gin.Context) {
+	id := c.GetHeader(requestIDHeader)
+	if id == "" {
+		id = uuid.New().String()
+	}
+
+	c.Set(requestIDKey, id)
+	c.Header(requestIDHeader, id)
+
+	c.Next()
+}
+
+// auditContext returns the request context carrying the caller the mutations
+// of the request are audited as
+func (r *Router) auditContext(c *gin.Context) context.Context {
+	return audit.WithActor(c.Request.Context(), audit.Actor{
+		Subject:   ginjwt.GetSubject(c),
+		RequestID: c.GetString(requestIDKey),
+	})
+}
+
+func (r *Router) getAudit(c *gin.Context) {
+	params, err := audit.NewListParams(c)
+	if err != nil {
+		badRequestResponse(c, "invalid list parameters", err)
+		return
+	}
+
+	events, count, err := audit.List(c.Request.Context(), r.db, params)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	listResponse(c, events, paginationData{
+		pageCount:  len(events),
+		totalPages: params.TotalPages(count),
+		totalCount: count,
+		page:       params.Page,
+		pageSize:   params.PageSize,
+	})
+}
@@ -0,0 +1,169 @@
+package router
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"go.hollow.sh/toolbox/ginjwt"
+
+	ox "go.hollow.sh/dnscontroller/pkg/api/v1/owners"
+)
+
+// rolesKey is where ginjwt keeps the scopes of the token
+const rolesKey = "jwt.roles"
+
+// ErrorNoPrincipal is when the token doesn't carry the claim callers are
+// identified by
+var ErrorNoPrincipal = errors.New("token has no owner claim")
+
+// OwnerAuth configures which owners a caller may change, answers can only be
+// created, updated and deleted by the principal of their owner
+type OwnerAuth struct {
+	// Enabled is off when tokens aren't checked, every caller may then change
+	// every owner
+	Enabled bool
+	// Claim identifies the caller, either sub or username
+	Claim string
+	// ClaimUnowned lets the first caller changing an owner without a
+	// principal claim it, owners stored before owners had principals
+	// otherwise belong to admins only
+	ClaimUnowned bool
+}
+
+func adminScope() string {
+	return scopePrefix + ":admin"
+}
+
+// isAdmin reports whether the caller bypasses the owner checks
+func (r *Router) isAdmin(c *gin.Context) bool {
+	if !r.ownerAuth.Enabled {
+		return true
+	}
+
+	for _, s := range c.GetStringSlice(rolesKey) {
+		if s == adminScope() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// principal returns the identity of the caller owners are mapped to
+func (r *Router) principal(c *gin.Context) string {
+	if r.ownerAuth.Claim == "username" {
+		return ginjwt.GetUser(c)
+	}
+
+	return ginjwt.GetSubject(c)
+}
+
+// authorizeOwner writes a 403 and returns false unless the caller may change
+// the answers of the owner, which is looked up by its identity. An owner that
+// doesn't exist yet is claimed by the caller.
+func (r *Router) authorizeOwner(c *gin.Context, o *ox.Owner) bool {
+	err := r.ownerError(c, o)
+
+	switch {
+	case errors.Is(err, ErrorNoPrincipal), errors.Is(err, ox.ErrorNotOwner):
+		forbiddenResponse(c, err)
+		return false
+	case err != nil:
+		dbErrorResponse(c, err)
+		return false
+	}
+
+	return true
+}
+
+// ownerError is authorizeOwner returning why the caller may not change the
+// answers of the owner rather than writing it
+func (r *Router) ownerError(c *gin.Context, o *ox.Owner) error {
+	unowned, err := r.checkOwner(c, o)
+	if err != nil || unowned == nil {
+		return err
+	}
+
+	return r.claim(c, unowned, o.Principal)
+}
+
+// checkOwner is ownerError without claiming the owner. A stored owner without
+// a principal that the caller may claim is returned for the caller to claim
+// in its own transaction, o.Principal is set to the caller already.
+func (r *Router) checkOwner(c *gin.Context, o *ox.Owner) (*ox.Owner, error) {
+	if r.isAdmin(c) {
+		return nil, nil
+	}
+
+	principal := r.principal(c)
+	if principal == "" {
+		return nil, ErrorNoPrincipal
+	}
+
+	current := &ox.Owner{Name: o.Name, Origin: o.Origin, Service: o.Service}
+
+	err := current.Find(c.Request.Context(), r.db)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		o.Principal = principal
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	if current.Principal == "" && r.ownerAuth.ClaimUnowned {
+		o.Principal = principal
+		return current, nil
+	}
+
+	if err := current.Authorize(principal); err != nil {
+		return nil, err
+	}
+
+	o.Principal = current.Principal
+
+	return nil, nil
+}
+
+// authorizeOwnerID is authorizeOwner for an owner looked up by its id, the
+// owner is filled in with what is stored
+func (r *Router) authorizeOwnerID(c *gin.Context, o *ox.Owner) bool {
+	if err := o.FindByUUID(c.Request.Context(), r.db); err != nil {
+		dbErrorResponse(c, err)
+		return false
+	}
+
+	if r.isAdmin(c) {
+		return true
+	}
+
+	principal := r.principal(c)
+
+	err := r.claim(c, o, principal)
+	if err == nil {
+		err = o.Authorize(principal)
+	}
+
+	switch {
+	case errors.Is(err, ox.ErrorNotOwner):
+		forbiddenResponse(c, err)
+		return false
+	case err != nil:
+		dbErrorResponse(c, err)
+		return false
+	}
+
+	return true
+}
+
+// claim gives a stored owner without a principal to the caller when
+// OwnerAuth allows it
+func (r *Router) claim(c *gin.Context, o *ox.Owner, principal string) error {
+	if o.Principal != "" || principal == "" || !r.ownerAuth.ClaimUnowned {
+		return nil
+	}
+
+	return o.Claim(r.auditContext(c), r.db, principal)
+}
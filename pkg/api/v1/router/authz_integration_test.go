@@ -0,0 +1,226 @@
+//go:build integration
+
+package router
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq" // Register the Postgres driver.
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"go.hollow.sh/dnscontroller/internal/models"
+	ax "go.hollow.sh/dnscontroller/pkg/api/v1/answers"
+	"go.hollow.sh/dnscontroller/pkg/api/v1/batch"
+	ox "go.hollow.sh/dnscontroller/pkg/api/v1/owners"
+)
+
+// newTestDB opens the migrated test database, see the integration-test
+// target of the Makefile
+func newTestDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	uri := os.Getenv("DNSCONTROLLER_DB_URI")
+	if uri == "" {
+		t.Skip("DNSCONTROLLER_DB_URI isn't set")
+	}
+
+	db := sqlx.MustOpen("postgres", uri)
+	t.Cleanup(func() { _ = db.Close() })
+
+	return db
+}
+
+// cleanupAuthz removes the records and owners of an authz test
+func cleanupAuthz(t *testing.T, db *sqlx.DB, record, owner string) {
+	t.Helper()
+
+	t.Cleanup(func() {
+		ctx := context.Background()
+
+		_, _ = models.Records(qm.Where("record=?", record)).DeleteAll(ctx, db)
+		_, _ = models.Owners(qm.Where("name=?", owner)).DeleteAll(ctx, db)
+	})
+}
+
+func answerRequest(o *ox.Owner, target string) *ax.Request {
+	return &ax.Request{Owner: o, Answer: &ax.Answer{Target: target}}
+}
+
+func TestAuthorizeOwner(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	e := newTestRouter(db, testOwnerAuth)
+
+	const name = "authz-test.example.com."
+
+	path := "/records/" + name + "/A/answers"
+	alice := &ox.Owner{Name: "authz-test", Origin: "test", Service: "alice"}
+	bob := &ox.Owner{Name: "authz-test", Origin: "test", Service: "bob"}
+
+	cleanupAuthz(t, db, name, "authz-test")
+
+	// A new owner is claimed by the caller that creates it
+	if w := serve(t, e, http.MethodPost, path, "alice", answerRequest(alice, "192.0.2.1")); w.Code != http.StatusCreated {
+		t.Fatalf("creating alice's answer status = %d: %s", w.Code, w.Body)
+	}
+
+	stored := &ox.Owner{Name: alice.Name, Origin: alice.Origin, Service: alice.Service}
+	if err := stored.Find(ctx, db); err != nil || stored.Principal != "alice" {
+		t.Fatalf("alice's owner = %+v, %v, want it claimed by alice", stored, err)
+	}
+
+	// Another caller can't change it, by identity or by id
+	if w := serve(t, e, http.MethodPost, path, "bob", answerRequest(alice, "192.0.2.2")); w.Code != http.StatusForbidden {
+		t.Errorf("bob adding to alice's owner status = %d, want 403", w.Code)
+	}
+
+	if w := serve(t, e, http.MethodDelete, "/owners/"+stored.UUID.String(), "bob", nil); w.Code != http.StatusForbidden {
+		t.Errorf("bob deleting alice's owner status = %d, want 403", w.Code)
+	}
+
+	// Neither can a token without the owner claim
+	if w := serve(t, e, http.MethodPost, path, "noclaim", answerRequest(alice, "192.0.2.2")); w.Code != http.StatusForbidden {
+		t.Errorf("adding without a claim status = %d, want 403", w.Code)
+	}
+
+	// Admins pass, and the owner stays alice's
+	if w := serve(t, e, http.MethodPost, path, "admin", answerRequest(alice, "192.0.2.3")); w.Code != http.StatusCreated {
+		t.Errorf("admin adding to alice's owner status = %d: %s", w.Code, w.Body)
+	}
+
+	if err := stored.Find(ctx, db); err != nil || stored.Principal != "alice" {
+		t.Errorf("alice's owner after an admin write = %+v, %v, want it kept by alice", stored, err)
+	}
+
+	// With bob's answer on the record alice can't delete it
+	if w := serve(t, e, http.MethodPost, path, "bob", answerRequest(bob, "192.0.2.4")); w.Code != http.StatusCreated {
+		t.Fatalf("creating bob's answer status = %d: %s", w.Code, w.Body)
+	}
+
+	if w := serve(t, e, http.MethodDelete, "/records/"+name+"/A", "alice", nil); w.Code != http.StatusForbidden {
+		t.Errorf("alice deleting a record with bob's answer status = %d, want 403", w.Code)
+	}
+
+	if w := serve(t, e, http.MethodDelete, "/records/"+name+"/A", "admin", nil); w.Code != http.StatusOK {
+		t.Errorf("admin deleting the record status = %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestClaimUnowned(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	const name = "claim-test.example.com."
+
+	path := "/records/" + name + "/A/answers"
+	o := &ox.Owner{Name: "claim-test", Origin: "test", Service: "web"}
+
+	cleanupAuthz(t, db, name, "claim-test")
+
+	// Owners from before principals were stored without one
+	dbOwner := &models.Owner{Name: o.Name, Origin: o.Origin, Service: o.Service, Principal: null.String{}}
+	if err := dbOwner.Insert(ctx, db, boil.Infer()); err != nil {
+		t.Fatalf("inserting the owner: %v", err)
+	}
+
+	if w := serve(t, newTestRouter(db, testOwnerAuth), http.MethodPost, path, "alice", answerRequest(o, "192.0.2.1")); w.Code != http.StatusForbidden {
+		t.Errorf("changing an unowned owner status = %d, want 403", w.Code)
+	}
+
+	oa := testOwnerAuth
+	oa.ClaimUnowned = true
+	e := newTestRouter(db, oa)
+
+	if w := serve(t, e, http.MethodPost, path, "alice", answerRequest(o, "192.0.2.1")); w.Code != http.StatusCreated {
+		t.Fatalf("claiming an unowned owner status = %d: %s", w.Code, w.Body)
+	}
+
+	stored := &ox.Owner{Name: o.Name, Origin: o.Origin, Service: o.Service}
+	if err := stored.Find(ctx, db); err != nil || stored.Principal != "alice" {
+		t.Fatalf("claimed owner = %+v, %v, want it claimed by alice", stored, err)
+	}
+
+	// Once claimed it is alice's like any other owner
+	if w := serve(t, e, http.MethodPost, path, "bob", answerRequest(o, "192.0.2.2")); w.Code != http.StatusForbidden {
+		t.Errorf("bob adding to the claimed owner status = %d, want 403", w.Code)
+	}
+
+	if err := stored.Claim(ctx, db, "bob"); !errors.Is(err, ox.ErrorNotOwner) {
+		t.Errorf("Claim() of a claimed owner error = %v, want %v", err, ox.ErrorNotOwner)
+	}
+}
+
+func TestBatchClaimRolledBack(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	const name = "batch-claim-test.example.com."
+
+	o := &ox.Owner{Name: "batch-claim-test", Origin: "test", Service: "web"}
+
+	cleanupAuthz(t, db, name, "batch-claim-test")
+
+	dbOwner := &models.Owner{Name: o.Name, Origin: o.Origin, Service: o.Service, Principal: null.String{}}
+	if err := dbOwner.Insert(ctx, db, boil.Infer()); err != nil {
+		t.Fatalf("inserting the owner: %v", err)
+	}
+
+	oa := testOwnerAuth
+	oa.ClaimUnowned = true
+
+	// The delete fails as the record doesn't exist, which rolls the claim
+	// back along with the create
+	b := &ax.Batch{Mode: batch.ModeAtomic, Operations: []*ax.Operation{
+		{Op: batch.OpCreate, Record: name, RecordType: "A", Owner: o, Answer: &ax.Answer{Target: "192.0.2.1"}},
+		{Op: batch.OpDelete, Record: "missing." + name, RecordType: "A", Owner: o, Answer: &ax.Answer{Target: "192.0.2.1"}},
+	}}
+
+	if w := serve(t, newTestRouter(db, oa), http.MethodPost, "/answers:batch", "alice", b); w.Code == http.StatusOK {
+		t.Fatalf("failing batch status = %d, want an error", w.Code)
+	}
+
+	stored := &ox.Owner{Name: o.Name, Origin: o.Origin, Service: o.Service}
+	if err := stored.Find(ctx, db); err != nil || stored.Principal != "" {
+		t.Errorf("owner after the rolled back batch = %+v, %v, want it unowned", stored, err)
+	}
+}
+
+func TestWritesCheckStoredPrincipal(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	const name = "principal-test.example.com."
+
+	o := &ox.Owner{Name: "principal-test", Origin: "test", Service: "web"}
+
+	cleanupAuthz(t, db, name, "principal-test")
+
+	if w := serve(t, newTestRouter(db, testOwnerAuth), http.MethodPost, "/records/"+name+"/A/answers", "alice", answerRequest(o, "192.0.2.1")); w.Code != http.StatusCreated {
+		t.Fatalf("creating alice's answer status = %d: %s", w.Code, w.Body)
+	}
+
+	// Bob was authorized before alice claimed the owner, each write checks
+	// the stored owner again in its transaction
+	for _, op := range []string{batch.OpCreate, batch.OpUpsert, batch.OpDelete} {
+		t.Run(op, func(t *testing.T) {
+			bob := &ox.Owner{Name: o.Name, Origin: o.Origin, Service: o.Service, Principal: "bob"}
+			step := &ax.Operation{Op: op, Record: name, RecordType: "A", Owner: bob, Answer: &ax.Answer{Target: "192.0.2.1", TTL: 60}}
+
+			errs, err := batch.Run(ctx, db, batch.ModeAtomic, []batch.Step{step.Apply})
+			if err != nil {
+				t.Fatalf("running the batch: %v", err)
+			}
+
+			if !errors.Is(errs[0], ox.ErrorNotOwner) {
+				t.Errorf("%s of alice's answer by bob error = %v, want %v", op, errs[0], ox.ErrorNotOwner)
+			}
+		})
+	}
+}
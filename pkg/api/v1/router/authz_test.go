@@ -0,0 +1,77 @@
+package router
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	ox "go.hollow.sh/dnscontroller/pkg/api/v1/owners"
+)
+
+// newAuthzContext is a request context holding what ginjwt keeps of a token
+func newAuthzContext(subject, user string, scopes ...string) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("POST", "/", nil)
+
+	c.Set("jwt.subject", subject)
+	c.Set("jwt.user", user)
+	c.Set(rolesKey, scopes)
+
+	return c
+}
+
+func TestIsAdmin(t *testing.T) {
+	tests := []struct {
+		name      string
+		ownerAuth OwnerAuth
+		scopes    []string
+		want      bool
+	}{
+		{name: "owner checks off", ownerAuth: OwnerAuth{}, scopes: []string{"read"}, want: true},
+		{name: "admin scope", ownerAuth: testOwnerAuth, scopes: []string{"write", adminScope()}, want: true},
+		{name: "write scope", ownerAuth: testOwnerAuth, scopes: []string{"write"}},
+		{name: "no scopes", ownerAuth: testOwnerAuth},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Router{ownerAuth: tt.ownerAuth}
+			if got := r.isAdmin(newAuthzContext("alice", "", tt.scopes...)); got != tt.want {
+				t.Errorf("isAdmin() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrincipal(t *testing.T) {
+	c := newAuthzContext("0f6b5d9e", "alice")
+
+	if got := (&Router{ownerAuth: OwnerAuth{Enabled: true, Claim: "sub"}}).principal(c); got != "0f6b5d9e" {
+		t.Errorf("principal() with the sub claim = %q, want the subject", got)
+	}
+
+	if got := (&Router{ownerAuth: OwnerAuth{Enabled: true, Claim: "username"}}).principal(c); got != "alice" {
+		t.Errorf("principal() with the username claim = %q, want the user", got)
+	}
+}
+
+// The router has no database, so these only pass when the owner isn't
+// looked up
+func TestOwnerErrorWithoutLookup(t *testing.T) {
+	r := &Router{ownerAuth: testOwnerAuth}
+	o := &ox.Owner{Name: "authz-test", Origin: "test", Service: "web"}
+
+	if err := r.ownerError(newAuthzContext("alice", "", adminScope()), o); err != nil {
+		t.Errorf("ownerError() of an admin = %v, want nil", err)
+	}
+
+	if err := r.ownerError(newAuthzContext("", "", "write"), o); !errors.Is(err, ErrorNoPrincipal) {
+		t.Errorf("ownerError() without a claim = %v, want %v", err, ErrorNoPrincipal)
+	}
+
+	if o.Principal != "" {
+		t.Errorf("ownerError() set the principal to %q", o.Principal)
+	}
+}
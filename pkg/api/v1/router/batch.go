@@ -0,0 +1,227 @@
+package router
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+
+	ax "go.hollow.sh/dnscontroller/pkg/api/v1/answers"
+	"go.hollow.sh/dnscontroller/pkg/api/v1/batch"
+	ox "go.hollow.sh/dnscontroller/pkg/api/v1/owners"
+	rx "go.hollow.sh/dnscontroller/pkg/api/v1/records"
+)
+
+// batchSuffix is the :batch suffix of the batch routes. Gin can't route a
+// literal colon, so it is matched as the batch param and checked by
+// batchRoute.
+const batchSuffix = ":batch"
+
+// batchRoute 404s the other paths the batch routes match, like /recordsfoo
+func batchRoute(c *gin.Context) {
+	if c.Param("batch") != batchSuffix {
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+
+	c.Next()
+}
+
+func (r *Router) batchRecords(c *gin.Context) {
+	b, err := rx.NewBatch(c)
+	if err != nil {
+		badRequestResponse(c, batch.ErrorInvalidBatch.Error(), err)
+		return
+	}
+
+	admin := r.isAdmin(c)
+	principal := r.principal(c)
+	steps := make([]batch.Step, len(b.Operations))
+
+	for i, op := range b.Operations {
+		steps[i] = func(ctx context.Context, exec boil.ContextExecutor) error {
+			if op.Op == batch.OpDelete {
+				// Deleting a record deletes its answers, which may belong to
+				// others
+				if !admin {
+					if err := authorizeRecordDelete(ctx, exec, op, principal); err != nil {
+						return err
+					}
+				}
+
+				record, err := op.Record()
+				if err != nil {
+					return err
+				}
+
+				if err := ax.DeleteRecordAnswers(ctx, exec, record); err != nil {
+					return err
+				}
+			}
+
+			return op.Apply(ctx, exec)
+		}
+	}
+
+	r.runBatch(c, b.Mode, steps)
+}
+
+func (r *Router) batchAnswers(c *gin.Context) {
+	b, err := ax.NewBatch(c)
+	if err != nil {
+		badRequestResponse(c, batch.ErrorInvalidBatch.Error(), err)
+		return
+	}
+
+	// Batches usually change the answers of a handful of owners, each is
+	// only authorized once
+	type ownerKey struct{ name, origin, service string }
+
+	type authorization struct {
+		principal string
+		unowned   *ox.Owner
+		err       error
+	}
+
+	admin := r.isAdmin(c)
+	authorized := map[ownerKey]authorization{}
+	steps := make([]batch.Step, len(b.Operations))
+
+	for i, op := range b.Operations {
+		if err := op.Validate(); err != nil {
+			steps[i] = failedStep(err)
+			continue
+		}
+
+		if !admin {
+			key := ownerKey{op.Owner.Name, op.Owner.Origin, op.Owner.Service}
+
+			a, seen := authorized[key]
+			if !seen {
+				a.unowned, a.err = r.checkOwner(c, op.Owner)
+				a.principal = op.Owner.Principal
+				authorized[key] = a
+			}
+
+			if a.err != nil {
+				steps[i] = failedStep(a.err)
+				continue
+			}
+
+			op.Owner.Principal = a.principal
+
+			// Unowned owners are claimed in the batch transaction, so a
+			// batch that is rolled back claims none of them
+			if a.unowned != nil {
+				steps[i] = func(ctx context.Context, exec boil.ContextExecutor) error {
+					if err := a.unowned.ClaimWith(ctx, exec, a.principal); err != nil {
+						return err
+					}
+
+					return op.Apply(ctx, exec)
+				}
+
+				continue
+			}
+		}
+
+		steps[i] = op.Apply
+	}
+
+	r.runBatch(c, b.Mode, steps)
+}
+
+// authorizeRecordDelete returns ErrorNotOwner when the record has answers of
+// owners that don't belong to the principal
+func authorizeRecordDelete(ctx context.Context, exec boil.ContextExecutor, op *rx.Operation, principal string) error {
+	record, err := op.Record()
+	if err != nil {
+		return err
+	}
+
+	if err := record.Find(ctx, exec); err != nil {
+		return err
+	}
+
+	foreign, err := record.HasAnswersNotFrom(ctx, exec, principal)
+	if err != nil {
+		return err
+	}
+
+	if foreign {
+		return ox.ErrorNotOwner
+	}
+
+	return nil
+}
+
+// failedStep is the step of an operation that failed before the batch ran
+func failedStep(err error) batch.Step {
+	return func(context.Context, boil.ContextExecutor) error {
+		return err
+	}
+}
+
+// runBatch runs the steps and writes the result of each. A batch that was
+// applied in full is a 200, an atomic batch that was rolled back has the
+// status of its failed operation and a best effort batch with failed
+// operations is a 207.
+func (r *Router) runBatch(c *gin.Context, mode string, steps []batch.Step) {
+	errs, err := batch.Run(r.auditContext(c), r.db, mode, steps)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	resp := &recordResponse{Message: "batch applied"}
+	status := http.StatusOK
+	results := make([]batch.Result, len(errs))
+
+	for i, err := range errs {
+		results[i] = batch.Result{Index: i, Status: http.StatusOK}
+
+		if err == nil {
+			continue
+		}
+
+		results[i].Status = batchErrorStatus(err)
+		results[i].Error = err.Error()
+
+		switch {
+		case mode == batch.ModeBestEffort:
+			resp.Message = "batch partially applied"
+			status = http.StatusMultiStatus
+		case !errors.Is(err, batch.ErrorAborted):
+			resp.Message = "batch rolled back"
+			resp.Error = fmt.Sprintf("operation %d: %s", i, err)
+			status = results[i].Status
+		}
+	}
+
+	resp.Results = results
+
+	c.JSON(status, resp)
+}
+
+// batchErrorStatus is the status the failed operation would have had as a
+// request of its own
+func batchErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, batch.ErrorAborted):
+		return http.StatusFailedDependency
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound
+	case errors.Is(err, ErrorNoPrincipal), errors.Is(err, ox.ErrorNotOwner):
+		return http.StatusForbidden
+	case errors.Is(err, rx.ErrorRecordExists), errors.Is(err, ax.ErrorAnswerExists):
+		return http.StatusConflict
+	case errors.Is(err, rx.ErrorPreconditionFailed):
+		return http.StatusPreconditionFailed
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,38 @@
+package router
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	rx "go.hollow.sh/dnscontroller/pkg/api/v1/records"
+)
+
+// recordWriteContext returns the audit context of a write to a record, with
+// the If-Match and If-None-Match of the request as its precondition
+func (r *Router) recordWriteContext(c *gin.Context) context.Context {
+	return rx.WithPrecondition(r.auditContext(c), &rx.Precondition{
+		IfMatch:     c.GetHeader("If-Match"),
+		IfNoneMatch: c.GetHeader("If-None-Match"),
+	})
+}
+
+// notModified sets the ETag of the record, and writes a 304 response when it
+// matches the If-None-Match of the request
+func (r *Router) notModified(c *gin.Context, record *rx.Record) bool {
+	etag, err := record.ETag(c.Request.Context(), r.db)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return true
+	}
+
+	c.Header("ETag", etag)
+
+	if inm := c.GetHeader("If-None-Match"); inm != "" && rx.MatchETag(inm, etag, true) {
+		c.Status(http.StatusNotModified)
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,159 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	ax "go.hollow.sh/dnscontroller/pkg/api/v1/answers"
+	ox "go.hollow.sh/dnscontroller/pkg/api/v1/owners"
+)
+
+func (r *Router) getOwners(c *gin.Context) {
+	owners, err := ox.List(c.Request.Context(), r.db)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, owners)
+}
+
+func (r *Router) getOwner(c *gin.Context) {
+	id, err := ox.ParseUUID(c)
+	if err != nil {
+		badRequestResponse(c, ox.ErrorInvalidOwner.Error(), err)
+		return
+	}
+
+	owner := &ox.Owner{UUID: id}
+	if err := owner.FindByUUID(c.Request.Context(), r.db); err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, owner)
+}
+
+func (r *Router) createOwner(c *gin.Context) {
+	owner, err := ox.NewOwner(c)
+	if err != nil {
+		badRequestResponse(c, ox.ErrorInvalidOwner.Error(), err)
+		return
+	}
+
+	// Only admins pick the principal of an owner
+	if !r.isAdmin(c) {
+		owner.Principal = ""
+	}
+
+	if !r.authorizeOwner(c, owner) {
+		return
+	}
+
+	if err := owner.Create(r.auditContext(c), r.db); err != nil {
+		badRequestResponse(c, ox.ErrorInvalidOwner.Error(), err)
+		return
+	}
+
+	createdResponse(c)
+}
+
+func (r *Router) updateOwner(c *gin.Context) {
+	owner, err := ox.NewOwner(c)
+	if err != nil {
+		badRequestResponse(c, ox.ErrorInvalidOwner.Error(), err)
+		return
+	}
+
+	current := &ox.Owner{UUID: owner.UUID}
+	if !r.authorizeOwnerID(c, current) {
+		return
+	}
+
+	// Only admins change the principal of an owner
+	if !r.isAdmin(c) {
+		owner.Principal = current.Principal
+	}
+
+	if err := owner.Update(r.auditContext(c), r.db); err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	updatedResponse(c, owner.UUID.String())
+}
+
+func (r *Router) deleteOwner(c *gin.Context) {
+	id, err := ox.ParseUUID(c)
+	if err != nil {
+		badRequestResponse(c, ox.ErrorInvalidOwner.Error(), err)
+		return
+	}
+
+	owner := &ox.Owner{UUID: id}
+	if !r.authorizeOwnerID(c, owner) {
+		return
+	}
+
+	if err := ax.DeleteOwner(r.auditContext(c), r.db, owner); err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	deletedResponse(c)
+}
+
+func (r *Router) renewOwner(c *gin.Context) {
+	id, err := ox.ParseUUID(c)
+	if err != nil {
+		badRequestResponse(c, ox.ErrorInvalidOwner.Error(), err)
+		return
+	}
+
+	owner := &ox.Owner{UUID: id}
+	if !r.authorizeOwnerID(c, owner) {
+		return
+	}
+
+	renewed, err := ax.Renew(r.auditContext(c), r.db, owner)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	renewedResponse(c, owner.UUID.String(), renewed)
+}
+
+func (r *Router) syncOwnerAnswers(c *gin.Context) {
+	id, err := ox.ParseUUID(c)
+	if err != nil {
+		badRequestResponse(c, ox.ErrorInvalidOwner.Error(), err)
+		return
+	}
+
+	req, err := ax.NewSyncRequest(c)
+	if err != nil {
+		badRequestResponse(c, ax.ErrorInvalidAnswer.Error(), err)
+		return
+	}
+
+	owner := &ox.Owner{UUID: id}
+	if !r.authorizeOwnerID(c, owner) {
+		return
+	}
+
+	if err := ax.ValidateSync(owner, req.Answers); err != nil {
+		badRequestResponse(c, ax.ErrorInvalidAnswer.Error(), err)
+		return
+	}
+
+	diff, err := ax.Sync(r.auditContext(c), r.db, owner, req.Answers)
+
+	if err != nil {
+		answerWriteErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
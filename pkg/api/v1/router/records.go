@@ -5,17 +5,39 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	ax "go.hollow.sh/dnscontroller/pkg/api/v1/answers"
+	ox "go.hollow.sh/dnscontroller/pkg/api/v1/owners"
 	rx "go.hollow.sh/dnscontroller/pkg/api/v1/records"
 )
 
 func (r *Router) deleteRecord(c *gin.Context) {
-	record, err := rx.NewRecord(c)
+	record, err := rx.NewStoredRecord(c)
 	if err != nil {
-		badRequestResponse(c, rx.ErrorInvalidRecord.Error(), err)
+		invalidRecordResponse(c, err)
+		return
+	}
+
+	// Deleting a record deletes its answers, which may belong to others
+	if !r.isAdmin(c) {
+		if err := record.Find(c.Request.Context(), r.db); err != nil {
+			dbErrorResponse(c, err)
+			return
+		}
+
+		foreign, err := record.HasAnswersNotFrom(c.Request.Context(), r.db, r.principal(c))
+		if err != nil {
+			dbErrorResponse(c, err)
+			return
+		}
+
+		if foreign {
+			forbiddenResponse(c, ox.ErrorNotOwner)
+			return
+		}
 	}
 
-	if err := record.Delete(c.Request.Context(), r.db); err != nil {
-		badRequestResponse(c, "failed to delete record", err)
+	if err := ax.DeleteRecord(r.recordWriteContext(c), r.db, record); err != nil {
+		writeErrorResponse(c, "failed to delete record", err)
 		return
 	}
 
@@ -25,12 +47,13 @@ func (r *Router) deleteRecord(c *gin.Context) {
 func (r *Router) createRecord(c *gin.Context) {
 	record, err := rx.NewRecord(c)
 	if err != nil {
-		badRequestResponse(c, rx.ErrorInvalidRecord.Error(), err)
+		invalidRecordResponse(c, err)
+		return
 	}
 
-	err = record.FindOrCreate(c.Request.Context(), r.db)
+	err = record.Create(r.recordWriteContext(c), r.db)
 	if err != nil {
-		badRequestResponse(c, rx.ErrorInvalidRecord.Error(), err)
+		writeErrorResponse(c, rx.ErrorInvalidRecord.Error(), err)
 		return
 	}
 
@@ -38,9 +61,10 @@ func (r *Router) createRecord(c *gin.Context) {
 }
 
 func (r *Router) getRecord(c *gin.Context) {
-	record, err := rx.NewRecord(c)
+	record, err := rx.NewStoredRecord(c)
 	if err != nil {
-		badRequestResponse(c, rx.ErrorInvalidRecord.Error(), err)
+		invalidRecordResponse(c, err)
+		return
 	}
 
 	err = record.Find(c.Request.Context(), r.db)
@@ -49,5 +73,31 @@ func (r *Router) getRecord(c *gin.Context) {
 		return
 	}
 
+	if r.notModified(c, record) {
+		return
+	}
+
 	c.JSON(http.StatusOK, record)
 }
+
+func (r *Router) getRecords(c *gin.Context) {
+	params, err := rx.NewListParams(c)
+	if err != nil {
+		badRequestResponse(c, "invalid list parameters", err)
+		return
+	}
+
+	records, count, err := rx.List(c.Request.Context(), r.db, params)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	listResponse(c, records, paginationData{
+		pageCount:  len(records),
+		totalPages: params.TotalPages(count),
+		totalCount: count,
+		page:       params.Page,
+		pageSize:   params.PageSize,
+	})
+}
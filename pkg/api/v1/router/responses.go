@@ -4,8 +4,11 @@ import (
 	"database/sql"
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
+
+	rx "go.hollow.sh/dnscontroller/pkg/api/v1/records"
 )
 
 type recordResponse struct {
@@ -17,9 +20,13 @@ type recordResponse struct {
 	Links            *recordResponseLinks `json:"_links,omitempty"`
 	Message          string               `json:"message,omitempty"`
 	Error            string               `json:"error,omitempty"`
+	Code             string               `json:"code,omitempty"`
 	Slug             string               `json:"slug,omitempty"`
+	Renewed          *int64               `json:"renewed,omitempty"`
+	Version          string               `json:"version,omitempty"`
 	Record           interface{}          `json:"record,omitempty"`
 	Records          interface{}          `json:"records,omitempty"`
+	Results          interface{}          `json:"results,omitempty"`
 }
 
 // recordResponseLinks represent links that could be returned on a page
@@ -36,6 +43,15 @@ type link struct {
 	Href string `json:"href,omitempty"`
 }
 
+// paginationData is the page information of a list response
+type paginationData struct {
+	pageCount  int
+	totalPages int
+	totalCount int64
+	page       int
+	pageSize   int
+}
+
 // // notFoundResponse writes a 404 response with the given message
 // func notFoundResponse(c *gin.Context, message string) {
 // 	c.JSON(http.StatusNotFound, &recordResponse{Message: message})
@@ -45,6 +61,38 @@ func badRequestResponse(c *gin.Context, message string, err error) {
 	c.JSON(http.StatusBadRequest, &recordResponse{Message: message, Error: err.Error()})
 }
 
+// invalidRecordResponse writes a 400 response for a record that failed
+// validation, name errors report the offending name and label along with
+// the code of the error
+func invalidRecordResponse(c *gin.Context, err error) {
+	var nameErr *rx.NameError
+	if errors.As(err, &nameErr) {
+		c.JSON(http.StatusBadRequest, &recordResponse{Message: "invalid record name", Error: err.Error(), Code: nameErr.Code()})
+		return
+	}
+
+	badRequestResponse(c, rx.ErrorInvalidRecord.Error(), err)
+}
+
+// writeErrorResponse writes a 412 response for a write whose precondition
+// failed, and a 400 response otherwise
+func writeErrorResponse(c *gin.Context, message string, err error) {
+	if errors.Is(err, rx.ErrorPreconditionFailed) {
+		preconditionFailedResponse(c, err)
+		return
+	}
+
+	badRequestResponse(c, message, err)
+}
+
+func preconditionFailedResponse(c *gin.Context, err error) {
+	c.JSON(http.StatusPreconditionFailed, &recordResponse{Message: "precondition failed", Error: err.Error()})
+}
+
+func forbiddenResponse(c *gin.Context, err error) {
+	c.JSON(http.StatusForbidden, &recordResponse{Message: "forbidden", Error: err.Error()})
+}
+
 func createdResponse(c *gin.Context) {
 	uri := uriWithoutQueryParams(c)
 	r := &recordResponse{
@@ -62,22 +110,58 @@ func deletedResponse(c *gin.Context) {
 	c.JSON(http.StatusOK, &recordResponse{Message: "resource deleted"})
 }
 
-// func updatedResponse(c *gin.Context, slug string) {
-// 	r := &RecordResponse{
-// 		Message: "resource updated",
-// 		Slug:    slug,
-// 		Links: &RecordResponseLinks{
-// 			Self: &Link{Href: uriWithoutQueryParams(c)},
-// 		},
-// 	}
+func updatedResponse(c *gin.Context, slug string) {
+	r := &recordResponse{
+		Message: "resource updated",
+		Slug:    slug,
+		Links: &recordResponseLinks{
+			Self: &link{Href: uriWithoutQueryParams(c)},
+		},
+	}
 
-// 	c.JSON(http.StatusOK, r)
-// }
+	c.JSON(http.StatusOK, r)
+}
+
+func renewedResponse(c *gin.Context, slug string, renewed int64) {
+	c.JSON(http.StatusOK, &recordResponse{Message: "leases renewed", Slug: slug, Renewed: &renewed})
+}
+
+func listResponse(c *gin.Context, records interface{}, pd paginationData) {
+	links := &recordResponseLinks{
+		Self:  pageLink(c, pd.page, pd.pageSize),
+		First: pageLink(c, 1, pd.pageSize),
+	}
+
+	if pd.totalPages > 0 {
+		links.Last = pageLink(c, pd.totalPages, pd.pageSize)
+	}
+
+	if pd.page > 1 {
+		links.Previous = pageLink(c, pd.page-1, pd.pageSize)
+	}
+
+	if pd.page < pd.totalPages {
+		links.Next = pageLink(c, pd.page+1, pd.pageSize)
+	}
+
+	c.JSON(http.StatusOK, &recordResponse{
+		PageSize:         pd.pageSize,
+		Page:             pd.page,
+		PageCount:        pd.pageCount,
+		TotalPages:       pd.totalPages,
+		TotalRecordCount: pd.totalCount,
+		Links:            links,
+		Records:          records,
+	})
+}
 
 func dbErrorResponse(c *gin.Context, err error) {
-	if errors.Is(err, sql.ErrNoRows) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
 		c.JSON(http.StatusNotFound, &recordResponse{Message: "resource not found", Error: err.Error()})
-	} else {
+	case errors.Is(err, rx.ErrorPreconditionFailed):
+		preconditionFailedResponse(c, err)
+	default:
 		c.JSON(http.StatusInternalServerError, &recordResponse{Message: "datastore error", Error: err.Error()})
 	}
 }
@@ -88,3 +172,15 @@ func uriWithoutQueryParams(c *gin.Context) string {
 
 	return uri.String()
 }
+
+// pageLink returns a link to the given page, keeping the other query params
+func pageLink(c *gin.Context, page, pageSize int) *link {
+	uri := *c.Request.URL
+
+	q := uri.Query()
+	q.Set("page", strconv.Itoa(page))
+	q.Set("page_size", strconv.Itoa(pageSize))
+	uri.RawQuery = q.Encode()
+
+	return &link{Href: uri.String()}
+}
@@ -2,11 +2,11 @@
 package router
 
 import (
+	"fmt"
 	"path"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
-	"go.hollow.sh/toolbox/ginjwt"
 	"go.uber.org/zap"
 )
 
@@ -15,39 +15,139 @@ const (
 	V1URI = "/api/v1"
 
 	// RecordsURI is the path to the regular record endpoint, called by the
-	// client to .
+	// client to list and filter records.
 	RecordsURI = "/records"
 
 	// RecordURI is the path to the endpoint used for
 	// retrieving the stored record for an instance
 	RecordURI = "/records/:record/:recordtype"
 
+	// RecordsBatchURI is the path to apply many record operations at once
+	RecordsBatchURI = "/records" + batchSuffix
+
+	// AnswersBatchURI is the path to apply many answer operations at once
+	AnswersBatchURI = "/answers" + batchSuffix
+
 	// RecordAnswerURI is for interactions with record's answers
 	RecordAnswerURI = "/records/:record/:recordtype/answers"
 
-	// scopePrefix = "dnscontroller"
+	// OwnersURI is the path to the owners endpoint
+	OwnersURI = "/owners"
+
+	// OwnerURI is the path to the endpoint for a single owner
+	OwnerURI = "/owners/:owner"
+
+	// OwnerRenewURI is the path to the endpoint renewing the leases of an
+	// owner's answers
+	OwnerRenewURI = "/owners/:owner/renew"
+
+	// OwnerAnswersURI is the path to sync the answers of an owner to a
+	// desired set
+	OwnerAnswersURI = "/owners/:owner/answers"
+
+	// ZonesURI is the path to the zones endpoint
+	ZonesURI = "/zones"
+
+	// ZoneURI is the path to the endpoint for a single zone
+	ZoneURI = "/zones/:zone"
+
+	// ZoneExportURI is the path to the master file of a zone
+	ZoneExportURI = "/zones/:zone/export"
+
+	// AuditURI is the path to the audit log of every mutation
+	AuditURI = "/audit"
+
+	// WatchURI is the path to stream or long-poll the changes to records and
+	// answers
+	WatchURI = "/watch"
+
+	// WebhooksURI is the path to the webhooks endpoint
+	WebhooksURI = "/webhooks"
+
+	// WebhookURI is the path to the endpoint for a single webhook
+	WebhookURI = "/webhooks/:webhook"
+
+	// WebhookDeliveriesURI is the path to the delivery history of a webhook
+	WebhookDeliveriesURI = "/webhooks/:webhook/deliveries"
+
+	// WebhookDeadLettersURI is the path to the deliveries of a webhook that
+	// failed every attempt
+	WebhookDeadLettersURI = "/webhooks/:webhook/dead-letters"
+
+	// scopePrefix namespaces the scopes of the route specific permissions
+	scopePrefix = "dnscontroller"
 )
 
+// AuthMiddleware checks the bearer token of a request and its scopes, serve
+// uses a ginjwt.Middleware
+type AuthMiddleware interface {
+	AuthRequired() gin.HandlerFunc
+	RequiredScopes(scopes []string) gin.HandlerFunc
+}
+
 // Router provides a router for the v1 API
 type Router struct {
-	authMW *ginjwt.Middleware
-	db     *sqlx.DB
-	logger *zap.SugaredLogger
+	authMW    AuthMiddleware
+	ownerAuth OwnerAuth
+	db        *sqlx.DB
+	logger    *zap.SugaredLogger
 }
 
 // New builds a Router
-func New(amw *ginjwt.Middleware, db *sqlx.DB, l *zap.SugaredLogger) *Router {
-	return &Router{authMW: amw, db: db, logger: l}
+func New(amw AuthMiddleware, oa OwnerAuth, db *sqlx.DB, l *zap.SugaredLogger) *Router {
+	return &Router{authMW: amw, ownerAuth: oa, db: db, logger: l}
 }
 
-// Routes will add the routes for this API version to a router group
+// Routes will add the routes for this API version to a router group, every
+// route requires a token with one of its scopes
 func (r *Router) Routes(rg *gin.RouterGroup) {
-	// TODO: add auth'd endpoints
-	// authMw := r.AuthMW
-	// rg.POST(RecordURI, authMw.AuthRequired(), authMw.RequiredScopes(upsertScopes("record")))
-	rg.GET(RecordURI, r.getRecord)
-	rg.POST(RecordURI, r.createRecord)
-	rg.DELETE(RecordURI, r.deleteRecord)
+	authMw := r.authMW
+
+	rg.Use(requestID)
+
+	rg.GET(RecordsURI, authMw.AuthRequired(), authMw.RequiredScopes(readScopes("record")), r.getRecords)
+	rg.GET(RecordURI, authMw.AuthRequired(), authMw.RequiredScopes(readScopes("record")), r.getRecord)
+	rg.POST(RecordURI, authMw.AuthRequired(), authMw.RequiredScopes(upsertScopes("record")), r.createRecord)
+	rg.DELETE(RecordURI, authMw.AuthRequired(), authMw.RequiredScopes(deleteScopes("record")), r.deleteRecord)
+
+	// Batches mix upserts and deletes, so they need the scopes of both
+	rg.POST(RecordsBatchURI, batchRoute, authMw.AuthRequired(), authMw.RequiredScopes(upsertScopes("record")),
+		authMw.RequiredScopes(deleteScopes("record")), r.batchRecords)
+	rg.POST(AnswersBatchURI, batchRoute, authMw.AuthRequired(), authMw.RequiredScopes(upsertScopes("answer")),
+		authMw.RequiredScopes(deleteScopes("answer")), r.batchAnswers)
+
+	rg.GET(RecordAnswerURI, authMw.AuthRequired(), authMw.RequiredScopes(readScopes("answer")), r.getAnswers)
+	rg.POST(RecordAnswerURI, authMw.AuthRequired(), authMw.RequiredScopes(upsertScopes("answer")), r.createAnswer)
+	rg.PUT(RecordAnswerURI, authMw.AuthRequired(), authMw.RequiredScopes(upsertScopes("answer")), r.updateAnswer)
+	rg.DELETE(RecordAnswerURI, authMw.AuthRequired(), authMw.RequiredScopes(deleteScopes("answer")), r.deleteAnswer)
+
+	rg.GET(OwnersURI, authMw.AuthRequired(), authMw.RequiredScopes(readScopes("owner")), r.getOwners)
+	rg.POST(OwnersURI, authMw.AuthRequired(), authMw.RequiredScopes(upsertScopes("owner")), r.createOwner)
+	rg.GET(OwnerURI, authMw.AuthRequired(), authMw.RequiredScopes(readScopes("owner")), r.getOwner)
+	rg.PUT(OwnerURI, authMw.AuthRequired(), authMw.RequiredScopes(upsertScopes("owner")), r.updateOwner)
+	rg.DELETE(OwnerURI, authMw.AuthRequired(), authMw.RequiredScopes(deleteScopes("owner")), r.deleteOwner)
+	// Renewing changes the owner's answers rather than the owner
+	rg.POST(OwnerRenewURI, authMw.AuthRequired(), authMw.RequiredScopes(upsertScopes("answer")), r.renewOwner)
+	rg.PUT(OwnerAnswersURI, authMw.AuthRequired(), authMw.RequiredScopes(upsertScopes("answer")),
+		authMw.RequiredScopes(deleteScopes("answer")), r.syncOwnerAnswers)
+
+	rg.GET(ZonesURI, authMw.AuthRequired(), authMw.RequiredScopes(readScopes("zone")), r.getZones)
+	rg.POST(ZonesURI, authMw.AuthRequired(), authMw.RequiredScopes(upsertScopes("zone")), r.createZone)
+	rg.GET(ZoneURI, authMw.AuthRequired(), authMw.RequiredScopes(readScopes("zone")), r.getZone)
+	rg.DELETE(ZoneURI, authMw.AuthRequired(), authMw.RequiredScopes(deleteScopes("zone")), r.deleteZone)
+	rg.GET(ZoneExportURI, authMw.AuthRequired(), authMw.RequiredScopes(readScopes("zone")), r.exportZone)
+
+	rg.GET(AuditURI, authMw.AuthRequired(), authMw.RequiredScopes(readScopes("audit")), r.getAudit)
+
+	rg.GET(WatchURI, authMw.AuthRequired(), authMw.RequiredScopes(readScopes("record", "answer")), r.getWatch)
+
+	rg.GET(WebhooksURI, authMw.AuthRequired(), authMw.RequiredScopes(readScopes("webhook")), r.getWebhooks)
+	rg.POST(WebhooksURI, authMw.AuthRequired(), authMw.RequiredScopes(upsertScopes("webhook")), r.createWebhook)
+	rg.GET(WebhookURI, authMw.AuthRequired(), authMw.RequiredScopes(readScopes("webhook")), r.getWebhook)
+	rg.PUT(WebhookURI, authMw.AuthRequired(), authMw.RequiredScopes(upsertScopes("webhook")), r.updateWebhook)
+	rg.DELETE(WebhookURI, authMw.AuthRequired(), authMw.RequiredScopes(deleteScopes("webhook")), r.deleteWebhook)
+	rg.GET(WebhookDeliveriesURI, authMw.AuthRequired(), authMw.RequiredScopes(readScopes("webhook")), r.getWebhookDeliveries)
+	rg.GET(WebhookDeadLettersURI, authMw.AuthRequired(), authMw.RequiredScopes(readScopes("webhook")), r.getWebhookDeadLetters)
 }
 
 // GetRecordPath returns the path used by an instance to fetch Record
@@ -55,33 +155,33 @@ func GetRecordPath() string {
 	return path.Join(V1URI, RecordsURI)
 }
 
-// func upsertScopes(items ...string) []string {
-// 	s := []string{"write", "create", "update"}
-// 	for _, i := range items {
-// 		s = append(s, fmt.Sprintf("%s:create:%s", scopePrefix, i))
-// 	}
+func upsertScopes(items ...string) []string {
+	s := []string{adminScope(), "write", "create", "update"}
+	for _, i := range items {
+		s = append(s, fmt.Sprintf("%s:create:%s", scopePrefix, i))
+	}
 
-// 	for _, i := range items {
-// 		s = append(s, fmt.Sprintf("%s:update:%s", scopePrefix, i))
-// 	}
+	for _, i := range items {
+		s = append(s, fmt.Sprintf("%s:update:%s", scopePrefix, i))
+	}
 
-// 	return s
-// }
+	return s
+}
 
-// func readScopes(items ...string) []string {
-// 	s := []string{"read"}
-// 	for _, i := range items {
-// 		s = append(s, fmt.Sprintf("%s:read:%s", scopePrefix, i))
-// 	}
+func readScopes(items ...string) []string {
+	s := []string{adminScope(), "read"}
+	for _, i := range items {
+		s = append(s, fmt.Sprintf("%s:read:%s", scopePrefix, i))
+	}
 
-// 	return s
-// }
+	return s
+}
 
-// func deleteScopes(items ...string) []string {
-// 	s := []string{"write", "delete"}
-// 	for _, i := range items {
-// 		s = append(s, fmt.Sprintf("%s:delete:%s", scopePrefix, i))
-// 	}
+func deleteScopes(items ...string) []string {
+	s := []string{adminScope(), "write", "delete"}
+	for _, i := range items {
+		s = append(s, fmt.Sprintf("%s:delete:%s", scopePrefix, i))
+	}
 
-// 	return s
-// }
+	return s
+}
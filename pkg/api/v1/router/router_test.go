@@ -0,0 +1,185 @@
+package router
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+
+	ax "go.hollow.sh/dnscontroller/pkg/api/v1/answers"
+	ox "go.hollow.sh/dnscontroller/pkg/api/v1/owners"
+	rx "go.hollow.sh/dnscontroller/pkg/api/v1/records"
+)
+
+// stubToken is what a stub token stands for
+type stubToken struct {
+	subject string
+	scopes  []string
+}
+
+// stubAuth stands in for ginjwt, the bearer token is a key of tokens. It
+// keeps the subject and scopes where ginjwt keeps them.
+type stubAuth struct {
+	tokens map[string]stubToken
+}
+
+func (s *stubAuth) AuthRequired() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tok, ok := s.tokens[strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")]
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "invalid token"})
+			return
+		}
+
+		c.Set("jwt.subject", tok.subject)
+		c.Set(rolesKey, tok.scopes)
+	}
+}
+
+func (s *stubAuth) RequiredScopes(scopes []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, have := range c.GetStringSlice(rolesKey) {
+			for _, want := range scopes {
+				if have == want {
+					return
+				}
+			}
+		}
+
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"message": "missing scope"})
+	}
+}
+
+// testTokens are the tokens newTestRouter accepts, named after who holds them
+var testTokens = map[string]stubToken{
+	"alice":   {subject: "alice", scopes: []string{"write"}},
+	"bob":     {subject: "bob", scopes: []string{"write"}},
+	"admin":   {subject: "admin", scopes: []string{adminScope()}},
+	"reader":  {subject: "reader", scopes: []string{"read"}},
+	"creator": {subject: "creator", scopes: []string{"dnscontroller:create:record"}},
+	"zones":   {subject: "zones", scopes: []string{"dnscontroller:create:zone"}},
+	"noclaim": {scopes: []string{"write"}},
+}
+
+// testOwnerAuth has the owner checks on, callers are their sub
+var testOwnerAuth = OwnerAuth{Enabled: true, Claim: "sub"}
+
+// newTestRouter serves the v1 routes with the stub tokens, db may be nil for
+// requests that fail before they reach it
+func newTestRouter(db *sqlx.DB, oa OwnerAuth) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	e := gin.New()
+	New(&stubAuth{tokens: testTokens}, oa, db, zap.NewNop().Sugar()).Routes(e.Group(V1URI))
+
+	return e
+}
+
+// serve sends a request with the token to the router, the body is encoded
+// as JSON unless it is nil
+func serve(t *testing.T, e *gin.Engine, method, path, token string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var payload strings.Builder
+
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			t.Fatalf("encoding the body: %v", err)
+		}
+	}
+
+	req := httptest.NewRequest(method, V1URI+path, strings.NewReader(payload.String()))
+	req.Header.Set("Content-Type", "application/json")
+
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	w := httptest.NewRecorder()
+	e.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestRoutesAuth(t *testing.T) {
+	// The requests that get past the checks have an invalid name or body, so
+	// they fail before they need the database
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		token      string
+		wantStatus int
+	}{
+		{name: "no token", method: http.MethodGet, path: "/records", wantStatus: http.StatusUnauthorized},
+		{name: "unknown token", method: http.MethodGet, path: "/records", token: "forged", wantStatus: http.StatusUnauthorized},
+		{name: "no scope of the resource", method: http.MethodGet, path: "/records", token: "zones", wantStatus: http.StatusForbidden},
+		{name: "read scope writing", method: http.MethodPost, path: "/records/a..example.com./A", token: "reader", wantStatus: http.StatusForbidden},
+		{name: "write scope", method: http.MethodPost, path: "/records/a..example.com./A", token: "alice", wantStatus: http.StatusBadRequest},
+		{name: "resource scope", method: http.MethodPost, path: "/records/a..example.com./A", token: "creator", wantStatus: http.StatusBadRequest},
+		{name: "resource scope of another resource", method: http.MethodPost, path: "/records/a..example.com./A/answers", token: "creator", wantStatus: http.StatusForbidden},
+		{name: "admin scope", method: http.MethodDelete, path: "/records/a..example.com./A", token: "admin", wantStatus: http.StatusBadRequest},
+		{name: "batch without the delete scope", method: http.MethodPost, path: "/records:batch", token: "creator", wantStatus: http.StatusForbidden},
+		{name: "batch", method: http.MethodPost, path: "/records:batch", token: "alice", wantStatus: http.StatusBadRequest},
+	}
+
+	e := newTestRouter(nil, testOwnerAuth)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(t, e, tt.method, tt.path, tt.token, nil)
+			if w.Code != tt.wantStatus {
+				t.Errorf("%s %s status = %d, want %d: %s", tt.method, tt.path, w.Code, tt.wantStatus, w.Body)
+			}
+		})
+	}
+}
+
+func TestInvalidRecordResponse(t *testing.T) {
+	w := serve(t, newTestRouter(nil, testOwnerAuth), http.MethodPost, "/records/web-.example.com./A", "alice", nil)
+
+	resp := &recordResponse{}
+	if err := json.NewDecoder(w.Body).Decode(resp); err != nil {
+		t.Fatalf("decoding the response: %v", err)
+	}
+
+	if w.Code != http.StatusBadRequest || resp.Message != "invalid record name" || resp.Code != "invalid_hyphen" {
+		t.Errorf("response = %d %+v, want 400 with the invalid_hyphen code", w.Code, resp)
+	}
+}
+
+func TestAnswerWriteErrorResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "claimed by another caller", err: fmt.Errorf("upsert: %w", ox.ErrorNotOwner), wantStatus: http.StatusForbidden},
+		{name: "cname target", err: ax.ErrorCNAMETarget, wantStatus: http.StatusBadRequest},
+		{name: "cname conflict", err: rx.ErrorCNAMEConflict, wantStatus: http.StatusBadRequest},
+		{name: "precondition", err: rx.ErrorPreconditionFailed, wantStatus: http.StatusPreconditionFailed},
+		{name: "not found", err: sql.ErrNoRows, wantStatus: http.StatusNotFound},
+		{name: "datastore", err: errors.New("connection reset"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			answerWriteErrorResponse(c, tt.err)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package router
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+
+	"go.hollow.sh/dnscontroller/pkg/api/v1/watch"
+)
+
+// eventStreamMIME is the content type of server-sent events
+const eventStreamMIME = "text/event-stream"
+
+// getWatch streams the changes as server-sent events to callers accepting
+// them, and long-polls for them otherwise
+func (r *Router) getWatch(c *gin.Context) {
+	params, err := watch.NewParams(c)
+	if err != nil {
+		badRequestResponse(c, "invalid watch parameters", err)
+		return
+	}
+
+	if err := params.Start(c.Request.Context(), r.db); err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	if c.NegotiateFormat(gin.MIMEJSON, eventStreamMIME) == eventStreamMIME {
+		r.streamWatch(c, params)
+		return
+	}
+
+	events, err := watch.Wait(c.Request.Context(), r.db, params)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, &recordResponse{Version: params.Version, Records: events})
+}
+
+// streamWatch writes the changes as server-sent events until the timeout of
+// params passes, clients reconnect with the id of the last event they got.
+// Only the last event of a version has its id, so a stream cut halfway
+// through a transaction's changes resumes before them.
+func (r *Router) streamWatch(c *gin.Context, params *watch.Params) {
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Content-Type", eventStreamMIME)
+	c.Status(http.StatusOK)
+	c.Writer.WriteHeaderNow()
+	c.Writer.Flush()
+
+	ctx := c.Request.Context()
+
+	timeout := time.NewTimer(params.TimeoutDuration())
+	defer timeout.Stop()
+
+	poll := time.NewTicker(watch.PollInterval)
+	defer poll.Stop()
+
+	for {
+		events, err := watch.Next(ctx, r.db, params)
+		if err != nil {
+			// The status is sent already, the client reconnects
+			r.logger.Warnw("failed to read watch events", "error", err)
+			return
+		}
+
+		for i, e := range events {
+			event := sse.Event{Event: e.Name(), Data: e}
+			if i == len(events)-1 || events[i+1].Version != e.Version {
+				event.Id = e.Version
+			}
+
+			c.Render(-1, event)
+		}
+
+		c.Writer.Flush()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-timeout.C:
+			return
+		case <-poll.C:
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package router
+
+import (
+	"context"
+	"net/http"
+	"path"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+
+	wx "go.hollow.sh/dnscontroller/pkg/api/v1/webhooks"
+)
+
+func (r *Router) getWebhooks(c *gin.Context) {
+	webhooks, err := wx.List(c.Request.Context(), r.db)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, webhooks)
+}
+
+func (r *Router) getWebhook(c *gin.Context) {
+	id, err := wx.ParseUUID(c)
+	if err != nil {
+		badRequestResponse(c, wx.ErrorInvalidWebhook.Error(), err)
+		return
+	}
+
+	webhook := &wx.Webhook{UUID: id}
+	if err := webhook.FindByUUID(c.Request.Context(), r.db); err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+// createWebhook answers with the stored webhook, its id is generated
+func (r *Router) createWebhook(c *gin.Context) {
+	webhook, err := wx.NewWebhook(c)
+	if err != nil {
+		badRequestResponse(c, wx.ErrorInvalidWebhook.Error(), err)
+		return
+	}
+
+	if err := webhook.Create(c.Request.Context(), r.db); err != nil {
+		badRequestResponse(c, wx.ErrorInvalidWebhook.Error(), err)
+		return
+	}
+
+	uri := path.Join(uriWithoutQueryParams(c), webhook.UUID.String())
+
+	c.Header("Location", uri)
+	c.JSON(http.StatusCreated, &recordResponse{
+		Message: "resource created",
+		Slug:    webhook.UUID.String(),
+		Links:   &recordResponseLinks{Self: &link{Href: uri}},
+		Record:  webhook,
+	})
+}
+
+func (r *Router) updateWebhook(c *gin.Context) {
+	webhook, err := wx.NewWebhook(c)
+	if err != nil {
+		badRequestResponse(c, wx.ErrorInvalidWebhook.Error(), err)
+		return
+	}
+
+	if err := webhook.Update(c.Request.Context(), r.db); err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	updatedResponse(c, webhook.UUID.String())
+}
+
+func (r *Router) deleteWebhook(c *gin.Context) {
+	id, err := wx.ParseUUID(c)
+	if err != nil {
+		badRequestResponse(c, wx.ErrorInvalidWebhook.Error(), err)
+		return
+	}
+
+	webhook := &wx.Webhook{UUID: id}
+	if err := webhook.Delete(c.Request.Context(), r.db); err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	deletedResponse(c)
+}
+
+func (r *Router) getWebhookDeliveries(c *gin.Context) {
+	r.listDeliveries(c, (*wx.Webhook).Deliveries)
+}
+
+func (r *Router) getWebhookDeadLetters(c *gin.Context) {
+	r.listDeliveries(c, (*wx.Webhook).DeadLetters)
+}
+
+// listDeliveries writes the page of deliveries of the webhook in the URL that
+// list returns
+func (r *Router) listDeliveries(c *gin.Context, list func(*wx.Webhook, context.Context, *sqlx.DB, *wx.ListParams) ([]*wx.Delivery, int64, error)) {
+	id, err := wx.ParseUUID(c)
+	if err != nil {
+		badRequestResponse(c, wx.ErrorInvalidWebhook.Error(), err)
+		return
+	}
+
+	params, err := wx.NewListParams(c)
+	if err != nil {
+		badRequestResponse(c, "invalid list parameters", err)
+		return
+	}
+
+	deliveries, count, err := list(&wx.Webhook{UUID: id}, c.Request.Context(), r.db, params)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	listResponse(c, deliveries, paginationData{
+		pageCount:  len(deliveries),
+		totalPages: params.TotalPages(count),
+		totalCount: count,
+		page:       params.Page,
+		pageSize:   params.PageSize,
+	})
+}
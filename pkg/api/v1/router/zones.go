@@ -0,0 +1,84 @@
+package router
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	zx "go.hollow.sh/dnscontroller/pkg/api/v1/zones"
+)
+
+func (r *Router) getZones(c *gin.Context) {
+	zones, err := zx.List(c.Request.Context(), r.db)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, zones)
+}
+
+func (r *Router) getZone(c *gin.Context) {
+	zone, err := zx.NewZone(c)
+	if err != nil {
+		badRequestResponse(c, zx.ErrorInvalidZone.Error(), err)
+		return
+	}
+
+	if err := zone.Find(c.Request.Context(), r.db); err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, zone)
+}
+
+func (r *Router) createZone(c *gin.Context) {
+	zone, err := zx.NewZone(c)
+	if err != nil {
+		badRequestResponse(c, zx.ErrorInvalidZone.Error(), err)
+		return
+	}
+
+	if err := zone.Create(c.Request.Context(), r.db); err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	createdResponse(c)
+}
+
+func (r *Router) deleteZone(c *gin.Context) {
+	zone, err := zx.NewZone(c)
+	if err != nil {
+		badRequestResponse(c, zx.ErrorInvalidZone.Error(), err)
+		return
+	}
+
+	if err := zone.Delete(c.Request.Context(), r.db); err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	deletedResponse(c)
+}
+
+// zoneFileContentType is the media type of master files (RFC 4027)
+const zoneFileContentType = "text/dns"
+
+func (r *Router) exportZone(c *gin.Context) {
+	zone, err := zx.NewZone(c)
+	if err != nil {
+		badRequestResponse(c, zx.ErrorInvalidZone.Error(), err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := zone.Export(c.Request.Context(), r.db, &buf); err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.Data(http.StatusOK, zoneFileContentType, buf.Bytes())
+}
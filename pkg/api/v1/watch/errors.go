@@ -0,0 +1,14 @@
+package watch
+
+import "errors"
+
+var (
+	// ErrorInvalidVersion is when the version to resume from isn't one a
+	// watch returned
+	ErrorInvalidVersion = errors.New("invalid resource version")
+	// ErrorInvalidOwnerID is when the owner filter isn't a uuid
+	ErrorInvalidOwnerID = errors.New("invalid owner id")
+	// ErrorInvalidTimeout is when the timeout isn't a positive number of
+	// seconds
+	ErrorInvalidTimeout = errors.New("timeout must be a positive number of seconds")
+)
@@ -0,0 +1,335 @@
+// Package watch reads the changes made to records and answers. Changes come
+// from the audit log, which is written in the transaction of each change, so a
+// change is seen once it is committed and never when it is rolled back.
+//
+// Every change has the resource version of its transaction, the commit
+// timestamp in nanoseconds. Changes are read in version order, and only up to
+// the timestamp of the read, so a transaction committing afterwards always has
+// a later version than the ones already read and resuming after the last
+// version read misses nothing.
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"go.hollow.sh/dnscontroller/internal/models"
+	"go.hollow.sh/dnscontroller/pkg/api/v1/audit"
+	record "go.hollow.sh/dnscontroller/pkg/api/v1/records"
+)
+
+// Kinds of the changed resources
+const (
+	KindRecord = "record"
+	KindAnswer = "answer"
+)
+
+// Types of the changes
+const (
+	TypeCreate = "create"
+	TypeUpdate = "update"
+	TypeDelete = "delete"
+)
+
+const (
+	// LastEventIDHeader is sent by SSE clients reconnecting, it has the version
+	// to resume from
+	LastEventIDHeader = "Last-Event-ID"
+
+	// DefaultTimeout is how long a watch waits for changes when the request
+	// doesn't say
+	DefaultTimeout = 15 * time.Second
+	// MaxTimeout is the longest a watch waits for changes, it is below the
+	// write timeout of the api server
+	MaxTimeout = 15 * time.Second
+	// PollInterval is how often a watch looks for new changes
+	PollInterval = time.Second
+
+	// maxEvents is the number of changes read at once, the changes of a
+	// transaction are never split so a read can return more
+	maxEvents = 1000
+)
+
+// Names are the names of the events a watch returns
+var Names = []string{
+	KindRecord + "." + TypeCreate,
+	KindRecord + "." + TypeDelete,
+	KindAnswer + "." + TypeCreate,
+	KindAnswer + "." + TypeUpdate,
+	KindAnswer + "." + TypeDelete,
+}
+
+// changes are the audit operations a watch returns, an expired answer is
+// deleted as far as watchers are concerned
+var changes = map[string]struct{ kind, typ string }{
+	audit.RecordCreate: {KindRecord, TypeCreate},
+	audit.RecordDelete: {KindRecord, TypeDelete},
+	audit.AnswerCreate: {KindAnswer, TypeCreate},
+	audit.AnswerUpdate: {KindAnswer, TypeUpdate},
+	audit.AnswerDelete: {KindAnswer, TypeDelete},
+	audit.AnswerExpire: {KindAnswer, TypeDelete},
+}
+
+// Event is a change to a record or answer. Object is the resource as the API
+// returns it after the change, or before it for deletions.
+type Event struct {
+	Version    string          `json:"version"`
+	UUID       uuid.UUID       `json:"uuid"`
+	Kind       string          `json:"kind"`
+	Type       string          `json:"type"`
+	Record     string          `json:"record"`
+	RecordType string          `json:"record_type"`
+	OwnerID    string          `json:"owner_id,omitempty"`
+	Object     json.RawMessage `json:"object,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// Name returns the kind and type of the event, like answer.update
+func (e *Event) Name() string {
+	return e.Kind + "." + e.Type
+}
+
+// Params filter a watch and say where it resumes from. Version is the last
+// version the caller has seen, the watch starts from now without one.
+type Params struct {
+	Version string `form:"version"`
+	Zone    string `form:"zone"`
+	Owner   string `form:"owner"`
+	Timeout int    `form:"timeout"`
+	version int64
+	timeout time.Duration
+}
+
+// NewParams creates watch params from the URL query and validates them, the
+// version falls back to the Last-Event-ID header
+func NewParams(c *gin.Context) (*Params, error) {
+	p := &Params{}
+	if err := c.ShouldBindQuery(p); err != nil {
+		return nil, err
+	}
+
+	if p.Version == "" {
+		p.Version = c.GetHeader(LastEventIDHeader)
+	}
+
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Validate ensures the params are valid and normalizes the zone
+func (p *Params) Validate() error {
+	if p.Version != "" {
+		v, err := strconv.ParseInt(p.Version, 10, 64)
+		if err != nil || v < 0 {
+			return ErrorInvalidVersion
+		}
+
+		p.version = v
+	}
+
+	// Names are stored fully qualified and lowercase
+	if p.Zone != "" {
+		p.Zone = strings.ToLower(p.Zone)
+
+		if !strings.HasSuffix(p.Zone, ".") {
+			p.Zone += "."
+		}
+	}
+
+	if p.Owner != "" {
+		if _, err := uuid.Parse(p.Owner); err != nil {
+			return ErrorInvalidOwnerID
+		}
+	}
+
+	switch {
+	case p.Timeout < 0:
+		return ErrorInvalidTimeout
+	case p.Timeout == 0:
+		p.timeout = DefaultTimeout
+	default:
+		p.timeout = time.Duration(p.Timeout) * time.Second
+	}
+
+	if p.timeout > MaxTimeout {
+		p.timeout = MaxTimeout
+	}
+
+	return nil
+}
+
+// TimeoutDuration returns how long the watch waits for changes
+func (p *Params) TimeoutDuration() time.Duration {
+	return p.timeout
+}
+
+// ResourceVersion returns the version the params resume after
+func (p *Params) ResourceVersion() int64 {
+	return p.version
+}
+
+// Start sets the version of params without one to the current version, so
+// only the changes committed from now on are returned
+func (p *Params) Start(ctx context.Context, db *sqlx.DB) error {
+	if p.Version != "" {
+		return nil
+	}
+
+	v, err := Current(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	p.version = v
+	p.Version = strconv.FormatInt(v, 10)
+
+	return nil
+}
+
+// Current returns the version to resume after for the changes committed from
+// now on
+func Current(ctx context.Context, q sqlx.QueryerContext) (int64, error) {
+	var v int64
+
+	// A transaction committing after this read can share its wall time, the
+	// changes at it are returned
+	err := sqlx.GetContext(ctx, q, &v, "SELECT cluster_logical_timestamp()::INT8 - 1")
+
+	return v, err
+}
+
+func (p *Params) queryMods() []qm.QueryMod {
+	operations := make([]string, 0, len(changes))
+	for op := range changes {
+		operations = append(operations, op)
+	}
+
+	sort.Strings(operations)
+
+	mods := []qm.QueryMod{
+		models.AuditEventWhere.Operation.IN(operations),
+		// Changes at or after the timestamp of the read may still commit
+		qm.Where("version < cluster_logical_timestamp()::INT8"),
+	}
+
+	if p.Zone != "" {
+		mods = append(mods, record.QMInZone(p.Zone))
+	}
+
+	if p.Owner != "" {
+		mods = append(mods, qm.Where("owner_id=?", p.Owner))
+	}
+
+	return mods
+}
+
+// Next returns the changes after the version of params in version order, and
+// moves the version of params past them
+func Next(ctx context.Context, db *sqlx.DB, p *Params) ([]*Event, error) {
+	mods := append(p.queryMods(),
+		qm.Where("version>?", p.version),
+		qm.OrderBy("version, id"),
+		qm.Limit(maxEvents),
+	)
+
+	dbEvents, err := models.AuditEvents(mods...).All(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resuming after the last version would skip the rest of its changes
+	if len(dbEvents) == maxEvents {
+		last := dbEvents[len(dbEvents)-1]
+
+		rest, err := models.AuditEvents(append(p.queryMods(),
+			qm.Where("version=?", last.Version),
+			qm.Where("id>?", last.ID),
+			qm.OrderBy("id"),
+		)...).All(ctx, db)
+		if err != nil {
+			return nil, err
+		}
+
+		dbEvents = append(dbEvents, rest...)
+	}
+
+	events := make([]*Event, 0, len(dbEvents))
+
+	for _, dbEvent := range dbEvents {
+		e := &Event{}
+		if err := e.FromDBModel(dbEvent); err != nil {
+			return nil, err
+		}
+
+		events = append(events, e)
+	}
+
+	if len(dbEvents) > 0 {
+		p.version = dbEvents[len(dbEvents)-1].Version.Int64
+		p.Version = strconv.FormatInt(p.version, 10)
+	}
+
+	return events, nil
+}
+
+// Wait returns the next changes, polling until there are any or the timeout of
+// params passes
+func Wait(ctx context.Context, db *sqlx.DB, p *Params) ([]*Event, error) {
+	timeout := time.NewTimer(p.timeout)
+	defer timeout.Stop()
+
+	poll := time.NewTicker(PollInterval)
+	defer poll.Stop()
+
+	for {
+		events, err := Next(ctx, db, p)
+		if err != nil || len(events) > 0 {
+			return events, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return events, ctx.Err()
+		case <-timeout.C:
+			return events, nil
+		case <-poll.C:
+		}
+	}
+}
+
+// FromDBModel converts a db type to an api type
+func (e *Event) FromDBModel(dbT *models.AuditEvent) error {
+	change := changes[dbT.Operation]
+
+	e.Version = strconv.FormatInt(dbT.Version.Int64, 10)
+	e.Kind = change.kind
+	e.Type = change.typ
+	e.Record = dbT.Record.String
+	e.RecordType = dbT.RecordType.String
+	e.OwnerID = dbT.OwnerID.String
+	e.CreatedAt = dbT.CreatedAt
+
+	if change.typ == TypeDelete {
+		e.Object = json.RawMessage(dbT.Before.JSON)
+	} else {
+		e.Object = json.RawMessage(dbT.After.JSON)
+	}
+
+	var err error
+
+	e.UUID, err = uuid.Parse(dbT.ID)
+
+	return err
+}
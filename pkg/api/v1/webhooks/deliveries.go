@@ -0,0 +1,199 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"go.hollow.sh/dnscontroller/internal/models"
+)
+
+const (
+	// DefaultPageSize is used when a list request doesn't specify a page size
+	DefaultPageSize = 100
+	// MaxPageSize is the largest page a list request can ask for
+	MaxPageSize = 1000
+)
+
+// ListParams filter and paginate the deliveries of a webhook, deliveries are
+// listed newest first
+type ListParams struct {
+	Status   string `form:"status"`
+	Page     int    `form:"page"`
+	PageSize int    `form:"page_size"`
+}
+
+// NewListParams creates list params from the URL query and validates them
+func NewListParams(c *gin.Context) (*ListParams, error) {
+	p := &ListParams{}
+	if err := c.ShouldBindQuery(p); err != nil {
+		return nil, err
+	}
+
+	switch p.Status {
+	case "", StatusPending, StatusDelivered, StatusDead:
+	default:
+		return nil, ErrorInvalidStatus
+	}
+
+	if p.Page < 1 {
+		p.Page = 1
+	}
+
+	if p.PageSize < 1 {
+		p.PageSize = DefaultPageSize
+	}
+
+	if p.PageSize > MaxPageSize {
+		p.PageSize = MaxPageSize
+	}
+
+	return p, nil
+}
+
+// TotalPages returns the number of pages needed for count deliveries
+func (p *ListParams) TotalPages(count int64) int {
+	return int((count + int64(p.PageSize) - 1) / int64(p.PageSize))
+}
+
+func (p *ListParams) pageMods() []qm.QueryMod {
+	return []qm.QueryMod{
+		qm.OrderBy("created_at DESC, id"),
+		qm.Limit(p.PageSize),
+		qm.Offset((p.Page - 1) * p.PageSize),
+	}
+}
+
+// Deliveries returns a page of the delivery history of the webhook, along
+// with the total number of matching deliveries
+func (w *Webhook) Deliveries(ctx context.Context, db *sqlx.DB, p *ListParams) ([]*Delivery, int64, error) {
+	if err := w.FindByUUID(ctx, db); err != nil {
+		return nil, 0, err
+	}
+
+	mods := []qm.QueryMod{models.WebhookDeliveryWhere.WebhookID.EQ(w.UUID.String())}
+
+	if p.Status != "" {
+		mods = append(mods, models.WebhookDeliveryWhere.Status.EQ(p.Status))
+	}
+
+	count, err := models.WebhookDeliveries(mods...).Count(ctx, db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	dbDeliveries, err := models.WebhookDeliveries(append(mods, p.pageMods()...)...).All(ctx, db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	deliveries := make([]*Delivery, 0, len(dbDeliveries))
+
+	for _, dbDelivery := range dbDeliveries {
+		d := &Delivery{}
+		if err := d.FromDBModel(dbDelivery); err != nil {
+			return nil, 0, err
+		}
+
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, count, nil
+}
+
+// DeadLetters returns a page of the deliveries of the webhook that failed
+// every attempt, along with their total number. The status of the params is
+// ignored.
+func (w *Webhook) DeadLetters(ctx context.Context, db *sqlx.DB, p *ListParams) ([]*Delivery, int64, error) {
+	if err := w.FindByUUID(ctx, db); err != nil {
+		return nil, 0, err
+	}
+
+	mods := []qm.QueryMod{models.WebhookDeadLetterWhere.WebhookID.EQ(w.UUID.String())}
+
+	count, err := models.WebhookDeadLetters(mods...).Count(ctx, db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	dbLetters, err := models.WebhookDeadLetters(append(mods, p.pageMods()...)...).All(ctx, db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	letters := make([]*Delivery, 0, len(dbLetters))
+
+	for _, dbLetter := range dbLetters {
+		d := &Delivery{}
+		if err := d.FromDeadLetter(dbLetter); err != nil {
+			return nil, 0, err
+		}
+
+		letters = append(letters, d)
+	}
+
+	return letters, count, nil
+}
+
+// DeadLetter returns the dead letter of a delivery that failed every attempt
+func DeadLetter(dbT *models.WebhookDelivery) *models.WebhookDeadLetter {
+	return &models.WebhookDeadLetter{
+		ID:             dbT.ID,
+		WebhookID:      dbT.WebhookID,
+		EventID:        dbT.EventID,
+		Event:          dbT.Event,
+		Payload:        dbT.Payload,
+		Attempts:       dbT.Attempts,
+		LastStatusCode: dbT.LastStatusCode,
+		LastError:      dbT.LastError,
+	}
+}
+
+// FromDBModel converts a db type to an api type
+func (d *Delivery) FromDBModel(dbT *models.WebhookDelivery) error {
+	d.Event = dbT.Event
+	d.Payload = json.RawMessage(dbT.Payload)
+	d.Status = dbT.Status
+	d.Attempts = dbT.Attempts
+	d.NextAttemptAt = dbT.NextAttemptAt.Ptr()
+	d.LastStatusCode = dbT.LastStatusCode.Ptr()
+	d.LastError = dbT.LastError.String
+	d.DeliveredAt = dbT.DeliveredAt.Ptr()
+	d.CreatedAt = dbT.CreatedAt
+
+	return d.parseIDs(dbT.ID, dbT.WebhookID, dbT.EventID)
+}
+
+// FromDeadLetter converts a dead letter db type to an api type, it is created
+// when the delivery failed its last attempt
+func (d *Delivery) FromDeadLetter(dbT *models.WebhookDeadLetter) error {
+	d.Event = dbT.Event
+	d.Payload = json.RawMessage(dbT.Payload)
+	d.Status = StatusDead
+	d.Attempts = dbT.Attempts
+	d.LastStatusCode = dbT.LastStatusCode.Ptr()
+	d.LastError = dbT.LastError.String
+	d.CreatedAt = dbT.CreatedAt
+
+	return d.parseIDs(dbT.ID, dbT.WebhookID, dbT.EventID)
+}
+
+func (d *Delivery) parseIDs(id, webhookID, eventID string) error {
+	var err error
+
+	if d.UUID, err = uuid.Parse(id); err != nil {
+		return err
+	}
+
+	if d.WebhookID, err = uuid.Parse(webhookID); err != nil {
+		return err
+	}
+
+	d.EventID, err = uuid.Parse(eventID)
+
+	return err
+}
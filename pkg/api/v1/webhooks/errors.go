@@ -0,0 +1,21 @@
+package webhook
+
+import "errors"
+
+var (
+	// ErrorInvalidWebhook is a generic invalid response
+	ErrorInvalidWebhook = errors.New("invalid webhook format")
+	// ErrorInvalidWebhookID is when the webhook in the URL isn't a uuid
+	ErrorInvalidWebhookID = errors.New("invalid webhook id")
+	// ErrorInvalidURL is when the url of a webhook isn't an absolute http or
+	// https url
+	ErrorInvalidURL = errors.New("webhook url must be an absolute http or https url")
+	// ErrorNoSecret is when a new webhook doesn't have a secret
+	ErrorNoSecret = errors.New("no webhook secret")
+	// ErrorInvalidEvent is when an event filter isn't the name of a change,
+	// like answer.update
+	ErrorInvalidEvent = errors.New("invalid webhook event")
+	// ErrorInvalidStatus is when the status filter isn't pending, delivered or
+	// dead
+	ErrorInvalidStatus = errors.New("invalid delivery status")
+)
@@ -0,0 +1,40 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// Headers of the requests sent to webhooks
+const (
+	// SignatureHeader has the HMAC-SHA256 of the body keyed by the secret of
+	// the webhook, as sha256=<hex>
+	SignatureHeader = "X-Dnscontroller-Signature"
+	// EventHeader has the name of the change, like answer.update
+	EventHeader = "X-Dnscontroller-Event"
+	// DeliveryHeader has the id of the delivery, it is the same for every
+	// attempt so receivers can drop duplicates
+	DeliveryHeader = "X-Dnscontroller-Delivery"
+
+	signaturePrefix = "sha256="
+)
+
+// Sign returns the signature of the body for the signature header
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether the signature header is the signature of the body,
+// receivers check it before trusting a request
+func Verify(secret string, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+
+	return hmac.Equal([]byte(signature), []byte(Sign(secret, body)))
+}
@@ -0,0 +1,49 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Statuses of a delivery
+const (
+	StatusPending   = "pending"
+	StatusDelivered = "delivered"
+	// StatusDead is a delivery that failed every attempt, it is copied to the
+	// dead letters
+	StatusDead = "dead"
+)
+
+// Webhook is the API model for a webhook subscription. The changes to records
+// and answers matching its events, zone and owner are POSTed to its URL,
+// signed with its secret. Every change matches an empty filter, and the
+// secret is never returned.
+type Webhook struct {
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret,omitempty"`
+	Events    []string  `json:"events,omitempty"`
+	Zone      string    `json:"zone,omitempty"`
+	Owner     string    `json:"owner,omitempty"`
+	UUID      uuid.UUID `json:"uuid"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Delivery is the API model for a change sent, or to be sent, to a webhook.
+// Payload is the body POSTed, the change as a watch returns it.
+type Delivery struct {
+	UUID           uuid.UUID       `json:"uuid"`
+	WebhookID      uuid.UUID       `json:"webhook_id"`
+	EventID        uuid.UUID       `json:"event_id"`
+	Event          string          `json:"event"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int64           `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	LastStatusCode *int64          `json:"last_status_code,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
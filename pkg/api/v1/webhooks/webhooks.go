@@ -0,0 +1,267 @@
+// Package webhook wraps the CRUD operations for a models.Webhook and its
+// deliveries. The deliveries themselves are made by the notifier.
+package webhook
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach-go/v2/crdb/crdbsqlx"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"go.hollow.sh/dnscontroller/internal/models"
+	owner "go.hollow.sh/dnscontroller/pkg/api/v1/owners"
+	"go.hollow.sh/dnscontroller/pkg/api/v1/watch"
+)
+
+// eventSeparator joins the event filters of a webhook in the db
+const eventSeparator = ","
+
+// List returns all the webhooks
+func List(ctx context.Context, db *sqlx.DB) ([]*Webhook, error) {
+	dbWebhooks, err := models.Webhooks(qm.OrderBy(models.WebhookColumns.CreatedAt)).All(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks := make([]*Webhook, 0, len(dbWebhooks))
+
+	for _, dbWebhook := range dbWebhooks {
+		w := &Webhook{}
+		if err := w.FromDBModel(dbWebhook); err != nil {
+			return nil, err
+		}
+
+		webhooks = append(webhooks, w)
+	}
+
+	return webhooks, nil
+}
+
+// FindByUUID looks the webhook up by its id
+func (w *Webhook) FindByUUID(ctx context.Context, exec boil.ContextExecutor) error {
+	dbWebhook, err := models.FindWebhook(ctx, exec, w.UUID.String())
+	if err != nil {
+		return err
+	}
+
+	return w.FromDBModel(dbWebhook)
+}
+
+// Create stores the webhook, it is sent the changes committed from now on
+func (w *Webhook) Create(ctx context.Context, db *sqlx.DB) error {
+	if w.Secret == "" {
+		return ErrorNoSecret
+	}
+
+	return crdbsqlx.ExecuteTx(ctx, db, nil, func(tx *sqlx.Tx) error {
+		dbWebhook := w.ToDBModel()
+
+		var err error
+
+		if dbWebhook.Version, err = watch.Current(ctx, tx); err != nil {
+			return err
+		}
+
+		if err := dbWebhook.Insert(ctx, tx, boil.Infer()); err != nil {
+			return err
+		}
+
+		return w.FromDBModel(dbWebhook)
+	})
+}
+
+// Update replaces the url and filters of the webhook with the given id, and
+// its secret when one is set. The changes already delivered aren't sent
+// again.
+func (w *Webhook) Update(ctx context.Context, db *sqlx.DB) error {
+	return crdbsqlx.ExecuteTx(ctx, db, nil, func(tx *sqlx.Tx) error {
+		dbWebhook, err := models.FindWebhook(ctx, tx, w.UUID.String())
+		if err != nil {
+			return err
+		}
+
+		update := w.ToDBModel()
+
+		dbWebhook.URL = update.URL
+		dbWebhook.Events = update.Events
+		dbWebhook.Zone = update.Zone
+		dbWebhook.OwnerID = update.OwnerID
+
+		if w.Secret != "" {
+			dbWebhook.Secret = w.Secret
+		}
+
+		if _, err := dbWebhook.Update(ctx, tx, boil.Infer()); err != nil {
+			return err
+		}
+
+		return w.FromDBModel(dbWebhook)
+	})
+}
+
+// Delete removes the webhook, its deliveries and dead letters are removed by
+// the cascade
+func (w *Webhook) Delete(ctx context.Context, db *sqlx.DB) error {
+	return crdbsqlx.ExecuteTx(ctx, db, nil, func(tx *sqlx.Tx) error {
+		if err := w.FindByUUID(ctx, tx); err != nil {
+			return err
+		}
+
+		_, err := w.ToDBModel().Delete(ctx, tx)
+
+		return err
+	})
+}
+
+// WatchParams returns the watch params of the changes the webhook is sent,
+// resuming after the version
+func (w *Webhook) WatchParams(version int64) (*watch.Params, error) {
+	p := &watch.Params{Version: strconv.FormatInt(version, 10), Zone: w.Zone, Owner: w.Owner}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Matches reports whether the webhook is sent the event, the zone and owner
+// are filtered by the watch
+func (w *Webhook) Matches(e *watch.Event) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+
+	for _, name := range w.Events {
+		if name == e.Name() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FromDBModel converts a db type to an api type, the secret is left out
+func (w *Webhook) FromDBModel(dbT *models.Webhook) error {
+	w.URL = dbT.URL
+	w.Secret = ""
+	w.Events = nil
+	w.Zone = dbT.Zone.String
+	w.Owner = dbT.OwnerID.String
+	w.CreatedAt = dbT.CreatedAt
+	w.UpdatedAt = dbT.UpdatedAt
+
+	if dbT.Events != "" {
+		w.Events = strings.Split(dbT.Events, eventSeparator)
+	}
+
+	var err error
+
+	w.UUID, err = uuid.Parse(dbT.ID)
+
+	return err
+}
+
+// ToDBModel converts the api type to db type
+func (w *Webhook) ToDBModel() *models.Webhook {
+	dbModel := &models.Webhook{
+		URL:       w.URL,
+		Secret:    w.Secret,
+		Events:    strings.Join(w.Events, eventSeparator),
+		Zone:      null.NewString(w.Zone, w.Zone != ""),
+		OwnerID:   null.NewString(w.Owner, w.Owner != ""),
+		CreatedAt: w.CreatedAt,
+		UpdatedAt: w.UpdatedAt,
+	}
+
+	if w.UUID.String() != uuid.Nil.String() {
+		dbModel.ID = w.UUID.String()
+	}
+
+	return dbModel
+}
+
+// NewWebhook creates a webhook from the request body and validates it, the
+// webhook id is taken from the URL params when present
+func NewWebhook(c *gin.Context) (*Webhook, error) {
+	w := &Webhook{}
+	if err := c.ShouldBindJSON(w); err != nil {
+		return nil, err
+	}
+
+	w.UUID = uuid.Nil
+
+	if c.Param("webhook") != "" {
+		id, err := ParseUUID(c)
+		if err != nil {
+			return nil, err
+		}
+
+		w.UUID = id
+	}
+
+	if err := w.Validate(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// ParseUUID returns the webhook id from the URL params
+func ParseUUID(c *gin.Context) (uuid.UUID, error) {
+	id, err := uuid.Parse(c.Param("webhook"))
+	if err != nil {
+		return uuid.Nil, ErrorInvalidWebhookID
+	}
+
+	return id, nil
+}
+
+// Validate ensures the url and filters of the webhook are valid, and
+// normalizes the zone
+func (w *Webhook) Validate() error {
+	u, err := url.Parse(w.URL)
+	if err != nil || !u.IsAbs() || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return ErrorInvalidURL
+	}
+
+	for _, name := range w.Events {
+		if !validEvent(name) {
+			return ErrorInvalidEvent
+		}
+	}
+
+	// Names are stored fully qualified and lowercase
+	if w.Zone != "" {
+		w.Zone = strings.ToLower(w.Zone)
+
+		if !strings.HasSuffix(w.Zone, ".") {
+			w.Zone += "."
+		}
+	}
+
+	if w.Owner != "" {
+		if _, err := uuid.Parse(w.Owner); err != nil {
+			return owner.ErrorInvalidOwnerID
+		}
+	}
+
+	return nil
+}
+
+func validEvent(name string) bool {
+	for _, n := range watch.Names {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
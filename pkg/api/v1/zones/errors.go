@@ -0,0 +1,10 @@
+package zone
+
+import "errors"
+
+var (
+	// ErrorInvalidZone is a generic invalid response
+	ErrorInvalidZone = errors.New("invalid zone format")
+	// ErrorNoZoneName is when a request / zone doesn't have a name
+	ErrorNoZoneName = errors.New("no zone name")
+)
@@ -0,0 +1,69 @@
+package zone
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/miekg/dns"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"go.hollow.sh/dnscontroller/internal/models"
+	answer "go.hollow.sh/dnscontroller/pkg/api/v1/answers"
+)
+
+// Export writes the served answers of the records linked to the zone as an
+// RFC 1035 master file. The SOA is the one serve-dns answers with its
+// defaults. Only stored NS are written, the default NS serve-dns answers with
+// when the apex has none would otherwise be stored by an import.
+func (z *Zone) Export(ctx context.Context, db *sqlx.DB, w io.Writer) error {
+	if err := z.Find(ctx, db); err != nil {
+		return err
+	}
+
+	dbRecords, err := models.Records(
+		qm.Where("zone_id=?", z.UUID.String()),
+		qm.Load(models.RecordRels.Answers, answer.QMServed(), qm.OrderBy(models.AnswerColumns.Target)),
+		qm.Load(models.RecordRels.Answers+"."+models.AnswerRels.AnswerDetail),
+		qm.OrderBy(models.RecordColumns.Record+", "+models.RecordColumns.RecordType),
+	).All(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	rrs := []dns.RR{SOA(z.Name, "", "", z.Serial, DefaultNegativeTTL)}
+
+	for _, dbRecord := range dbRecords {
+		for _, dbAnswer := range dbRecord.R.Answers {
+			a := &answer.Answer{}
+			if err := a.FromDBModel(dbAnswer); err != nil {
+				return err
+			}
+
+			rr, err := a.RR(dbRecord.Record)
+			if err != nil {
+				return err
+			}
+
+			rrs = append(rrs, rr)
+		}
+	}
+
+	return z.write(w, rrs)
+}
+
+// write writes the resource records as a master file of the zone
+func (z *Zone) write(w io.Writer, rrs []dns.RR) error {
+	if _, err := fmt.Fprintf(w, "$ORIGIN %s\n", z.Name); err != nil {
+		return err
+	}
+
+	for _, rr := range rrs {
+		if _, err := fmt.Fprintln(w, rr.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
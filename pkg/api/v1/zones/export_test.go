@@ -0,0 +1,107 @@
+package zone
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/miekg/dns"
+
+	answer "go.hollow.sh/dnscontroller/pkg/api/v1/answers"
+)
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func TestExportParse(t *testing.T) {
+	type recordAnswer struct {
+		name   string
+		answer *answer.Answer
+	}
+
+	stored := []recordAnswer{
+		{"example.com.", &answer.Answer{Type: "CAA", Target: `letsencrypt.org; validationmethods="dns-01"`, TTL: 3600, Flags: int64Ptr(0), Tag: "issue"}},
+		{"example.com.", &answer.Answer{Type: "MX", Target: "mail.example.com.", TTL: 300, Priority: int64Ptr(10)}},
+		{"example.com.", &answer.Answer{Type: "NS", Target: "ns1.example.net.", TTL: 3600}},
+		{"example.com.", &answer.Answer{Type: "TXT", Target: `v=spf1 "quoted" \ -all`, TTL: 300}},
+		{"example.com.", &answer.Answer{Type: "TXT", Target: "café\ttab", TTL: 300}},
+		{"long.example.com.", &answer.Answer{Type: "TXT", Target: strings.Repeat("a", 300), TTL: 300}},
+		{"www.example.com.", &answer.Answer{Type: "A", Target: "192.0.2.1", TTL: 60}},
+		{"www.example.com.", &answer.Answer{Type: "AAAA", Target: "2001:db8::1", TTL: 60}},
+		{"alias.example.com.", &answer.Answer{Type: "CNAME", Target: "www.example.com.", TTL: 300}},
+		{"_web._tcp.example.com.", &answer.Answer{Type: "SRV", Target: "web1.example.com.", TTL: 60, Priority: int64Ptr(10), Weight: int64Ptr(20), Port: int64Ptr(443)}},
+	}
+
+	z := &Zone{Name: "example.com.", Serial: 42}
+	rrs := []dns.RR{SOA(z.Name, "", "", z.Serial, DefaultNegativeTTL)}
+
+	for _, ra := range stored {
+		rr, err := ra.answer.RR(ra.name)
+		if err != nil {
+			t.Fatalf("RR() of %s %s error = %v", ra.name, ra.answer.Type, err)
+		}
+
+		rrs = append(rrs, rr)
+	}
+
+	var file bytes.Buffer
+	if err := z.write(&file, rrs); err != nil {
+		t.Fatalf("write() error = %v", err)
+	}
+
+	var skipped bytes.Buffer
+
+	parsed, err := Parse(&file, "", "example.com.zone", &skipped)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if skipped.Len() != 0 {
+		t.Errorf("Parse() skipped %q", skipped.String())
+	}
+
+	if len(parsed) != len(stored) {
+		t.Fatalf("Parse() returned %d records, want %d", len(parsed), len(stored))
+	}
+
+	for i, rr := range parsed {
+		got, err := answer.NewAnswerFromRR(rr)
+		if err != nil {
+			t.Fatalf("NewAnswerFromRR(%s) error = %v", rr, err)
+		}
+
+		if rr.Header().Name != stored[i].name || !reflect.DeepEqual(got, stored[i].answer) {
+			t.Errorf("record %d = %s %+v, want %s %+v", i, rr.Header().Name, got, stored[i].name, stored[i].answer)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	file := `$TTL 300
+@	IN	SOA	ns.example.com. hostmaster.example.com. 1 3600 600 604800 300
+www	IN	A	192.0.2.1
+	IN	HINFO	"x86" "linux"
+`
+
+	var skipped bytes.Buffer
+
+	rrs, err := Parse(strings.NewReader(file), "example.com", "example.com.zone", &skipped)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if len(rrs) != 1 || rrs[0].String() != "www.example.com.\t300\tIN\tA\t192.0.2.1" {
+		t.Errorf("Parse() = %v, want the A record only", rrs)
+	}
+
+	if !strings.Contains(skipped.String(), "HINFO") {
+		t.Errorf("Parse() skipped = %q, want the HINFO noted", skipped.String())
+	}
+
+	if _, err := Parse(strings.NewReader("www IN A not-an-ip\n"), "example.com", "bad.zone", io.Discard); err == nil {
+		t.Errorf("Parse() of a bad file didn't fail")
+	}
+}
@@ -0,0 +1,42 @@
+package zone
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/miekg/dns"
+
+	answer "go.hollow.sh/dnscontroller/pkg/api/v1/answers"
+)
+
+// Parse returns the resource records of a master file that can be stored as
+// answers, the inverse of Export. The origin is used for relative names until
+// the file sets $ORIGIN. SOA records are dropped, and the records that can't
+// be answers are noted on skipped.
+func Parse(r io.Reader, origin, file string, skipped io.Writer) ([]dns.RR, error) {
+	if origin != "" {
+		origin = dns.Fqdn(origin)
+	}
+
+	zp := dns.NewZoneParser(r, origin, file)
+	rrs := []dns.RR{}
+
+	for rr, ok := zp.Next(); ok; rr, ok = zp.Next() {
+		if rr.Header().Rrtype == dns.TypeSOA {
+			continue
+		}
+
+		if _, err := answer.NewAnswerFromRR(rr); err != nil {
+			fmt.Fprintf(skipped, "skipping %s: %v\n", rr, err)
+			continue
+		}
+
+		rrs = append(rrs, rr)
+	}
+
+	if err := zp.Err(); err != nil {
+		return nil, err
+	}
+
+	return rrs, nil
+}
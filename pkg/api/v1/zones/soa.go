@@ -0,0 +1,57 @@
+package zone
+
+import (
+	"context"
+
+	"github.com/miekg/dns"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"go.hollow.sh/dnscontroller/internal/models"
+	answer "go.hollow.sh/dnscontroller/pkg/api/v1/answers"
+)
+
+// DefaultNegativeTTL is the SOA minimum when none is configured
+const DefaultNegativeTTL uint32 = 300
+
+var (
+	// soaRefresh, soaRetry and soaExpire are only used by secondaries
+	soaRefresh uint32 = 3600
+	soaRetry   uint32 = 600
+	soaExpire  uint32 = 604800
+)
+
+// SOA returns the SOA of a zone, the nameserver and mailbox default to
+// ns.<zone> and hostmaster.<zone>
+func SOA(zone, nameserver, mailbox string, serial, negativeTTL uint32) *dns.SOA {
+	if nameserver == "" {
+		nameserver = "ns." + zone
+	}
+
+	if mailbox == "" {
+		mailbox = "hostmaster." + zone
+	}
+
+	return &dns.SOA{
+		Hdr:     dns.RR_Header{Name: zone, Rrtype: dns.TypeSOA, Class: dns.ClassINET, Ttl: uint32(answer.DefaultTTL)},
+		Ns:      dns.Fqdn(nameserver),
+		Mbox:    dns.Fqdn(mailbox),
+		Serial:  serial,
+		Refresh: soaRefresh,
+		Retry:   soaRetry,
+		Expire:  soaExpire,
+		Minttl:  negativeTTL,
+	}
+}
+
+// Serial returns the SOA serial of a zone. Every change to a record linked to
+// the zone increments the serial in the transaction of the change, it wraps
+// around as RFC 1982 serials do.
+func Serial(ctx context.Context, exec boil.ContextExecutor, name string) (uint32, error) {
+	dbZone, err := models.Zones(qm.Where("name=?", name)).One(ctx, exec)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint32(dbZone.Serial), nil
+}
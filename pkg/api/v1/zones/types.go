@@ -0,0 +1,20 @@
+package zone
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Zone is the API model for a zone, records are linked to the longest zone
+// their name is in. Zones created for a record are removed once they have no
+// more answers. The serial is the SOA serial of the zone.
+type Zone struct {
+	Name        string     `json:"name"`
+	AutoCreated bool       `json:"auto_created"`
+	Serial      uint32     `json:"serial"`
+	EmptySince  *time.Time `json:"empty_since,omitempty"`
+	UUID        uuid.UUID  `json:"uuid"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
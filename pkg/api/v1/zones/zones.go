@@ -0,0 +1,170 @@
+// Package zone wraps the CRUD operations for a models.Zone
+package zone
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/cockroachdb/cockroach-go/v2/crdb/crdbsqlx"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"go.hollow.sh/dnscontroller/internal/models"
+	record "go.hollow.sh/dnscontroller/pkg/api/v1/records"
+)
+
+// List returns all the zones
+func List(ctx context.Context, db *sqlx.DB) ([]*Zone, error) {
+	dbZones, err := models.Zones(qm.OrderBy(models.ZoneColumns.Name)).All(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	zones := make([]*Zone, 0, len(dbZones))
+
+	for _, dbZone := range dbZones {
+		z := &Zone{}
+		if err := z.FromDBModel(dbZone); err != nil {
+			return nil, err
+		}
+
+		zones = append(zones, z)
+	}
+
+	return zones, nil
+}
+
+// Find looks the zone up by name
+func (z *Zone) Find(ctx context.Context, exec boil.ContextExecutor) error {
+	dbZone, err := models.Zones(qm.Where("name=?", z.Name)).One(ctx, exec)
+	if err != nil {
+		return err
+	}
+
+	return z.FromDBModel(dbZone)
+}
+
+// Create stores the zone and links the records in it. Creating a zone that
+// was created for a record adopts it, so it is no longer garbage collected.
+func (z *Zone) Create(ctx context.Context, db *sqlx.DB) error {
+	return crdbsqlx.ExecuteTx(ctx, db, nil, func(tx *sqlx.Tx) error {
+		dbZone := z.ToDBModel()
+		dbZone.AutoCreated = false
+
+		update := boil.Whitelist(models.ZoneColumns.AutoCreated, models.ZoneColumns.EmptySince, models.ZoneColumns.UpdatedAt)
+
+		if err := dbZone.Upsert(ctx, tx, true, []string{models.ZoneColumns.Name}, update, boil.Infer()); err != nil {
+			return err
+		}
+
+		if err := record.RelinkZone(ctx, tx, z.Name); err != nil {
+			return err
+		}
+
+		return z.Find(ctx, tx)
+	})
+}
+
+// FindOrCreate stores the zone and links the records in it unless it exists.
+// A zone that exists is left as it is, one created for a record is still
+// garbage collected.
+func (z *Zone) FindOrCreate(ctx context.Context, db *sqlx.DB) error {
+	return crdbsqlx.ExecuteTx(ctx, db, nil, func(tx *sqlx.Tx) error {
+		err := z.Find(ctx, tx)
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		dbZone := z.ToDBModel()
+		dbZone.AutoCreated = false
+
+		// A zone created concurrently makes this a no-op
+		if err := dbZone.Upsert(ctx, tx, false, []string{models.ZoneColumns.Name}, boil.None(), boil.Infer()); err != nil {
+			return err
+		}
+
+		if err := record.RelinkZone(ctx, tx, z.Name); err != nil {
+			return err
+		}
+
+		return z.Find(ctx, tx)
+	})
+}
+
+// Delete removes the zone, its records are linked to the next longest zone
+// they are in
+func (z *Zone) Delete(ctx context.Context, db *sqlx.DB) error {
+	return crdbsqlx.ExecuteTx(ctx, db, nil, func(tx *sqlx.Tx) error {
+		if err := z.Find(ctx, tx); err != nil {
+			return err
+		}
+
+		if _, err := z.ToDBModel().Delete(ctx, tx); err != nil {
+			return err
+		}
+
+		return record.RelinkZone(ctx, tx, z.Name)
+	})
+}
+
+// FromDBModel converts a db type to an api type
+func (z *Zone) FromDBModel(dbT *models.Zone) error {
+	z.Name = dbT.Name
+	z.AutoCreated = dbT.AutoCreated
+	z.Serial = uint32(dbT.Serial)
+	z.EmptySince = dbT.EmptySince.Ptr()
+	z.CreatedAt = dbT.CreatedAt
+	z.UpdatedAt = dbT.UpdatedAt
+
+	var err error
+
+	z.UUID, err = uuid.Parse(dbT.ID)
+
+	return err
+}
+
+// ToDBModel converts the api type to db type
+func (z *Zone) ToDBModel() *models.Zone {
+	dbModel := &models.Zone{
+		Name:        z.Name,
+		AutoCreated: z.AutoCreated,
+		CreatedAt:   z.CreatedAt,
+		UpdatedAt:   z.UpdatedAt,
+	}
+
+	if z.UUID.String() != uuid.Nil.String() {
+		dbModel.ID = z.UUID.String()
+	}
+
+	return dbModel
+}
+
+// NewZone creates a zone from the URL params, or the request body when there
+// are none, and validates it
+func NewZone(c *gin.Context) (*Zone, error) {
+	z := &Zone{Name: c.Param("zone")}
+
+	if z.Name == "" {
+		if err := c.ShouldBindJSON(z); err != nil {
+			return nil, err
+		}
+	}
+
+	if z.Name == "" {
+		return nil, ErrorNoZoneName
+	}
+
+	name, err := record.CanonicalName(z.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	z.Name = name
+	z.UUID = uuid.Nil
+
+	return z, nil
+}